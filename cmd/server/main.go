@@ -3,14 +3,25 @@ package main
 import (
 	"fmt"
 	"log"
+	"os"
 
 	"kokka.com/kokka/internal/app"
 )
 
 func main() {
-	if err := run(); err != nil {
+	// server migrate <command>
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			log.Println(err)
+			os.Exit(1)
+		}
 		return
 	}
+
+	if err := run(); err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
 }
 
 func run() error {
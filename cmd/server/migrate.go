@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"kokka.com/kokka/internal/driven-adapter/database"
+	"kokka.com/kokka/internal/shared/config"
+)
+
+const migrateUsage = `usage: server migrate <command>
+
+commands:
+  up [N]         apply all (or the next N) pending migrations, waiting for any other migrator
+  down [N]       revert the last (or the last N) applied migrations
+  status         list migrations and whether they are applied
+  create NAME    create empty up/down migration files`
+
+// runMigrate runs the migrate subcommand
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%s", migrateUsage)
+	}
+
+	// create only touches files, it does not need a database
+	if args[0] == "create" {
+		if len(args) != 2 {
+			return fmt.Errorf("%s", migrateUsage)
+		}
+		dir := "migrations"
+		if env, err := config.NewEnv(".env"); err == nil {
+			dir = env.DBEnv.MigrationsDir
+		}
+		upPath, downPath, err := database.CreateMigration(dir, args[1])
+		if err != nil {
+			return fmt.Errorf("failed to create migration: %w", err)
+		}
+		fmt.Printf("Created migration files:\n   -> %s\n   -> %s\n", upPath, downPath)
+		return nil
+	}
+
+	env, err := config.NewEnv(".env")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if env.DBEnv.Name == "" {
+		return fmt.Errorf("DB_NAME is not configured")
+	}
+
+	db, err := database.Open(env.DBEnv)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// Interrupting stops waiting for another migrator; a migration in progress is rolled back
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	migrator := database.NewMigrator(db, env.DBEnv.MigrationsDir)
+
+	switch args[0] {
+	case "up", "down":
+		n := 0
+		if len(args) > 1 {
+			if n, err = strconv.Atoi(args[1]); err != nil || n < 1 {
+				return fmt.Errorf("N must be a positive number")
+			}
+		}
+
+		var done []*database.Migration
+		if args[0] == "up" {
+			done, err = migrator.Up(ctx, n)
+		} else {
+			done, err = migrator.Down(ctx, n)
+		}
+		for _, migration := range done {
+			fmt.Printf("--> %s: %s_%s\n", args[0], migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(done) == 0 {
+			fmt.Println("Nothing to migrate.")
+		}
+		return nil
+
+	case "status":
+		migrations, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		if len(migrations) == 0 {
+			fmt.Println("No migrations found.")
+		}
+		for _, migration := range migrations {
+			state := "pending"
+			if migration.AppliedAt != nil {
+				state = "applied " + migration.AppliedAt.Format(time.RFC3339)
+			}
+			if migration.UpPath == "" {
+				state += " (missing on disk)"
+			}
+			fmt.Printf("%s_%s\t%s\n", migration.Version, migration.Name, state)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("%s", migrateUsage)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"kokka.com/kokka/internal/signerd"
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Printf("kokka-signer: %v", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	listen := flag.String("listen", envOr("SIGNER_LISTEN", "unix:///tmp/kokka-signer.sock"), "unix:///path/to/socket or host:port")
+	keystoreDir := flag.String("keystore", envOr("SIGNER_KEYSTORE_DIR", "keys/keystore"), "go-ethereum keystore directory")
+	passphraseFile := flag.String("passphrase-file", os.Getenv("SIGNER_PASSPHRASE_FILE"), "file containing the keystore passphrase")
+	policyFile := flag.String("policy", os.Getenv("SIGNER_POLICY_FILE"), "signer policy file")
+	authTokenFile := flag.String("auth-token-file", os.Getenv("SIGNER_AUTH_TOKEN_FILE"), "file containing the bearer token callers must present")
+	flag.Parse()
+
+	if *passphraseFile == "" {
+		return fmt.Errorf("-passphrase-file is required")
+	}
+	passphrase, err := readSecretFile(*passphraseFile)
+	if err != nil {
+		return err
+	}
+
+	if *policyFile == "" {
+		return fmt.Errorf("-policy is required")
+	}
+	policy, err := signerd.LoadPolicy(*policyFile)
+	if err != nil {
+		return err
+	}
+
+	var authToken string
+	if *authTokenFile != "" {
+		authToken, err = readSecretFile(*authTokenFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	server, err := signerd.NewServer(&signerd.Config{
+		Listen:      *listen,
+		KeystoreDir: *keystoreDir,
+		Passphrase:  passphrase,
+		AuthToken:   authToken,
+		Policy:      policy,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	return server.ListenAndServe(ctx)
+}
+
+func envOr(key, defaultValue string) string {
+	if val := os.Getenv(key); val != "" {
+		return val
+	}
+	return defaultValue
+}
+
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
@@ -1,18 +1,27 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/i247app/gex"
 	"kokka.com/kokka/internal/app/resources"
 	"kokka.com/kokka/internal/app/routes"
 	"kokka.com/kokka/internal/app/services"
+	"kokka.com/kokka/internal/applications/policy"
+	diSvc "kokka.com/kokka/internal/core/di/services"
+	"kokka.com/kokka/internal/driven-adapter/database"
 	"kokka.com/kokka/internal/handlers/http/middleware"
 	"kokka.com/kokka/internal/shared/config"
 	"kokka.com/kokka/internal/shared/constant/status"
+	"kokka.com/kokka/internal/shared/lifecycle"
+	"kokka.com/kokka/internal/shared/logger"
+	"kokka.com/kokka/internal/shared/redact"
 	"kokka.com/kokka/internal/shared/response"
+	"kokka.com/kokka/internal/shared/tracing"
 )
 
 func NewFromEnv(envPath string) (*App, error) {
@@ -33,9 +42,64 @@ func NewFromEnv(envPath string) (*App, error) {
 	if env.HostConfig.HttpsKeyFile != nil {
 		hostConfig.HttpsKeyFile = *env.HostConfig.HttpsKeyFile
 	}
+
+	// Opt-in: send the kstatus as the HTTP status as well
+	response.UseHTTPStatus(env.HostConfig.HTTPStatusCodes)
+
+	// Redact secrets from request, response and HTTP client logs
+	redactor, err := redact.New(env.RedactionConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up log redaction: %w", err)
+	}
+	redact.SetDefault(redactor)
+
+	// Open the shared log output (file rotation, format and level)
+	if err := logger.Setup(env.LogConfig); err != nil {
+		return nil, fmt.Errorf("failed to set up logging: %w", err)
+	}
+
+	// Load authorisation policy (optional)
+	var accessPolicy *policy.Policy
+	if env.PolicyFile != "" {
+		accessPolicy, err = policy.LoadFile(env.PolicyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load policy: %w", err)
+		}
+	} else {
+		logger.Warn("POLICY_FILE is not set: /admin/*, /wallet/*, /journal/*, /diagnostics and /metrics are refused and custodial wallets cannot sign")
+	}
+
+	// Open database (optional - the transaction journal is disabled without DB_NAME)
+	var db *database.DB
+	if env.DBEnv != nil && env.DBEnv.Name != "" {
+		db, err = database.Open(env.DBEnv)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open database: %w", err)
+		}
+
+		// Refuse to start against an outdated schema
+		if err := checkMigrations(db, env.DBEnv); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+
+	// Set up tracing (spans are only recorded when TRACING_EXPORTER is set)
+	tracingProvider, err := tracing.Setup(env.TracingConfig)
+	if err != nil {
+		if db != nil {
+			db.Close()
+		}
+		return nil, fmt.Errorf("failed to set up tracing: %w", err)
+	}
+
 	resources := resources.AppResource{
 		Env:        env,
 		HostConfig: hostConfig,
+		Policy:     accessPolicy,
+		DB:         db,
+		Drainer:    lifecycle.NewDrainer(),
+		Tracing:    tracingProvider,
 	}
 
 	app := NewApp(&resources)
@@ -48,6 +112,23 @@ func NewFromEnv(envPath string) (*App, error) {
 	return app, nil
 }
 
+// checkMigrations fails when migrations are pending, unless ALLOW_PENDING_MIGRATIONS is set
+func checkMigrations(db *database.DB, cfg *config.DBConfig) error {
+	pending, err := database.NewMigrator(db, cfg.MigrationsDir).Pending(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to check migrations: %w", err)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if cfg.AllowPendingMigrations {
+		logger.Warn("starting with %d pending migration(s)", len(pending))
+		return nil
+	}
+	return fmt.Errorf("%d pending migration(s), run `server migrate up` or set ALLOW_PENDING_MIGRATIONS=true", len(pending))
+}
+
 func (a *App) Init() error {
 	services, err := services.SetupServiceContainer(a.Resource)
 	if err != nil {
@@ -64,6 +145,9 @@ func (a *App) Init() error {
 	a.setupMiddleware(a.Server, services)
 
 	// Setup jobs
+	if err := a.setupJobs(services); err != nil {
+		return fmt.Errorf("failed to setup jobs: %w", err)
+	}
 
 	// Setup shutdown hooks
 	a.setupShutdownHooks(a.Server, services)
@@ -77,16 +161,135 @@ func (a *App) Start() error {
 	return a.Server.Start()
 }
 
-func (a *App) setupShutdownHooks(gexServer *gex.Server, _ *services.ServiceContainer) {
+func (a *App) setupShutdownHooks(gexServer *gex.Server, services *services.ServiceContainer) {
+	gexServer.OnShutdown(func() {
+		a.shutdown(services)
+	})
+}
+
+// shutdown runs on SIGINT/SIGTERM while the listener is still open
+// New writes are refused and readiness reports draining; in-flight writes and running jobs
+// get until the shutdown deadline to finish before the server closes
+func (a *App) shutdown(services *services.ServiceContainer) {
+	timeout := time.Duration(a.Resource.Env.HostConfig.ShutdownTimeoutSeconds) * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
+	logger.Info("shutdown: draining in-flight requests (deadline %s)", timeout)
+	if remaining := a.Resource.Drainer.Drain(ctx); remaining > 0 {
+		logger.Warn("shutdown: deadline reached with %d requests still in flight", remaining)
+	}
+
+	logger.Info("shutdown: stopping jobs")
+	services.JobService.Stop(ctx)
+
+	if err := a.Resource.Tracing.Shutdown(ctx); err != nil {
+		logger.Warn("shutdown: failed to flush traces: %v", err)
+	}
+	logger.Info("shutdown: done")
+}
+
+// Setup background jobs
+func (a *App) setupJobs(services *services.ServiceContainer) error {
+	cfg := a.Resource.Env.JobsConfig
+	timeout := time.Duration(cfg.TimeoutSeconds) * time.Second
+	jobs := services.JobService
+
+	var specs []jobSpec
+
+	if services.MonitorService != nil {
+		specs = append(specs,
+			jobSpec{"confirmations", cfg.ConfirmationsSchedule, func(ctx context.Context) (string, error) {
+				result, err := services.MonitorService.PollConfirmations(ctx)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("checked %d, confirmed %d, reverted %d", result.Checked, result.Confirmed, result.Reverted), nil
+			}},
+			jobSpec{"reconcile", cfg.ReconcileSchedule, func(ctx context.Context) (string, error) {
+				result, err := services.MonitorService.Reconcile(ctx)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("checked %d, dropped %d, stale pending %v", result.Checked, result.Dropped, result.StalePending), nil
+			}},
+		)
+	}
+
+	if services.IndexerService != nil {
+		specs = append(specs, jobSpec{"index_transfers", cfg.IndexerSchedule, func(ctx context.Context) (string, error) {
+			result, err := services.IndexerService.IndexTransfers(ctx)
+			if err != nil {
+				return "", err
+			}
+			if result.ToBlock < result.FromBlock {
+				return "up to date", nil
+			}
+			return fmt.Sprintf("blocks %d-%d, %d transfers", result.FromBlock, result.ToBlock, result.Transfers), nil
+		}})
+	}
+
+	if services.SweeperService != nil {
+		schedule := fmt.Sprintf("@every %ds", a.Resource.Env.SweeperConfig.IntervalSeconds)
+		specs = append(specs, jobSpec{"sweep", schedule, func(ctx context.Context) (string, error) {
+			result, err := services.SweeperService.Sweep(ctx)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("checked %d deposit wallets, %d transfers", result.Checked, len(result.Transfers)), nil
+		}})
+	}
+
+	// Drop expired Idempotency-Key records, old job history and old RPC audit entries
+	specs = append(specs, jobSpec{"housekeeping", cfg.HousekeepingSchedule, func(ctx context.Context) (string, error) {
+		keys, err := services.IdempotencyService.PurgeExpired(ctx)
+		if err != nil {
+			return "", err
+		}
+		runs, err := jobs.PruneRuns(ctx, time.Duration(cfg.HistoryDays)*24*time.Hour)
+		if err != nil {
+			return "", err
+		}
+		calls, err := services.RPCAuditService.Prune(ctx, time.Duration(a.Resource.Env.RPCProxyConfig.AuditRetentionDays)*24*time.Hour)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("purged %d idempotency keys, %d job runs, %d rpc audit entries", keys, runs, calls), nil
+	}})
+
+	for _, spec := range specs {
+		if spec.schedule == jobScheduleOff {
+			continue
+		}
+		if err := jobs.Register(spec.name, spec.schedule, timeout, spec.run); err != nil {
+			return err
+		}
+	}
+
+	jobs.Start()
+	return nil
+}
+
+// jobScheduleOff disables a job in JOB_*_SCHEDULE
+const jobScheduleOff = "off"
+
+// jobSpec is a background job to register
+type jobSpec struct {
+	name     string
+	schedule string
+	run      diSvc.JobFunc
 }
 
 // Setup middlewares
 func (a *App) setupMiddleware(gexSvr *gex.Server, services *services.ServiceContainer) {
 	middlewares := []gex.Middleware{
 		// Start-->
-		middleware.LoggerMiddleware(a.Resource.Env.LogFile),
+		middleware.MetricsMiddleware,
+		middleware.TracingMiddleware,
+		middleware.LoggerMiddleware,
 		middleware.LogRequestMiddleware,
+		middleware.ClientAuthMiddleware(a.Resource.Policy),
+		middleware.RouteMiddleware,
 		// -->End
 	}
 
@@ -99,5 +302,15 @@ func (a *App) setupMiddleware(gexSvr *gex.Server, services *services.ServiceCont
 }
 
 func (a *App) Close() error {
-	return nil
+	if a.Services != nil {
+		a.Services.JobService.Stop(context.Background())
+	}
+	a.Resource.Tracing.Shutdown(context.Background())
+
+	var err error
+	if a.Resource.DB != nil {
+		err = a.Resource.DB.Close()
+	}
+	logger.Close() // Last, so shutdown messages reach the log file
+	return err
 }
@@ -2,10 +2,18 @@ package resources
 
 import (
 	"github.com/i247app/gex"
+	"kokka.com/kokka/internal/applications/policy"
+	"kokka.com/kokka/internal/driven-adapter/database"
 	"kokka.com/kokka/internal/shared/config"
+	"kokka.com/kokka/internal/shared/lifecycle"
+	"kokka.com/kokka/internal/shared/tracing"
 )
 
 type AppResource struct {
 	Env        *config.Env
 	HostConfig gex.HostConfig
+	Policy     *policy.Policy // nil when no POLICY_FILE is configured
+	DB         *database.DB   // nil when no DB_NAME is configured
+	Drainer    *lifecycle.Drainer
+	Tracing    *tracing.Provider
 }
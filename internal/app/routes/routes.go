@@ -5,6 +5,8 @@ import (
 	"kokka.com/kokka/internal/app/resources"
 	"kokka.com/kokka/internal/app/services"
 	"kokka.com/kokka/internal/handlers/http/controller"
+	"kokka.com/kokka/internal/handlers/http/middleware"
+	"kokka.com/kokka/internal/shared/metrics"
 )
 
 func SetUpHttpRoutes(server *gex.Server, res *resources.AppResource, services *services.ServiceContainer) {
@@ -16,6 +18,19 @@ func SetUpHttpRoutes(server *gex.Server, res *resources.AppResource, services *s
 	server.AddRoute("GET /goboard/index.css", staticCtrl.ServeFile("index.css"))
 	server.AddRoute("GET /goboard/helper.js", staticCtrl.ServeFile("helper.js"))
 
+	// health routes (probes are public, diagnostics and metrics are for operators; see policy.example.json)
+	health := controller.NewHealthController(services.HealthService)
+	server.AddRoute("GET /healthz", health.HandleHealthz)
+	server.AddRoute("GET /readyz", health.HandleReadyz)
+	server.AddRoute("GET /diagnostics", health.HandleDiagnostics)
+	server.AddRoute("GET /metrics", metrics.Handler().ServeHTTP)
+
+	// Writes are tracked so shutdown can wait for them, and refused once it starts
+	drain := middleware.DrainMiddleware(res.Drainer)
+
+	// Writes that sign accept an Idempotency-Key header and replay the first response on retry
+	idempotent := middleware.IdempotencyMiddleware(services.IdempotencyService)
+
 	// blockchain routes
 	bc := controller.NewBlockchainController(services.BlockchainService)
 	// GET endpoints
@@ -29,16 +44,21 @@ func SetUpHttpRoutes(server *gex.Server, res *resources.AppResource, services *s
 	server.AddRoute("POST /blockchain/transaction", bc.GetTransaction)
 	server.AddRoute("POST /blockchain/call", bc.CallContract)
 	server.AddRoute("POST /blockchain/estimate-gas", bc.EstimateGas)
-	server.AddRoute("POST /blockchain/send-transaction", bc.SendRawTransaction)
-	server.AddRoute("POST /blockchain/sign-and-send", bc.SignAndSendTransaction)
-	server.AddRoute("POST /blockchain/rpc", bc.GenericRPCCall)
+	server.AddRoute("POST /blockchain/send-transaction", bc.SendRawTransaction, drain)
+	server.AddRoute("POST /blockchain/sign-and-send", bc.SignAndSendTransaction, drain, idempotent)
+	server.AddRoute("POST /blockchain/sign", bc.SignTransaction, drain, idempotent)
+	server.AddRoute("POST /blockchain/build", bc.BuildTransaction)
+	server.AddRoute("POST /blockchain/sign-message", bc.SignMessage, drain)
+	server.AddRoute("POST /blockchain/sign-typed-data", bc.SignTypedData, drain)
+	server.AddRoute("POST /blockchain/verify-signature", bc.VerifySignature)
+	server.AddRoute("POST /blockchain/rpc", bc.GenericRPCCall, drain)
 
 	// token routes (supports VNDX, SGDX, YEXN, etc.)
 	token := controller.NewTokenController(services.TokenService)
 	// POST endpoints
-	server.AddRoute("POST /token/mint", token.HandleMintToken)
-	server.AddRoute("POST /token/burn", token.HandleBurnToken)
-	server.AddRoute("POST /token/transfer", token.HandleTransferToken)
+	server.AddRoute("POST /token/mint", token.HandleMintToken, drain, idempotent)
+	server.AddRoute("POST /token/burn", token.HandleBurnToken, drain, idempotent)
+	server.AddRoute("POST /token/transfer", token.HandleTransferToken, drain, idempotent)
 	server.AddRoute("POST /token/contract-address-info", token.HandleGetContractAddressInfo)
 
 	// GET endpoints
@@ -50,7 +70,48 @@ func SetUpHttpRoutes(server *gex.Server, res *resources.AppResource, services *s
 	// swap routes (supports SGPX <-> VNDX, YENX <-> VNDX , etc.)
 	swap := controller.NewSwapController(services.SwapService)
 	// POST endpoints
-	server.AddRoute("POST /swap", swap.HandleSwap)
+	server.AddRoute("POST /swap", swap.HandleSwap, drain, idempotent)
 	server.AddRoute("POST /swap/quote", swap.HandleGetSwapQuote)
 	server.AddRoute("POST /swap/info", swap.HandleGetSwapInfo)
+
+	// wallet routes (server-managed custodial wallets)
+	wallet := controller.NewWalletController(services.WalletService)
+	// GET endpoints
+	server.AddRoute("GET /wallet/list", wallet.HandleListWallets)
+	server.AddRoute("GET /wallet/derive/address", wallet.HandleResolveDerivedAddress)
+
+	// POST endpoints
+	server.AddRoute("POST /wallet/create", wallet.HandleCreateWallet, drain)
+	server.AddRoute("POST /wallet/import", wallet.HandleImportWallet, drain)
+	server.AddRoute("POST /wallet/register", wallet.HandleRegisterWallet, drain)
+	server.AddRoute("POST /wallet/derive", wallet.HandleDeriveWallet, drain)
+	server.AddRoute("POST /wallet/info", wallet.HandleGetWallet)
+
+	// journal routes (kokka-initiated writes)
+	journal := controller.NewJournalController(services.JournalService)
+	server.AddRoute("GET /journal/list", journal.HandleListTransactions)
+	server.AddRoute("POST /journal/info", journal.HandleGetTransaction)
+
+	// admin routes (grant only to an operator role in the policy file)
+	keys := controller.NewKeyController(services.KeyService)
+	server.AddRoute("GET /admin/keys", keys.HandleGetKeyringInfo)
+	server.AddRoute("POST /admin/keys/rewrap", keys.HandleRewrapKeys, drain)
+
+	logs := controller.NewLogController(services.LogService)
+	server.AddRoute("GET /admin/log-level", logs.HandleGetLogLevel)
+	server.AddRoute("POST /admin/log-level", logs.HandleSetLogLevel)
+
+	rpcAudit := controller.NewRPCAuditController(services.RPCAuditService)
+	server.AddRoute("GET /admin/rpc-audit", rpcAudit.HandleListEntries)
+
+	rpcCache := controller.NewRPCCacheController(services.RPCCacheService)
+	server.AddRoute("GET /admin/rpc-cache", rpcCache.HandleGetStats)
+	server.AddRoute("POST /admin/rpc-cache/purge", rpcCache.HandlePurge)
+
+	jobs := controller.NewJobController(services.JobService)
+	server.AddRoute("GET /admin/jobs", jobs.HandleListJobs)
+	server.AddRoute("GET /admin/jobs/runs", jobs.HandleListJobRuns)
+	server.AddRoute("POST /admin/jobs/trigger", jobs.HandleTriggerJob, drain)
+	server.AddRoute("POST /admin/jobs/pause", jobs.HandlePauseJob)
+	server.AddRoute("POST /admin/jobs/resume", jobs.HandleResumeJob)
 }
@@ -1,19 +1,46 @@
 package services
 
 import (
+	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"kokka.com/kokka/internal/app/resources"
+	"kokka.com/kokka/internal/applications/policy"
 	"kokka.com/kokka/internal/applications/services"
 	"kokka.com/kokka/internal/applications/validators"
+	diRepo "kokka.com/kokka/internal/core/di/repositories"
 	diSvc "kokka.com/kokka/internal/core/di/services"
+	"kokka.com/kokka/internal/core/domain"
 	"kokka.com/kokka/internal/driven-adapter/external/blockchain"
+	"kokka.com/kokka/internal/driven-adapter/repository"
+	"kokka.com/kokka/internal/driven-adapter/storage"
+	"kokka.com/kokka/internal/shared/logger"
+	"kokka.com/kokka/internal/shared/utils"
 )
 
 type ServiceContainer struct {
-	BlockchainService diSvc.IBlockChainService
-	TokenService      diSvc.ITokenService
-	SwapService       diSvc.ISwapService
+	BlockchainService  diSvc.IBlockChainService
+	TokenService       diSvc.ITokenService
+	SwapService        diSvc.ISwapService
+	WalletService      diSvc.IWalletService
+	SweeperService     diSvc.ISweeperService // nil unless the sweeper is enabled
+	KeyService         diSvc.IKeyService
+	JournalService     diSvc.IJournalService
+	IdempotencyService diSvc.IIdempotencyService
+	RPCAuditService    diSvc.IRPCAuditService
+	RPCCacheService    diSvc.IRPCCacheService
+	JobService         diSvc.IJobService
+	MonitorService     diSvc.ITransactionMonitorService // nil without a database
+	IndexerService     diSvc.IIndexerService            // nil unless INDEXER_TOKENS is set
+	HealthService      diSvc.IHealthService
+	LogService         diSvc.ILogService
 }
 
 func SetupServiceContainer(res *resources.AppResource) (*ServiceContainer, error) {
@@ -22,18 +49,209 @@ func SetupServiceContainer(res *resources.AppResource) (*ServiceContainer, error
 	if res.Env.BlockchainConfig != nil && res.Env.BlockchainConfig.RPCURL != "" {
 		blockchainConfig = blockchainConfig.WithBaseURL(res.Env.BlockchainConfig.RPCURL)
 	}
+	if cfg := res.Env.BlockchainConfig; cfg != nil {
+		if cfg.MaxRetries < 0 || cfg.RetryDelayMillis <= 0 || cfg.RetryMaxDelayMillis < cfg.RetryDelayMillis {
+			return nil, fmt.Errorf("BLOCKCHAIN_RPC_MAX_RETRIES must not be negative and BLOCKCHAIN_RPC_RETRY_DELAY_MS must be positive and at most BLOCKCHAIN_RPC_RETRY_MAX_DELAY_MS")
+		}
+		blockchainConfig = blockchainConfig.WithRetry(
+			cfg.MaxRetries,
+			time.Duration(cfg.RetryDelayMillis)*time.Millisecond,
+			time.Duration(cfg.RetryMaxDelayMillis)*time.Millisecond,
+		)
+	}
+	if cfg := res.Env.RPCCacheConfig; cfg != nil && cfg.Enabled {
+		if cfg.MaxEntries <= 0 || cfg.FinalityDepth <= 0 || cfg.HeadRefreshMillis <= 0 {
+			return nil, fmt.Errorf("RPC_CACHE_MAX_ENTRIES, RPC_CACHE_FINALITY_DEPTH and RPC_CACHE_HEAD_REFRESH_MS must be positive")
+		}
+		blockchainConfig = blockchainConfig.WithCache(blockchain.CacheConfig{
+			MaxEntries:    cfg.MaxEntries,
+			FinalityDepth: uint64(cfg.FinalityDepth),
+			HeadRefresh:   time.Duration(cfg.HeadRefreshMillis) * time.Millisecond,
+		})
+	}
 	blockchainClient := blockchain.NewClient(blockchainConfig)
 
+	// Initialize wallet store (optional - custodial wallets are disabled without a master key)
+	// Wallets live in the database when there is one, so every instance and the sweeper share them;
+	// the WALLET_STORE_PATH file is only used without a database
+	var walletRepo diRepo.IWalletRepository
+	var walletMasterKey []byte
+	if res.Env.WalletConfig != nil && res.Env.WalletConfig.MasterKey != "" {
+		masterKey, err := hex.DecodeString(res.Env.WalletConfig.MasterKey)
+		if err != nil || len(masterKey) != 32 {
+			return nil, fmt.Errorf("WALLET_MASTER_KEY must be a hex-encoded 32-byte key")
+		}
+		walletStore, err := storage.NewWalletFileStore(res.Env.WalletConfig.StorePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize wallet store: %w", err)
+		}
+		walletRepo = walletStore
+		if res.DB != nil {
+			walletRepository := repository.NewWalletRepository(res.DB)
+			if err := importWallets(context.Background(), walletStore, walletRepository); err != nil {
+				return nil, fmt.Errorf("failed to import %s into the database: %w", res.Env.WalletConfig.StorePath, err)
+			}
+			walletRepo = walletRepository
+		}
+		walletMasterKey = masterKey
+	}
+
+	// Initialize transaction journal (disabled without a database)
+	var transactionRepo diRepo.ITransactionRepository
+	if res.DB != nil {
+		transactionRepo = repository.NewTransactionRepository(res.DB)
+	}
+	journalService := services.NewJournalService(validators.NewJournalValidator(), transactionRepo)
+
+	// Initialize idempotency store (in memory without a database, so keys do not survive a restart)
+	var idempotencyRepo diRepo.IIdempotencyRepository = storage.NewIdempotencyMemoryStore()
+	if res.DB != nil {
+		idempotencyRepo = repository.NewIdempotencyRepository(res.DB)
+	}
+	idempotencyTTL := time.Duration(res.Env.IdempotencyConfig.TTLHours) * time.Hour
+	if idempotencyTTL <= 0 {
+		return nil, fmt.Errorf("IDEMPOTENCY_TTL_HOURS must be positive")
+	}
+	idempotencyService := services.NewIdempotencyService(validators.NewIdempotencyValidator(), idempotencyRepo, idempotencyTTL)
+
+	// Initialize job scheduler (locks are only local to this instance without a database)
+	var jobRepo diRepo.IJobRepository = storage.NewJobMemoryStore()
+	if res.DB != nil {
+		jobRepo = repository.NewJobRepository(res.DB)
+	}
+	jobOwner := res.Env.JobsConfig.Owner
+	if jobOwner == "" {
+		hostname, _ := os.Hostname()
+		jobOwner = fmt.Sprintf("%s:%d", hostname, os.Getpid())
+	}
+	jobService := services.NewJobService(validators.NewJobValidator(), jobRepo, jobOwner)
+
+	// Initialize transaction monitor (confirmations and reconciliation of the journal)
+	var monitorService diSvc.ITransactionMonitorService
+	if transactionRepo != nil {
+		reconcileAfter := time.Duration(res.Env.JobsConfig.ReconcileAfterMinutes) * time.Minute
+		monitorService = services.NewTransactionMonitorService(blockchainClient, transactionRepo, reconcileAfter)
+	}
+
+	// Initialize token transfer indexer (optional - needs a database and INDEXER_TOKENS)
+	var indexerService diSvc.IIndexerService
+	if cfg := res.Env.IndexerConfig; cfg != nil && len(cfg.Tokens) > 0 {
+		if res.DB == nil {
+			return nil, fmt.Errorf("INDEXER_TOKENS requires a database (DB_NAME)")
+		}
+		if cfg.StartBlock < 0 || cfg.BatchBlocks <= 0 || cfg.Confirmations < 0 {
+			return nil, fmt.Errorf("INDEXER_START_BLOCK, INDEXER_BATCH_BLOCKS and INDEXER_CONFIRMATIONS must not be negative")
+		}
+		indexer, err := services.NewIndexerService(
+			blockchainClient,
+			repository.NewTokenTransferRepository(res.DB),
+			cfg.Tokens,
+			uint64(cfg.StartBlock),
+			uint64(cfg.BatchBlocks),
+			uint64(cfg.Confirmations),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize indexer service: %w", err)
+		}
+		indexerService = indexer
+	}
+
+	// Initialize keyring for client-encrypted private keys (envelopes by key-id, plus legacy CryptoJS)
+	keyring, err := utils.NewKeyring(
+		res.Env.BlockchainConfig.DecryptionKeys,
+		res.Env.BlockchainConfig.ActiveKeyID,
+		res.Env.BlockchainConfig.DecryptionKey,
+		res.Env.BlockchainConfig.EnvelopeKDF,
+		res.Env.BlockchainConfig.EnvelopeAcceptKDFs,
+		res.Env.BlockchainConfig.EnvelopeMaxDerivations,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize keyring: %w", err)
+	}
+
+	// Load HD wallet (optional - derived deposit addresses are disabled without a mnemonic)
+	var hdWallet *blockchain.HDWallet
+	if cfg := res.Env.HDWalletConfig; cfg != nil && cfg.MnemonicFile != "" {
+		mnemonic, err := readSecretFile(cfg.MnemonicFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read HD_MNEMONIC_FILE: %w", err)
+		}
+		hdWallet, err = blockchain.NewHDWallet(mnemonic, cfg.Passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize HD wallet: %w", err)
+		}
+	}
+
+	// Load keystore/remote signer secrets (optional - only needed for keystore and remote wallets)
+	var keystoreDir, keystorePassphrase, remoteSignerURL, remoteSignerToken string
+	if cfg := res.Env.SignerConfig; cfg != nil {
+		var err error
+		keystoreDir = cfg.KeystoreDir
+		if keystorePassphrase, err = readSecretFile(cfg.KeystorePassphraseFile); err != nil {
+			return nil, fmt.Errorf("failed to read KEYSTORE_PASSPHRASE_FILE: %w", err)
+		}
+		if remoteSignerToken, err = readSecretFile(cfg.RemoteAuthTokenFile); err != nil {
+			return nil, fmt.Errorf("failed to read REMOTE_SIGNER_TOKEN_FILE: %w", err)
+		}
+		remoteSignerURL = cfg.RemoteURL
+	}
+
+	// Initialize signer provider (resolves per-request signers from wallet_id or encrypted_private_key)
+	signerProvider := services.NewSignerProvider(
+		blockchainClient,
+		res.Policy,
+		walletRepo,
+		hdWallet,
+		walletMasterKey,
+		keyring,
+		keystoreDir,
+		keystorePassphrase,
+		remoteSignerURL,
+		remoteSignerToken,
+	)
+
+	// Load RPC proxy rules (built-in rules without RPC_POLICY_FILE)
+	rpcPolicy, err := policy.LoadRPCFile(res.Env.RPCProxyConfig.PolicyFile, func(ctx context.Context) (uint64, error) {
+		head, err := blockchainClient.GetBlockNumber(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return hexutil.DecodeUint64(head)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load RPC policy: %w", err)
+	}
+
+	// Initialize RPC audit trail (logged only without a database)
+	if res.Env.RPCProxyConfig.AuditRetentionDays <= 0 {
+		return nil, fmt.Errorf("RPC_AUDIT_RETENTION_DAYS must be positive")
+	}
+	var rpcAuditRepo diRepo.IRPCAuditRepository
+	if res.DB != nil {
+		rpcAuditRepo = repository.NewRPCAuditRepository(res.DB)
+	}
+	rpcAuditService := services.NewRPCAuditService(validators.NewRPCAuditValidator(), rpcAuditRepo)
+
 	// Initialize blockchain service (no global signer - uses per-request signing)
 	blockChainValidator := validators.NewBlockChainValidator()
-	blockchainService := services.NewBlockchainService(blockChainValidator, blockchainClient, res.Env.BlockchainConfig.DecryptionKey)
+	blockchainService := services.NewBlockchainService(
+		blockChainValidator,
+		blockchainClient,
+		signerProvider,
+		res.Policy,
+		journalService,
+		rpcPolicy,
+		rpcAuditService,
+	)
 
 	// Initialize Token service (uses per-request signers, no global signer needed)
 	tokenValidator := validators.NewTokenValidator()
 	tokenService, err := services.NewTokenService(
 		tokenValidator,
 		blockchainClient,
-		res.Env.BlockchainConfig.DecryptionKey,
+		signerProvider,
+		res.Policy,
+		journalService,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize token service: %w", err)
@@ -44,15 +262,131 @@ func SetupServiceContainer(res *resources.AppResource) (*ServiceContainer, error
 	swapService, err := services.NewSwapService(
 		swapValidator,
 		blockchainClient,
-		res.Env.BlockchainConfig.DecryptionKey,
+		signerProvider,
+		res.Policy,
+		journalService,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize swap service: %w", err)
 	}
 
+	// Initialize Wallet service
+	walletValidator := validators.NewWalletValidator()
+	walletService := services.NewWalletService(
+		walletValidator,
+		res.Policy,
+		walletRepo,
+		signerProvider,
+		hdWallet,
+		walletMasterKey,
+		keyring,
+	)
+
+	// Initialize Key service
+	keyService := services.NewKeyService(validators.NewKeyValidator(), keyring)
+
+	// Initialize Sweeper service (optional - moves deposit balances to the treasury)
+	var sweeperService diSvc.ISweeperService
+	if cfg := res.Env.SweeperConfig; cfg != nil && cfg.Enabled {
+		if walletRepo == nil || hdWallet == nil {
+			return nil, fmt.Errorf("sweeper requires WALLET_MASTER_KEY and HD_MNEMONIC_FILE")
+		}
+		if !common.IsHexAddress(cfg.TreasuryAddress) {
+			return nil, fmt.Errorf("SWEEPER_TREASURY_ADDRESS must be a valid address")
+		}
+		if cfg.IntervalSeconds <= 0 {
+			return nil, fmt.Errorf("SWEEPER_INTERVAL_SECONDS must be positive")
+		}
+		sweeper, err := services.NewSweeperService(
+			blockchainClient,
+			walletRepo,
+			signerProvider,
+			cfg.TreasuryAddress,
+			cfg.Tokens,
+			cfg.Threshold,
+			cfg.GasWalletID,
+			journalService,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize sweeper service: %w", err)
+		}
+		sweeperService = sweeper
+	}
+
+	// Initialize health service (readiness and diagnostics)
+	var dbPinger services.Pinger
+	if res.DB != nil {
+		dbPinger = res.DB
+	}
+	healthService, err := services.NewHealthService(
+		blockchainClient,
+		dbPinger,
+		transactionRepo,
+		keyring,
+		res.Drainer,
+		res.Env.BlockchainConfig.ChainID,
+		time.Duration(res.Env.HealthConfig.MaxBlockAgeSeconds)*time.Second,
+		time.Duration(res.Env.HealthConfig.CheckTimeoutSeconds)*time.Second,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize health service: %w", err)
+	}
+
 	return &ServiceContainer{
-		BlockchainService: blockchainService,
-		TokenService:      tokenService,
-		SwapService:       swapService,
+		BlockchainService:  blockchainService,
+		TokenService:       tokenService,
+		SwapService:        swapService,
+		WalletService:      walletService,
+		SweeperService:     sweeperService,
+		KeyService:         keyService,
+		JournalService:     journalService,
+		IdempotencyService: idempotencyService,
+		RPCAuditService:    rpcAuditService,
+		RPCCacheService:    services.NewRPCCacheService(blockchainClient.Cache()),
+		JobService:         jobService,
+		MonitorService:     monitorService,
+		IndexerService:     indexerService,
+		HealthService:      healthService,
+		LogService:         services.NewLogService(),
 	}, nil
 }
+
+// importWallets copies the wallets of a file store that the database does not have yet,
+// so deployments that move to a database keep their wallets
+func importWallets(ctx context.Context, from diRepo.IWalletRepository, to diRepo.IWalletRepository) error {
+	wallets, err := from.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	imported := 0
+	for _, wallet := range wallets {
+		_, err := to.GetByID(ctx, wallet.ID)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, domain.ErrWalletNotFound) {
+			return err
+		}
+		if err := to.Create(ctx, wallet); err != nil {
+			return fmt.Errorf("wallet %s: %w", wallet.ID, err)
+		}
+		imported++
+	}
+	if imported > 0 {
+		logger.Info("imported %d wallets from the wallet store file into the database", imported)
+	}
+	return nil
+}
+
+// readSecretFile reads a trimmed secret from a file; an empty path yields an empty secret
+func readSecretFile(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
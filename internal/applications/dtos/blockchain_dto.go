@@ -1,20 +1,35 @@
 package dtos
 
+import (
+	"encoding/json"
+
+	"kokka.com/kokka/internal/core/domain"
+)
+
 // GetBalanceRequest represents a request to get an address balance
 type GetBalanceRequest struct {
 	Address string `json:"address"`
 	Block   string `json:"block,omitempty"` // Optional, defaults to "latest"
 }
 
+// Response formats of chain data
+const (
+	FormatHex   = "hex"   // Quantities hex-encoded as the node returns them (default)
+	FormatHuman = "human" // Decimal quantities, ISO 8601 timestamps and decoded token transfers
+)
+
 // GetBlockRequest represents a request to get block details
 type GetBlockRequest struct {
 	BlockNumber string `json:"block_number"`
 	FullTx      bool   `json:"full_tx,omitempty"` // If true, returns full transaction objects
+	Format      string `json:"format,omitempty"`  // "hex" (default) or "human"
 }
 
 // GetTransactionRequest represents a request to get transaction details
 type GetTransactionRequest struct {
-	TxHash string `json:"tx_hash"`
+	TxHash         string `json:"tx_hash"`
+	IncludeReceipt bool   `json:"include_receipt,omitempty"` // Also return the receipt and token transfers once mined
+	Format         string `json:"format,omitempty"`          // "hex" (default) or "human", which implies include_receipt
 }
 
 // CallContractRequest represents a request to call a contract method (read-only)
@@ -37,22 +52,74 @@ type SendRawTransactionRequest struct {
 }
 
 // SignAndSendTransactionRequest represents a request to sign and send a transaction
-// The server will sign the transaction using its private key
+// The server will sign the transaction using the wallet's private key
 type SignAndSendTransactionRequest struct {
-	EncryptedPrivateKey string `json:"encrypted_private_key"` // Encrypted private key to sign the transaction
-	To                  string `json:"to"`                    // Recipient address (required)
-	Value               string `json:"value,omitempty"`       // Amount in wei (hex string, e.g., "0x0" for 0 wei)
-	Data                string `json:"data,omitempty"`        // Optional: contract call data (hex string)
-	GasLimit            string `json:"gas_limit,omitempty"`   // Optional: gas limit (hex string, auto-estimated if not provided)
-	GasPrice            string `json:"gas_price,omitempty"`   // Optional: gas price (hex string, fetched from network if not provided)
-	Nonce               string `json:"nonce,omitempty"`       // Optional: transaction nonce (hex string, fetched from network if not provided)
+	WalletID            string `json:"wallet_id,omitempty"`             // Custodial wallet used to sign the transaction
+	EncryptedPrivateKey string `json:"encrypted_private_key,omitempty"` // Deprecated: use wallet_id
+	To                  string `json:"to"`                              // Recipient address (required)
+	Value               string `json:"value,omitempty"`                 // Amount in wei (hex string, e.g., "0x0" for 0 wei)
+	Data                string `json:"data,omitempty"`                  // Optional: contract call data (hex string)
+	GasLimit            string `json:"gas_limit,omitempty"`             // Optional: gas limit (hex string, auto-estimated if not provided)
+	GasPrice            string `json:"gas_price,omitempty"`             // Optional: gas price (hex string, fetched from network if not provided)
+	Nonce               string `json:"nonce,omitempty"`                 // Optional: transaction nonce (hex string, fetched from network if not provided)
+	DryRun              bool   `json:"dry_run,omitempty"`               // Simulate without signing or sending
+}
+
+// SignTransactionRequest represents a request to sign a transaction without sending it
+// The signed transaction can be broadcast later with /blockchain/send-transaction
+type SignTransactionRequest struct {
+	WalletID            string `json:"wallet_id,omitempty"`             // Custodial wallet used to sign the transaction
+	EncryptedPrivateKey string `json:"encrypted_private_key,omitempty"` // Deprecated: use wallet_id
+	To                  string `json:"to"`                              // Recipient address (required)
+	Value               string `json:"value,omitempty"`                 // Amount in wei (hex string)
+	Data                string `json:"data,omitempty"`                  // Optional: contract call data (hex string)
+	GasLimit            string `json:"gas_limit,omitempty"`             // Optional: gas limit (hex string, auto-estimated if not provided)
+	GasPrice            string `json:"gas_price,omitempty"`             // Optional: gas price (hex string, fetched from network if not provided)
+	Nonce               string `json:"nonce,omitempty"`                 // Optional: transaction nonce (hex string, pending nonce if not provided)
+}
+
+// BuildTransactionRequest represents a request to build an unsigned transaction for external or cold signing
+type BuildTransactionRequest struct {
+	From     string `json:"from"`                // Address that will sign the transaction (required)
+	To       string `json:"to"`                  // Recipient address (required)
+	Value    string `json:"value,omitempty"`     // Amount in wei (hex string)
+	Data     string `json:"data,omitempty"`      // Optional: contract call data (hex string)
+	GasLimit string `json:"gas_limit,omitempty"` // Optional: gas limit (hex string, auto-estimated if not provided)
+	GasPrice string `json:"gas_price,omitempty"` // Optional: gas price (hex string, fetched from network if not provided)
+	Nonce    string `json:"nonce,omitempty"`     // Optional: transaction nonce (hex string, pending nonce of from if not provided)
+}
+
+// SignMessageRequest represents a request to sign an EIP-191 personal message (personal_sign)
+type SignMessageRequest struct {
+	WalletID            string `json:"wallet_id,omitempty"`             // Custodial wallet used to sign the message
+	EncryptedPrivateKey string `json:"encrypted_private_key,omitempty"` // Deprecated: use wallet_id
+	Message             string `json:"message"`                         // Message to sign, e.g. a login challenge
+	Encoding            string `json:"encoding,omitempty"`              // "text" (default) or "hex" for 0x-prefixed raw bytes
+}
+
+// SignTypedDataRequest represents a request to sign EIP-712 typed data (eth_signTypedData_v4)
+type SignTypedDataRequest struct {
+	WalletID            string          `json:"wallet_id,omitempty"`             // Custodial wallet used to sign the data
+	EncryptedPrivateKey string          `json:"encrypted_private_key,omitempty"` // Deprecated: use wallet_id
+	TypedData           json.RawMessage `json:"typed_data"`                      // Typed data with types, primaryType, domain and message; the domain chainId must be the connected chain
+}
+
+// VerifySignatureRequest represents a request to recover the signer of a personal message or typed data
+// Exactly one of message and typed_data must be set
+type VerifySignatureRequest struct {
+	Signature string          `json:"signature"`            // 65-byte signature (hex string), v as 27/28 or 0/1
+	Message   string          `json:"message,omitempty"`    // Personal message that was signed
+	Encoding  string          `json:"encoding,omitempty"`   // Encoding of message: "text" (default) or "hex"
+	TypedData json.RawMessage `json:"typed_data,omitempty"` // Typed data that was signed
+	Address   string          `json:"address,omitempty"`    // Optional: expected signer address
 }
 
 // GenericRPCRequest represents a generic JSON-RPC request
-// This allows clients to call any RPC method directly
+// This allows clients to call any RPC method the RPC policy allows; a JSON array of them is a batch
 type GenericRPCRequest struct {
-	Method string      `json:"method"`
-	Params interface{} `json:"params"`
+	ID     json.RawMessage `json:"id,omitempty"` // Echoed back in batch results
+	Method string          `json:"method"`
+	Params interface{}     `json:"params"`
 }
 
 // GetBalanceResponse represents the response for balance query
@@ -69,12 +136,93 @@ type GetBlockNumberResponse struct {
 
 // GetBlockResponse represents the response for block details
 type GetBlockResponse struct {
-	Block interface{} `json:"block"` // Full block object
+	Block *Block `json:"block"` // null when the node does not have the block
 }
 
 // GetTransactionResponse represents the response for transaction details
 type GetTransactionResponse struct {
-	Transaction interface{} `json:"transaction"` // Full transaction object
+	Transaction *Transaction          `json:"transaction"`         // null when the node does not know the transaction
+	Receipt     *Receipt              `json:"receipt,omitempty"`   // Once mined, when requested
+	Transfers   []*TokenTransferEvent `json:"transfers,omitempty"` // ERC-20 transfers emitted by the transaction
+}
+
+// Block represents a block
+// Quantities are hex-encoded, or decimal with format "human"
+type Block struct {
+	Number            string         `json:"number"` // Empty for a pending block
+	Hash              string         `json:"hash"`
+	ParentHash        string         `json:"parent_hash"`
+	Timestamp         string         `json:"timestamp"` // Unix seconds, or ISO 8601 with format "human"
+	Miner             string         `json:"miner"`
+	GasLimit          string         `json:"gas_limit"`
+	GasUsed           string         `json:"gas_used"`
+	BaseFeePerGas     string         `json:"base_fee_per_gas,omitempty"` // In wei
+	Size              string         `json:"size"`
+	ExtraData         string         `json:"extra_data"`
+	TransactionCount  int            `json:"transaction_count"`
+	TransactionHashes []string       `json:"transaction_hashes,omitempty"` // Without full_tx
+	Transactions      []*Transaction `json:"transactions,omitempty"`       // With full_tx
+}
+
+// Transaction represents a transaction as stored on chain
+// Quantities are hex-encoded, or decimal with format "human"; amounts are in wei
+type Transaction struct {
+	Hash                 string `json:"hash"`
+	Type                 string `json:"type"`
+	Nonce                string `json:"nonce"`
+	From                 string `json:"from"`
+	To                   string `json:"to,omitempty"` // Empty for a contract creation
+	Value                string `json:"value"`
+	Gas                  string `json:"gas"`
+	GasPrice             string `json:"gas_price,omitempty"`
+	MaxFeePerGas         string `json:"max_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas string `json:"max_priority_fee_per_gas,omitempty"`
+	Input                string `json:"input"`
+	ChainID              string `json:"chain_id,omitempty"`
+	Pending              bool   `json:"pending"`
+	BlockHash            string `json:"block_hash,omitempty"`
+	BlockNumber          string `json:"block_number,omitempty"`
+	TransactionIndex     string `json:"transaction_index,omitempty"`
+}
+
+// Receipt represents the outcome of a mined transaction
+// Quantities are hex-encoded, or decimal with format "human"; amounts are in wei
+type Receipt struct {
+	TransactionHash   string      `json:"transaction_hash"`
+	TransactionIndex  string      `json:"transaction_index"`
+	BlockHash         string      `json:"block_hash"`
+	BlockNumber       string      `json:"block_number"`
+	From              string      `json:"from"`
+	To                string      `json:"to,omitempty"`
+	ContractAddress   string      `json:"contract_address,omitempty"`
+	Status            string      `json:"status"` // "0x1"/"0x0", or "success"/"reverted" with format "human"
+	Type              string      `json:"type"`
+	GasUsed           string      `json:"gas_used"`
+	CumulativeGasUsed string      `json:"cumulative_gas_used"`
+	EffectiveGasPrice string      `json:"effective_gas_price,omitempty"`
+	FeeWei            string      `json:"fee_wei,omitempty"` // gas_used * effective_gas_price
+	Fee               string      `json:"fee,omitempty"`     // In native token units, with format "human"
+	Logs              []*EventLog `json:"logs"`
+}
+
+// EventLog represents an event emitted by a contract
+type EventLog struct {
+	Address         string   `json:"address"`
+	Topics          []string `json:"topics"`
+	Data            string   `json:"data"`
+	BlockNumber     string   `json:"block_number"`
+	TransactionHash string   `json:"transaction_hash"`
+	LogIndex        string   `json:"log_index"`
+	Removed         bool     `json:"removed,omitempty"`
+}
+
+// TokenTransferEvent represents a decoded ERC-20 Transfer event
+type TokenTransferEvent struct {
+	Token    string `json:"token"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Amount   string `json:"amount"` // Decimal, in the token's smallest unit
+	LogIndex string `json:"log_index"`
 }
 
 // CallContractResponse represents the response for contract call
@@ -94,8 +242,84 @@ type SendRawTransactionResponse struct {
 
 // SignAndSendTransactionResponse represents the response for sign and send transaction
 type SignAndSendTransactionResponse struct {
-	TxHash      string `json:"tx_hash"`      // Transaction hash
-	FromAddress string `json:"from_address"` // Address that signed and sent the transaction
+	TxHash      string        `json:"tx_hash"`           // Transaction hash
+	FromAddress string        `json:"from_address"`      // Address that signed and sent the transaction
+	DryRun      *DryRunResult `json:"dry_run,omitempty"` // Simulation outcome; set instead of tx_hash for dry runs
+}
+
+// SignTransactionResponse represents a signed, unsent transaction
+type SignTransactionResponse struct {
+	SignedTx  string `json:"signed_tx"`   // Raw signed transaction, accepted by /blockchain/send-transaction
+	TxHash    string `json:"tx_hash"`     // Hash the transaction will have once sent
+	From      string `json:"from"`        // Address that signed the transaction
+	To        string `json:"to"`          // Recipient address
+	Nonce     string `json:"nonce"`       // Hex
+	GasLimit  string `json:"gas_limit"`   // Hex
+	GasPrice  string `json:"gas_price"`   // Hex, in wei
+	Value     string `json:"value"`       // Hex, in wei
+	ChainID   string `json:"chain_id"`    // Hex
+	MaxFeeWei string `json:"max_fee_wei"` // gas_limit * gas_price in wei (decimal), the most the transaction can cost in gas
+}
+
+// BuildTransactionResponse represents an unsigned transaction and what signing it involves
+type BuildTransactionResponse struct {
+	UnsignedTx      *UnsignedTransaction `json:"unsigned_tx"`       // Transaction to sign; sign signing_hash or raw
+	EstimatedFeeWei string               `json:"estimated_fee_wei"` // gas_limit * gas_price in wei (decimal)
+	EstimatedFee    string               `json:"estimated_fee"`     // Estimated fee in native token units
+}
+
+// SignatureResponse represents a message or typed data signature
+type SignatureResponse struct {
+	Address   string `json:"address"`   // Address that signed
+	Signature string `json:"signature"` // 65-byte signature r || s || v (hex string), v is 27 or 28
+	Hash      string `json:"hash"`      // Digest that was signed
+}
+
+// VerifySignatureResponse represents the recovered signer of a signature
+type VerifySignatureResponse struct {
+	Signer string `json:"signer"`          // Recovered signer address
+	Hash   string `json:"hash"`            // Digest that was signed
+	Valid  *bool  `json:"valid,omitempty"` // Whether the signer is the expected address; only set when an address was given
+}
+
+// DryRunResult represents the simulated outcome of a write sent with dry_run
+type DryRunResult struct {
+	Success         bool                 `json:"success"`                 // Whether the transaction would succeed
+	ReturnData      string               `json:"return_data,omitempty"`   // Raw return data of the call
+	Outputs         map[string]any       `json:"outputs,omitempty"`       // Decoded return values
+	Events          []*SimulatedEvent    `json:"events,omitempty"`        // Emitted events, when the node supports debug_traceCall
+	Error           string               `json:"error,omitempty"`         // Why the transaction would fail
+	ErrorCode       string               `json:"error_code,omitempty"`    // Stable code of the failure, as in error responses
+	RevertReason    string               `json:"revert_reason,omitempty"` // Decoded revert reason
+	Revert          *domain.Revert       `json:"revert,omitempty"`        // Decoded revert data
+	GasLimit        string               `json:"gas_limit"`               // Estimated gas limit (decimal); 0 when a failing call could not be estimated
+	GasPrice        string               `json:"gas_price"`               // Gas price in wei (decimal)
+	EstimatedFeeWei string               `json:"estimated_fee_wei"`       // gas_limit * gas_price in wei (decimal)
+	EstimatedFee    string               `json:"estimated_fee"`           // Estimated fee in native token units
+	UnsignedTx      *UnsignedTransaction `json:"unsigned_tx"`             // Transaction that would be signed
+}
+
+// SimulatedEvent represents an event emitted by a simulated transaction
+type SimulatedEvent struct {
+	Address string         `json:"address"`
+	Event   string         `json:"event,omitempty"` // Event name, when the contract ABI is known
+	Args    map[string]any `json:"args,omitempty"`  // Decoded arguments by name
+	Topics  []string       `json:"topics"`
+	Data    string         `json:"data"`
+}
+
+// UnsignedTransaction represents an unsigned legacy (EIP-155) transaction
+type UnsignedTransaction struct {
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Nonce       string `json:"nonce"`        // Hex
+	GasLimit    string `json:"gas_limit"`    // Hex
+	GasPrice    string `json:"gas_price"`    // Hex, in wei
+	Value       string `json:"value"`        // Hex, in wei
+	Data        string `json:"data"`         // Hex call data
+	ChainID     string `json:"chain_id"`     // Hex
+	Raw         string `json:"raw"`          // RLP-encoded EIP-155 signing payload
+	SigningHash string `json:"signing_hash"` // Keccak-256 of raw, the hash that is signed
 }
 
 // GetGasPriceResponse represents the response for current gas price
@@ -112,3 +336,23 @@ type GetChainIDResponse struct {
 type GenericRPCResponse struct {
 	Result interface{} `json:"result"`
 }
+
+// GenericRPCBatchResponse represents the results of a JSON-RPC batch, in request order
+type GenericRPCBatchResponse struct {
+	Results []*GenericRPCBatchResult `json:"results"`
+}
+
+// GenericRPCBatchResult is the outcome of one call of a batch, either a result or an error
+type GenericRPCBatchResult struct {
+	ID     json.RawMessage  `json:"id,omitempty"`
+	Result json.RawMessage  `json:"result,omitempty"`
+	Error  *GenericRPCError `json:"error,omitempty"`
+}
+
+// GenericRPCError is a JSON-RPC error of a batch call
+type GenericRPCError struct {
+	Code      int             `json:"code"` // JSON-RPC error code from the node, or -32600/-32601/-32602 when kokka rejected the call
+	Message   string          `json:"message"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	ErrorCode string          `json:"error_code,omitempty"` // kokka error code of a call rejected before it reached the node
+}
@@ -0,0 +1,87 @@
+package dtos
+
+import "time"
+
+// Health check outcomes
+const (
+	HealthCheckOK      = "ok"
+	HealthCheckFailed  = "failed"
+	HealthCheckSkipped = "skipped" // Not configured
+)
+
+// Readiness states
+const (
+	ReadinessReady    = "ready"
+	ReadinessNotReady = "not_ready"
+	ReadinessDraining = "draining"
+)
+
+// HealthCheck is the outcome of one readiness check
+type HealthCheck struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms"`
+	Detail    string `json:"detail,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// LivenessResponse is the body of GET /healthz
+type LivenessResponse struct {
+	Status        string `json:"status"`
+	UptimeSeconds int64  `json:"uptime_seconds"`
+}
+
+// ReadinessResponse is the body of GET /readyz
+type ReadinessResponse struct {
+	Status   string         `json:"status"`
+	InFlight int            `json:"in_flight"`
+	Checks   []*HealthCheck `json:"checks,omitempty"` // Not run while draining
+}
+
+// BuildInfo describes the running binary
+type BuildInfo struct {
+	Version      string `json:"version"`
+	Revision     string `json:"revision,omitempty"`
+	RevisionTime string `json:"revision_time,omitempty"`
+	Modified     bool   `json:"modified"`
+	GoVersion    string `json:"go_version"`
+}
+
+// RPCEndpointDiagnostics is the measured state of an RPC endpoint
+type RPCEndpointDiagnostics struct {
+	Endpoint    string `json:"endpoint"` // Scheme and host only, paths may carry API keys
+	Status      string `json:"status"`
+	LatencyMs   int64  `json:"latency_ms"`
+	BlockNumber string `json:"block_number,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// SignerNonceDiagnostics compares the node's nonces of a signer with its unfinished journal entries
+type SignerNonceDiagnostics struct {
+	Address          string  `json:"address"`
+	LatestNonce      string  `json:"latest_nonce,omitempty"`  // Mined transactions
+	PendingNonce     string  `json:"pending_nonce,omitempty"` // Including the node's mempool; the next transaction uses it
+	JournalPending   int     `json:"journal_pending"`
+	JournalSubmitted int     `json:"journal_submitted"`
+	JournalUnknown   int     `json:"journal_unknown"` // Signed, broadcast failed; may still be in the node's pool
+	HighestNonce     *uint64 `json:"highest_journaled_nonce,omitempty"`
+	Error            string  `json:"error,omitempty"`
+}
+
+// NonceDiagnostics is the nonce state of the signers with unfinished transactions
+type NonceDiagnostics struct {
+	Source  string                    `json:"source"` // Where signers take their nonces from
+	Signers []*SignerNonceDiagnostics `json:"signers"`
+	Error   string                    `json:"error,omitempty"`
+}
+
+// DiagnosticsResponse is the body of GET /diagnostics
+type DiagnosticsResponse struct {
+	Build         *BuildInfo                `json:"build"`
+	StartedAt     time.Time                 `json:"started_at"`
+	UptimeSeconds int64                     `json:"uptime_seconds"`
+	Draining      bool                      `json:"draining"`
+	InFlight      int                       `json:"in_flight"`
+	RPCEndpoints  []*RPCEndpointDiagnostics `json:"rpc_endpoints"`
+	Nonces        *NonceDiagnostics         `json:"nonces"`
+}
@@ -0,0 +1,46 @@
+package dtos
+
+import (
+	"time"
+
+	"kokka.com/kokka/internal/core/domain"
+)
+
+// JobResponse represents a background job and its current state
+type JobResponse struct {
+	Name      string         `json:"name"`
+	Schedule  string         `json:"schedule"` // Cron expression or "@every <duration>"
+	Timeout   string         `json:"timeout"`
+	Paused    bool           `json:"paused"`
+	Running   bool           `json:"running"` // Locked by a run on any server instance
+	LockedBy  string         `json:"locked_by,omitempty"`
+	NextRunAt *time.Time     `json:"next_run_at,omitempty"`
+	LastRun   *domain.JobRun `json:"last_run,omitempty"`
+}
+
+// ListJobsResponse represents the registered background jobs
+type ListJobsResponse struct {
+	Owner string         `json:"owner"` // This server instance
+	Jobs  []*JobResponse `json:"jobs"`
+}
+
+// JobRequest represents a request that targets one job (trigger, pause, resume)
+type JobRequest struct {
+	Name string `json:"name"`
+}
+
+// JobRunResponse represents a job run started by a manual trigger
+type JobRunResponse struct {
+	Run *domain.JobRun `json:"run"`
+}
+
+// ListJobRunsRequest represents a request to list job run history
+type ListJobRunsRequest struct {
+	Name  string `json:"name,omitempty"`  // Empty lists runs of all jobs
+	Limit int    `json:"limit,omitempty"` // Default 100, max 1000
+}
+
+// ListJobRunsResponse represents job runs, newest first
+type ListJobRunsResponse struct {
+	Runs []*domain.JobRun `json:"runs"`
+}
@@ -0,0 +1,28 @@
+package dtos
+
+import "kokka.com/kokka/internal/core/domain"
+
+// ListJournalTransactionsRequest represents a request to list journal entries
+type ListJournalTransactionsRequest struct {
+	Status      string `json:"status,omitempty"`
+	Operation   string `json:"operation,omitempty"`
+	RequestedBy string `json:"requested_by,omitempty"`
+	Limit       int    `json:"limit,omitempty"` // Default 100, max 1000
+}
+
+// ListJournalTransactionsResponse represents a list of journal entries, newest first
+type ListJournalTransactionsResponse struct {
+	Transactions []*domain.Transaction `json:"transactions"`
+}
+
+// GetJournalTransactionRequest represents a request to get a journal entry by ID or tx hash
+type GetJournalTransactionRequest struct {
+	ID     string `json:"id,omitempty"`
+	TxHash string `json:"tx_hash,omitempty"`
+}
+
+// JournalTransactionResponse represents a journal entry with its status transitions
+type JournalTransactionResponse struct {
+	Transaction *domain.Transaction        `json:"transaction"`
+	Events      []*domain.TransactionEvent `json:"events"`
+}
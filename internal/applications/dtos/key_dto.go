@@ -0,0 +1,26 @@
+package dtos
+
+// RewrapKeysRequest represents a request to re-encrypt ciphertexts under the active key
+type RewrapKeysRequest struct {
+	Ciphertexts []string `json:"ciphertexts"` // Legacy CryptoJS or envelope ciphertexts
+}
+
+// RewrapResult represents the outcome for one ciphertext
+type RewrapResult struct {
+	Ciphertext    string `json:"ciphertext,omitempty"`      // New envelope under the active key
+	PreviousKeyID string `json:"previous_key_id,omitempty"` // Key-id the input was encrypted with
+	Legacy        bool   `json:"legacy"`                    // Whether the input was in the legacy CryptoJS format
+	Error         string `json:"error,omitempty"`
+}
+
+// RewrapKeysResponse represents the re-encrypted ciphertexts, in request order
+type RewrapKeysResponse struct {
+	ActiveKeyID string          `json:"active_key_id"`
+	Results     []*RewrapResult `json:"results"`
+}
+
+// KeyringInfoResponse represents the configured envelope keys (never includes secrets)
+type KeyringInfoResponse struct {
+	ActiveKeyID string   `json:"active_key_id"`
+	KeyIDs      []string `json:"key_ids"`
+}
@@ -0,0 +1,12 @@
+package dtos
+
+// SetLogLevelRequest represents a request to change the minimum log level at runtime
+type SetLogLevelRequest struct {
+	Level string `json:"level"` // debug, info, warn or error
+}
+
+// LogLevelResponse represents the current minimum log level
+type LogLevelResponse struct {
+	Level         string `json:"level"`
+	PreviousLevel string `json:"previous_level,omitempty"` // Set when the level was changed
+}
@@ -0,0 +1,23 @@
+package dtos
+
+// ConfirmationResult represents the outcome of one confirmation polling run
+type ConfirmationResult struct {
+	Checked   int `json:"checked"` // Submitted and unknown journal entries checked
+	Confirmed int `json:"confirmed"`
+	Reverted  int `json:"reverted"`
+	Submitted int `json:"submitted"` // Unknown entries found in the node's pool
+}
+
+// ReconciliationResult represents the outcome of one reconciliation run
+type ReconciliationResult struct {
+	Checked      int      `json:"checked"`       // Stale journal entries checked
+	Dropped      int      `json:"dropped"`       // Submitted or unknown transactions the node does not know, marked failed
+	StalePending []string `json:"stale_pending"` // Entries never marked submitted, left for operator review
+}
+
+// IndexResult represents the outcome of one indexing run
+type IndexResult struct {
+	FromBlock uint64 `json:"from_block"`
+	ToBlock   uint64 `json:"to_block"`
+	Transfers int    `json:"transfers"`
+}
@@ -0,0 +1,16 @@
+package dtos
+
+import "kokka.com/kokka/internal/core/domain"
+
+// ListRPCAuditRequest represents a request to list the audit trail of the RPC proxy
+type ListRPCAuditRequest struct {
+	ClientID string `json:"client_id,omitempty"`
+	Method   string `json:"method,omitempty"`
+	Outcome  string `json:"outcome,omitempty"` // ok, denied or error
+	Limit    int    `json:"limit,omitempty"`   // Default 100, max 1000
+}
+
+// ListRPCAuditResponse represents proxied calls, newest first
+type ListRPCAuditResponse struct {
+	Entries []*domain.RPCAuditEntry `json:"entries"`
+}
@@ -0,0 +1,22 @@
+package dtos
+
+// PurgeRPCCacheRequest represents a request to drop cached JSON-RPC results
+type PurgeRPCCacheRequest struct {
+	Address string `json:"address,omitempty"` // Only drop the contract reads of this address; empty drops everything
+}
+
+// PurgeRPCCacheResponse represents the outcome of a cache purge
+type PurgeRPCCacheResponse struct {
+	Purged int `json:"purged"`
+}
+
+// RPCCacheStatsResponse represents the content and hit rate of the JSON-RPC read cache
+type RPCCacheStatsResponse struct {
+	Enabled   bool    `json:"enabled"`
+	Entries   int     `json:"entries"`
+	Contracts int     `json:"contracts"` // Contracts watched for events that invalidate their reads
+	Hits      uint64  `json:"hits"`      // Since start
+	Misses    uint64  `json:"misses"`    // Since start
+	HitRate   float64 `json:"hit_rate"`  // hits / (hits + misses), 0 before the first lookup
+	HeadBlock uint64  `json:"head_block"`
+}
@@ -2,21 +2,24 @@ package dtos
 
 // SwapTokenRequest represents a request to swap tokens
 type SwapTokenRequest struct {
-	ContractAddress     string `json:"contract_address"`     // Swap contract address
-	AmountIn            string `json:"amount_in"`            // Amount of input token to swap
-	Direction           string `json:"direction"`            // "AtoB" or "BtoA"
-	EncryptedPrivateKey string `json:"encrypted_private_key"` // Encrypted private key for signing
+	ContractAddress     string `json:"contract_address"`                // Swap contract address
+	AmountIn            string `json:"amount_in"`                       // Amount of input token to swap
+	Direction           string `json:"direction"`                       // "AtoB" or "BtoA"
+	WalletID            string `json:"wallet_id,omitempty"`             // Custodial wallet used for signing
+	EncryptedPrivateKey string `json:"encrypted_private_key,omitempty"` // Deprecated: use wallet_id
+	DryRun              bool   `json:"dry_run,omitempty"`               // Simulate without signing or sending
 }
 
 // SwapTokenResponse represents the response from swapping tokens
 type SwapTokenResponse struct {
-	TxHash          string `json:"tx_hash"`           // Transaction hash
-	ContractAddress string `json:"contract_address"`  // Swap contract address
-	AmountIn        string `json:"amount_in"`         // Amount of input token swapped
-	AmountOut       string `json:"amount_out"`        // Amount of output token received (estimated)
-	FromToken       string `json:"from_token"`        // Address of token swapped from
-	ToToken         string `json:"to_token"`          // Address of token swapped to
-	Direction       string `json:"direction"`         // "AtoB" or "BtoA"
+	TxHash          string        `json:"tx_hash"`           // Transaction hash
+	ContractAddress string        `json:"contract_address"`  // Swap contract address
+	AmountIn        string        `json:"amount_in"`         // Amount of input token swapped
+	AmountOut       string        `json:"amount_out"`        // Amount of output token received (estimated)
+	FromToken       string        `json:"from_token"`        // Address of token swapped from
+	ToToken         string        `json:"to_token"`          // Address of token swapped to
+	Direction       string        `json:"direction"`         // "AtoB" or "BtoA"
+	DryRun          *DryRunResult `json:"dry_run,omitempty"` // Simulation outcome; set instead of tx_hash for dry runs
 }
 
 // GetSwapQuoteRequest represents a request to get a swap quote
@@ -0,0 +1,18 @@
+package dtos
+
+// SweepTransfer represents a single deposit-to-treasury token transfer
+type SweepTransfer struct {
+	WalletID        string `json:"wallet_id"`
+	From            string `json:"from"`
+	ContractAddress string `json:"contract_address"`
+	Amount          string `json:"amount"` // Amount in wei
+	TxHash          string `json:"tx_hash,omitempty"`
+	GasTopUpTxHash  string `json:"gas_top_up_tx_hash,omitempty"` // Set when the deposit address was funded for gas instead
+	Error           string `json:"error,omitempty"`
+}
+
+// SweepResult represents the outcome of one sweep run
+type SweepResult struct {
+	Checked   int              `json:"checked"` // Deposit wallets checked
+	Transfers []*SweepTransfer `json:"transfers"`
+}
@@ -5,31 +5,37 @@ type MintTokenRequest struct {
 	ContractAddress     string `json:"contract_address"`
 	To                  string `json:"to"`
 	Amount              string `json:"amount"`
-	EncryptedPrivateKey string `json:"encrypted_private_key"`
+	WalletID            string `json:"wallet_id,omitempty"`
+	EncryptedPrivateKey string `json:"encrypted_private_key,omitempty"` // Deprecated: use wallet_id
+	DryRun              bool   `json:"dry_run,omitempty"`               // Simulate without signing or sending
 }
 
 // MintTokenResponse represents the response from minting tokens
 type MintTokenResponse struct {
-	TxHash          string `json:"tx_hash"`
-	ContractAddress string `json:"contract_address"`
-	To              string `json:"to"`
-	Amount          string `json:"amount"`
-	NewBalance      string `json:"new_balance,omitempty"`
+	TxHash          string        `json:"tx_hash"`
+	ContractAddress string        `json:"contract_address"`
+	To              string        `json:"to"`
+	Amount          string        `json:"amount"`
+	NewBalance      string        `json:"new_balance,omitempty"`
+	DryRun          *DryRunResult `json:"dry_run,omitempty"`
 }
 
 // BurnTokenRequest represents a request to burn tokens
 type BurnTokenRequest struct {
 	ContractAddress     string `json:"contract_address"`
 	Amount              string `json:"amount"`
-	EncryptedPrivateKey string `json:"encrypted_private_key"`
+	WalletID            string `json:"wallet_id,omitempty"`
+	EncryptedPrivateKey string `json:"encrypted_private_key,omitempty"` // Deprecated: use wallet_id
+	DryRun              bool   `json:"dry_run,omitempty"`               // Simulate without signing or sending
 }
 
 // BurnTokenResponse represents the response from burning tokens
 type BurnTokenResponse struct {
-	TxHash          string `json:"tx_hash"`
-	ContractAddress string `json:"contract_address"`
-	Amount          string `json:"amount"`
-	NewBalance      string `json:"new_balance,omitempty"`
+	TxHash          string        `json:"tx_hash"`
+	ContractAddress string        `json:"contract_address"`
+	Amount          string        `json:"amount"`
+	NewBalance      string        `json:"new_balance,omitempty"`
+	DryRun          *DryRunResult `json:"dry_run,omitempty"`
 }
 
 // TransferTokenRequest represents a request to transfer tokens
@@ -37,16 +43,19 @@ type TransferTokenRequest struct {
 	ContractAddress     string `json:"contract_address"`
 	To                  string `json:"to"`
 	Amount              string `json:"amount"`
-	EncryptedPrivateKey string `json:"encrypted_private_key"`
+	WalletID            string `json:"wallet_id,omitempty"`
+	EncryptedPrivateKey string `json:"encrypted_private_key,omitempty"` // Deprecated: use wallet_id
+	DryRun              bool   `json:"dry_run,omitempty"`               // Simulate without signing or sending
 }
 
 // TransferTokenResponse represents the response from transferring tokens
 type TransferTokenResponse struct {
-	TxHash          string `json:"tx_hash"`
-	ContractAddress string `json:"contract_address"`
-	From            string `json:"from"`
-	To              string `json:"to"`
-	Amount          string `json:"amount"`
+	TxHash          string        `json:"tx_hash"`
+	ContractAddress string        `json:"contract_address"`
+	From            string        `json:"from"`
+	To              string        `json:"to"`
+	Amount          string        `json:"amount"`
+	DryRun          *DryRunResult `json:"dry_run,omitempty"`
 }
 
 // GetTokenBalanceRequest represents a request to get token balance
@@ -0,0 +1,63 @@
+package dtos
+
+import "time"
+
+// CreateWalletRequest represents a request to generate a new custodial wallet
+type CreateWalletRequest struct {
+	Label string `json:"label,omitempty"`
+}
+
+// ImportWalletRequest represents a request to import an existing key as a custodial wallet
+type ImportWalletRequest struct {
+	EncryptedPrivateKey string `json:"encrypted_private_key"` // Private key encrypted with DECRYPTION_KEY (CryptoJS format)
+	Label               string `json:"label,omitempty"`
+}
+
+// RegisterWalletRequest represents a request to register an externally held key (keystore file or remote signer)
+type RegisterWalletRequest struct {
+	Kind         string `json:"kind"`                    // "keystore" or "remote"
+	Address      string `json:"address"`                 // Expected signer address
+	KeystorePath string `json:"keystore_path,omitempty"` // Required for keystore wallets; file name inside KEYSTORE_DIR
+	Label        string `json:"label,omitempty"`
+}
+
+// DeriveWalletRequest represents a request for the deposit address of a customer index
+type DeriveWalletRequest struct {
+	Index *uint32 `json:"index"` // BIP-44 address index, typically the customer ID
+	Label string  `json:"label,omitempty"`
+}
+
+// ResolveDerivedAddressRequest represents a request to resolve a deposit address to its index
+type ResolveDerivedAddressRequest struct {
+	Address string `json:"address"`
+}
+
+// DerivedAddressResponse represents a derived deposit address
+type DerivedAddressResponse struct {
+	WalletID string `json:"wallet_id"`
+	Address  string `json:"address"`
+	Index    uint32 `json:"index"`
+	Path     string `json:"path"`
+}
+
+// GetWalletRequest represents a request to get a wallet
+type GetWalletRequest struct {
+	WalletID string `json:"wallet_id"`
+}
+
+// WalletResponse represents a custodial wallet (never includes key material)
+type WalletResponse struct {
+	WalletID        string    `json:"wallet_id"`
+	Address         string    `json:"address"`
+	Label           string    `json:"label,omitempty"`
+	Kind            string    `json:"kind"`
+	DerivationIndex *uint32   `json:"derivation_index,omitempty"`
+	DerivationPath  string    `json:"derivation_path,omitempty"`
+	CreatedBy       string    `json:"created_by,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// ListWalletsResponse represents the list of custodial wallets
+type ListWalletsResponse struct {
+	Wallets []*WalletResponse `json:"wallets"`
+}
@@ -0,0 +1,38 @@
+package policy
+
+import (
+	"errors"
+
+	"kokka.com/kokka/internal/core/domain"
+)
+
+// UnauthorizedError is returned when the caller is not allowed to perform an action
+type UnauthorizedError struct {
+	Reason string
+}
+
+// Error implements the error interface
+func (e *UnauthorizedError) Error() string {
+	return "unauthorized: " + e.Reason
+}
+
+// ErrorCode returns the stable error code
+func (e *UnauthorizedError) ErrorCode() string {
+	return string(domain.ErrorCodeUnauthorized)
+}
+
+// IsRetryable reports that repeating the request will not help
+func (e *UnauthorizedError) IsRetryable() bool {
+	return false
+}
+
+// Unauthorized creates a new UnauthorizedError
+func Unauthorized(reason string) error {
+	return &UnauthorizedError{Reason: reason}
+}
+
+// IsUnauthorized checks if an error is (or wraps) an UnauthorizedError
+func IsUnauthorized(err error) bool {
+	var target *UnauthorizedError
+	return errors.As(err, &target)
+}
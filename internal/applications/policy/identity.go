@@ -0,0 +1,28 @@
+package policy
+
+import "context"
+
+// AnonymousClientID identifies callers that did not present an API key
+const AnonymousClientID = "anonymous"
+
+type identityKeyType string
+
+const identityKey = identityKeyType("policy_identity")
+
+// Identity is the caller of the current request
+type Identity struct {
+	ClientID string
+	Roles    []string
+	Route    string // e.g. "POST /token/mint"
+}
+
+// WithIdentity adds the caller identity to the context
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey, identity)
+}
+
+// GetIdentity retrieves the caller identity from the context
+func GetIdentity(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityKey).(*Identity)
+	return identity
+}
@@ -0,0 +1,275 @@
+package policy
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path"
+	"strings"
+
+	"kokka.com/kokka/internal/shared/utils"
+)
+
+// Authorizer decides whether the caller in the context may perform an action
+type Authorizer interface {
+	Authorize(ctx context.Context, action Action) error
+}
+
+// Action describes what a caller is about to do
+// Empty fields are not checked
+type Action struct {
+	Token  string   // Token contract address
+	Pool   string   // Swap pool contract address
+	Target string   // Arbitrary contract/recipient address (sign-and-send)
+	Amount *big.Int // Amount in wei
+
+	Wallet      string // Custodial wallet ID signed with
+	WalletOwner string // Client that created the wallet; its owner may always sign with it
+}
+
+// Config is the on-disk policy file format
+type Config struct {
+	AnonymousRoles []string              `json:"anonymous_roles"`
+	Clients        []ClientConfig        `json:"clients"`
+	Roles          map[string]RoleConfig `json:"roles"`
+}
+
+// ClientConfig maps an API client to its roles
+type ClientConfig struct {
+	ID           string   `json:"id"`
+	APIKeySHA256 string   `json:"api_key_sha256"` // Hex-encoded SHA-256 of the API key
+	Roles        []string `json:"roles"`
+}
+
+// RoleConfig lists what a role is allowed to do
+type RoleConfig struct {
+	Routes    []string `json:"routes"`               // e.g. "POST /token/*", "*" for any route
+	Tokens    []string `json:"tokens,omitempty"`     // Allowed token contracts, empty means any
+	Pools     []string `json:"pools,omitempty"`      // Allowed swap pools, empty means any
+	MaxAmount string   `json:"max_amount,omitempty"` // Max amount per request in token units, empty means unlimited
+	Wallets   []string `json:"wallets,omitempty"`    // Custodial wallets usable besides the client's own (sign, read, list), "*" for any
+}
+
+type role struct {
+	name      string
+	routes    []string
+	tokens    map[string]bool
+	pools     map[string]bool
+	maxAmount *big.Int
+	wallets   map[string]bool
+}
+
+type client struct {
+	id      string
+	keyHash []byte
+	roles   []string
+}
+
+// Policy maps API clients to roles and roles to permissions
+type Policy struct {
+	clients        []client
+	roles          map[string]*role
+	anonymousRoles []string
+}
+
+// LoadFile loads a policy from a JSON file
+func LoadFile(filePath string) (*Policy, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file: %w", err)
+	}
+
+	return New(&cfg)
+}
+
+// New builds a policy from its configuration
+func New(cfg *Config) (*Policy, error) {
+	p := &Policy{
+		roles:          make(map[string]*role, len(cfg.Roles)),
+		anonymousRoles: cfg.AnonymousRoles,
+	}
+
+	for name, rc := range cfg.Roles {
+		r := &role{
+			name:    name,
+			routes:  rc.Routes,
+			tokens:  toAddressSet(rc.Tokens),
+			pools:   toAddressSet(rc.Pools),
+			wallets: make(map[string]bool, len(rc.Wallets)),
+		}
+		for _, walletID := range rc.Wallets {
+			r.wallets[walletID] = true
+		}
+		if rc.MaxAmount != "" {
+			maxAmount, err := utils.TokenUnitsToWei(rc.MaxAmount)
+			if err != nil {
+				return nil, fmt.Errorf("role %s: invalid max_amount: %w", name, err)
+			}
+			r.maxAmount = maxAmount
+		}
+		p.roles[name] = r
+	}
+
+	for _, cc := range cfg.Clients {
+		if cc.ID == "" {
+			return nil, fmt.Errorf("client id is required")
+		}
+		keyHash, err := hex.DecodeString(strings.TrimPrefix(cc.APIKeySHA256, "0x"))
+		if err != nil || len(keyHash) != sha256.Size {
+			return nil, fmt.Errorf("client %s: api_key_sha256 must be a hex-encoded SHA-256 digest", cc.ID)
+		}
+		for _, name := range cc.Roles {
+			if _, ok := p.roles[name]; !ok {
+				return nil, fmt.Errorf("client %s: unknown role %s", cc.ID, name)
+			}
+		}
+		p.clients = append(p.clients, client{id: cc.ID, keyHash: keyHash, roles: cc.Roles})
+	}
+
+	for _, name := range cfg.AnonymousRoles {
+		if _, ok := p.roles[name]; !ok {
+			return nil, fmt.Errorf("anonymous: unknown role %s", name)
+		}
+	}
+
+	return p, nil
+}
+
+// Identify resolves an API key to an identity
+// An empty key resolves to the anonymous identity
+func (p *Policy) Identify(apiKey string) (*Identity, error) {
+	if apiKey == "" {
+		return &Identity{ClientID: AnonymousClientID, Roles: p.anonymousRoles}, nil
+	}
+
+	sum := sha256.Sum256([]byte(apiKey))
+	for _, c := range p.clients {
+		if subtle.ConstantTimeCompare(sum[:], c.keyHash) == 1 {
+			return &Identity{ClientID: c.id, Roles: c.roles}, nil
+		}
+	}
+
+	return nil, Unauthorized("unknown API key")
+}
+
+// AuthorizeRoute checks that the identity may call the given route
+func (p *Policy) AuthorizeRoute(identity *Identity, route string) error {
+	if p == nil {
+		return nil
+	}
+	if identity == nil {
+		return Unauthorized("caller is not identified")
+	}
+
+	for _, name := range identity.Roles {
+		if r := p.roles[name]; r != nil && r.allowsRoute(route) {
+			return nil
+		}
+	}
+
+	return Unauthorized(fmt.Sprintf("client %s is not allowed to call %s", identity.ClientID, route))
+}
+
+// Authorize checks that the caller in the context may perform the action on its route
+// A nil policy allows everything
+func (p *Policy) Authorize(ctx context.Context, action Action) error {
+	if p == nil {
+		return nil
+	}
+
+	identity := GetIdentity(ctx)
+	if identity == nil {
+		return Unauthorized("caller is not identified")
+	}
+
+	// Clients may always sign with the wallets they created; anonymous callers own nothing
+	if action.Wallet != "" && action.WalletOwner == identity.ClientID && identity.ClientID != AnonymousClientID {
+		action.Wallet = ""
+	}
+
+	// The action is allowed if any single role covers all of it
+	var reason string
+	for _, name := range identity.Roles {
+		r := p.roles[name]
+		if r == nil {
+			continue
+		}
+		denied := r.check(identity.Route, action)
+		if denied == "" {
+			return nil
+		}
+		if reason == "" {
+			reason = denied
+		}
+	}
+
+	if reason == "" {
+		reason = fmt.Sprintf("not allowed to call %s", identity.Route)
+	}
+	return Unauthorized(fmt.Sprintf("client %s is %s", identity.ClientID, reason))
+}
+
+// check returns an empty string when the role allows the action, or the reason it does not
+func (r *role) check(route string, action Action) string {
+	if !r.allowsRoute(route) {
+		return fmt.Sprintf("not allowed to call %s", route)
+	}
+
+	if action.Token != "" && len(r.tokens) > 0 && !r.tokens[strings.ToLower(action.Token)] {
+		return fmt.Sprintf("not allowed to use token %s", action.Token)
+	}
+
+	if action.Pool != "" && len(r.pools) > 0 && !r.pools[strings.ToLower(action.Pool)] {
+		return fmt.Sprintf("not allowed to use pool %s", action.Pool)
+	}
+
+	// A raw target must be one of the contracts the role is restricted to
+	if action.Target != "" && (len(r.tokens) > 0 || len(r.pools) > 0) {
+		target := strings.ToLower(action.Target)
+		if !r.tokens[target] && !r.pools[target] {
+			return fmt.Sprintf("not allowed to send to %s", action.Target)
+		}
+	}
+
+	if action.Amount != nil && r.maxAmount != nil && action.Amount.Cmp(r.maxAmount) > 0 {
+		return fmt.Sprintf("not allowed to exceed max amount %s wei", r.maxAmount.String())
+	}
+
+	if action.Wallet != "" && !r.wallets["*"] && !r.wallets[action.Wallet] {
+		return fmt.Sprintf("not allowed to sign with wallet %s", action.Wallet)
+	}
+
+	return ""
+}
+
+// allowsRoute reports whether any of the role's route patterns matches
+func (r *role) allowsRoute(route string) bool {
+	for _, pattern := range r.routes {
+		if pattern == "*" || pattern == route {
+			return true
+		}
+		if ok, _ := path.Match(pattern, route); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// toAddressSet lower-cases addresses into a lookup set
+func toAddressSet(addresses []string) map[string]bool {
+	set := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		set[strings.ToLower(address)] = true
+	}
+	return set
+}
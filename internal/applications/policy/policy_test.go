@@ -0,0 +1,49 @@
+package policy
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAuthorizeWallet(t *testing.T) {
+	p, err := New(&Config{
+		AnonymousRoles: []string{"signer"},
+		Roles: map[string]RoleConfig{
+			"signer":   {Routes: []string{"POST /blockchain/*"}},
+			"treasury": {Routes: []string{"POST /blockchain/*"}, Wallets: []string{"wal_treasury"}},
+			"operator": {Routes: []string{"POST /blockchain/*"}, Wallets: []string{"*"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		client  string
+		roles   []string
+		action  Action
+		allowed bool
+	}{
+		{"own wallet", "alice", []string{"signer"}, Action{Wallet: "wal_a", WalletOwner: "alice"}, true},
+		{"other client's wallet", "bob", []string{"signer"}, Action{Wallet: "wal_a", WalletOwner: "alice"}, false},
+		{"wallet without owner", "bob", []string{"signer"}, Action{Wallet: "wal_a"}, false},
+		{"anonymous owner", AnonymousClientID, []string{"signer"}, Action{Wallet: "wal_a", WalletOwner: AnonymousClientID}, false},
+		{"listed wallet", "bob", []string{"treasury"}, Action{Wallet: "wal_treasury", WalletOwner: "alice"}, true},
+		{"unlisted wallet", "bob", []string{"treasury"}, Action{Wallet: "wal_a", WalletOwner: "alice"}, false},
+		{"any wallet", "ops", []string{"operator"}, Action{Wallet: "wal_a", WalletOwner: "alice"}, true},
+		{"no wallet", "bob", []string{"signer"}, Action{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := WithIdentity(context.Background(), &Identity{ClientID: tt.client, Roles: tt.roles, Route: "POST /blockchain/sign"})
+			err := p.Authorize(ctx, tt.action)
+			if allowed := err == nil; allowed != tt.allowed {
+				t.Errorf("allowed = %v, want %v (%v)", allowed, tt.allowed, err)
+			}
+			if err != nil && !IsUnauthorized(err) {
+				t.Errorf("error %v is not an UnauthorizedError", err)
+			}
+		})
+	}
+}
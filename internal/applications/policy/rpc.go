@@ -0,0 +1,350 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"kokka.com/kokka/internal/core/domain"
+)
+
+// RPCGuard decides which JSON-RPC calls the caller in the context may make through the proxy
+type RPCGuard interface {
+	Admit(ctx context.Context, calls int) error
+	CheckCall(ctx context.Context, method string, params any) error
+}
+
+// HeadFunc returns the current block number, used to resolve block tags such as "latest"
+type HeadFunc func(ctx context.Context) (uint64, error)
+
+// RPCConfig is the on-disk RPC policy file format
+// Fields left out of the file keep the built-in defaults, so an explicit "deny": [] is needed to lift the default denials
+type RPCConfig struct {
+	Allow        []string                  `json:"allow"`          // Method patterns such as "eth_*"; empty allows any method that is not denied
+	Deny         []string                  `json:"deny"`           // Method patterns, checked before allow
+	Methods      map[string]RPCMethodLimit `json:"methods"`        // Parameter limits by method; "*" fills in limits a method does not set
+	MaxBatchSize int                       `json:"max_batch_size"` // Max calls in one batch
+	Quotas       map[string]RPCQuota       `json:"quotas"`         // Call quotas by client ID; "*" applies to clients not listed
+}
+
+// RPCMethodLimit bounds the parameters of a method, zero values are not checked
+type RPCMethodLimit struct {
+	MaxParamsBytes int    `json:"max_params_bytes,omitempty"` // Size of the JSON-encoded params
+	MaxBlockRange  uint64 `json:"max_block_range,omitempty"`  // toBlock - fromBlock of an eth_getLogs/eth_newFilter filter
+	MaxAddresses   int    `json:"max_addresses,omitempty"`    // Addresses of an eth_getLogs/eth_newFilter filter
+}
+
+// RPCQuota limits how many calls a client makes, zero values are unlimited
+// Calls are counted per server instance in fixed UTC minutes and days
+type RPCQuota struct {
+	PerMinute int `json:"per_minute,omitempty"`
+	PerDay    int `json:"per_day,omitempty"`
+}
+
+// rpcDefaultKey is the methods and quotas entry that applies to anything not listed
+const rpcDefaultKey = "*"
+
+// rpcFilterMethods take a log filter object as their first param
+var rpcFilterMethods = map[string]bool{
+	"eth_getLogs":   true,
+	"eth_newFilter": true,
+}
+
+// DefaultRPCConfig returns the built-in RPC policy
+// Node administration, debugging, txpool inspection and node-held keys are denied; log queries are bounded
+func DefaultRPCConfig() *RPCConfig {
+	filterLimit := RPCMethodLimit{MaxBlockRange: 10000, MaxAddresses: 100}
+	return &RPCConfig{
+		Deny: []string{
+			"admin_*", "debug_*", "engine_*", "miner_*", "personal_*", "txpool_*", "clique_*", "les_*",
+			"eth_sendTransaction", "eth_sign", "eth_signTransaction", "eth_signTypedData*",
+		},
+		Methods: map[string]RPCMethodLimit{
+			rpcDefaultKey:   {MaxParamsBytes: 128 * 1024},
+			"eth_getLogs":   filterLimit,
+			"eth_newFilter": filterLimit,
+		},
+		MaxBatchSize: 50,
+	}
+}
+
+type rpcUsage struct {
+	minute      time.Time
+	minuteCalls int
+	day         time.Time
+	dayCalls    int
+}
+
+// RPCPolicy restricts the JSON-RPC proxy by method, parameters and per-client quota
+type RPCPolicy struct {
+	allow        []string
+	deny         []string
+	methods      map[string]RPCMethodLimit
+	maxBatchSize int
+	quotas       map[string]RPCQuota
+	head         HeadFunc
+
+	mutex sync.Mutex
+	usage map[string]*rpcUsage // By client ID
+}
+
+// LoadRPCFile loads an RPC policy from a JSON file; an empty path yields the built-in policy
+func LoadRPCFile(filePath string, head HeadFunc) (*RPCPolicy, error) {
+	cfg := DefaultRPCConfig()
+	if filePath == "" {
+		return NewRPC(cfg, head)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read RPC policy file: %w", err)
+	}
+
+	var fileCfg RPCConfig
+	if err := json.Unmarshal(data, &fileCfg); err != nil {
+		return nil, fmt.Errorf("failed to parse RPC policy file: %w", err)
+	}
+
+	// Keep the defaults of anything the file leaves out
+	if fileCfg.Deny == nil {
+		fileCfg.Deny = cfg.Deny
+	}
+	if fileCfg.Methods == nil {
+		fileCfg.Methods = cfg.Methods
+	}
+	if fileCfg.MaxBatchSize == 0 {
+		fileCfg.MaxBatchSize = cfg.MaxBatchSize
+	}
+
+	return NewRPC(&fileCfg, head)
+}
+
+// NewRPC builds an RPC policy from its configuration
+func NewRPC(cfg *RPCConfig, head HeadFunc) (*RPCPolicy, error) {
+	for _, pattern := range append(append([]string{}, cfg.Allow...), cfg.Deny...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid method pattern %q: %w", pattern, err)
+		}
+	}
+	if cfg.MaxBatchSize < 0 {
+		return nil, fmt.Errorf("max_batch_size must not be negative")
+	}
+	for method, limit := range cfg.Methods {
+		if limit.MaxParamsBytes < 0 || limit.MaxAddresses < 0 {
+			return nil, fmt.Errorf("method %s: limits must not be negative", method)
+		}
+	}
+	for clientID, quota := range cfg.Quotas {
+		if quota.PerMinute < 0 || quota.PerDay < 0 {
+			return nil, fmt.Errorf("client %s: quotas must not be negative", clientID)
+		}
+	}
+
+	return &RPCPolicy{
+		allow:        cfg.Allow,
+		deny:         cfg.Deny,
+		methods:      cfg.Methods,
+		maxBatchSize: cfg.MaxBatchSize,
+		quotas:       cfg.Quotas,
+		head:         head,
+		usage:        make(map[string]*rpcUsage),
+	}, nil
+}
+
+// Admit checks the batch size and takes the calls from the caller's quota
+// Calls are counted whether or not they are allowed afterwards
+func (p *RPCPolicy) Admit(ctx context.Context, calls int) error {
+	if p == nil {
+		return nil
+	}
+
+	if p.maxBatchSize > 0 && calls > p.maxBatchSize {
+		return domain.NewValidationError(fmt.Sprintf("batch of %d calls exceeds the limit of %d", calls, p.maxBatchSize))
+	}
+
+	clientID := AnonymousClientID
+	if identity := GetIdentity(ctx); identity != nil {
+		clientID = identity.ClientID
+	}
+	quota, ok := p.quotas[clientID]
+	if !ok {
+		quota = p.quotas[rpcDefaultKey]
+	}
+	if quota.PerMinute == 0 && quota.PerDay == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	minute := now.Truncate(time.Minute)
+	day := now.Truncate(24 * time.Hour)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	usage := p.usage[clientID]
+	if usage == nil {
+		usage = &rpcUsage{}
+		p.usage[clientID] = usage
+	}
+	if !usage.minute.Equal(minute) {
+		usage.minute, usage.minuteCalls = minute, 0
+	}
+	if !usage.day.Equal(day) {
+		usage.day, usage.dayCalls = day, 0
+	}
+
+	if quota.PerMinute > 0 && usage.minuteCalls+calls > quota.PerMinute {
+		return domain.NewError(domain.ErrorCodeQuotaExceeded,
+			fmt.Sprintf("client %s exceeded its quota of %d RPC calls per minute", clientID, quota.PerMinute), nil)
+	}
+	if quota.PerDay > 0 && usage.dayCalls+calls > quota.PerDay {
+		return domain.NewError(domain.ErrorCodeQuotaExceeded,
+			fmt.Sprintf("client %s exceeded its quota of %d RPC calls per day", clientID, quota.PerDay), nil)
+	}
+
+	usage.minuteCalls += calls
+	usage.dayCalls += calls
+	return nil
+}
+
+// CheckCall checks that the method is allowed and its params are within the method's limits
+func (p *RPCPolicy) CheckCall(ctx context.Context, method string, params any) error {
+	if p == nil {
+		return nil
+	}
+
+	if !p.allowsMethod(method) {
+		return domain.NewError(domain.ErrorCodeMethodNotAllowed, fmt.Sprintf("method %s is not allowed", method), nil)
+	}
+
+	limit := p.limit(method)
+	if limit == (RPCMethodLimit{}) {
+		return nil
+	}
+
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return domain.NewValidationError(fmt.Sprintf("invalid params: %v", err))
+	}
+	if limit.MaxParamsBytes > 0 && len(encoded) > limit.MaxParamsBytes {
+		return domain.NewValidationError(fmt.Sprintf("params of %s exceed the limit of %d bytes", method, limit.MaxParamsBytes))
+	}
+
+	if rpcFilterMethods[method] && (limit.MaxBlockRange > 0 || limit.MaxAddresses > 0) {
+		return p.checkFilter(ctx, method, encoded, limit)
+	}
+	return nil
+}
+
+// allowsMethod reports whether the method is not denied and, with an allow list, allowed
+func (p *RPCPolicy) allowsMethod(method string) bool {
+	if matchesAny(p.deny, method) {
+		return false
+	}
+	return len(p.allow) == 0 || matchesAny(p.allow, method)
+}
+
+// limit returns the limits of a method, with the "*" limits filling in what it does not set
+func (p *RPCPolicy) limit(method string) RPCMethodLimit {
+	limit := p.methods[method]
+	fallback := p.methods[rpcDefaultKey]
+	if limit.MaxParamsBytes == 0 {
+		limit.MaxParamsBytes = fallback.MaxParamsBytes
+	}
+	if limit.MaxBlockRange == 0 {
+		limit.MaxBlockRange = fallback.MaxBlockRange
+	}
+	if limit.MaxAddresses == 0 {
+		limit.MaxAddresses = fallback.MaxAddresses
+	}
+	return limit
+}
+
+// checkFilter bounds the block range and addresses of a log filter
+func (p *RPCPolicy) checkFilter(ctx context.Context, method string, encoded []byte, limit RPCMethodLimit) error {
+	var filters []struct {
+		FromBlock string          `json:"fromBlock"`
+		ToBlock   string          `json:"toBlock"`
+		BlockHash string          `json:"blockHash"`
+		Address   json.RawMessage `json:"address"`
+	}
+	if err := json.Unmarshal(encoded, &filters); err != nil || len(filters) == 0 {
+		return domain.NewValidationError(fmt.Sprintf("params of %s must be [filter]", method))
+	}
+	filter := filters[0]
+
+	if limit.MaxAddresses > 0 {
+		var addresses []string
+		if err := json.Unmarshal(filter.Address, &addresses); err == nil && len(addresses) > limit.MaxAddresses {
+			return domain.NewValidationError(fmt.Sprintf("filter of %d addresses exceeds the limit of %d", len(addresses), limit.MaxAddresses))
+		}
+	}
+
+	// A block hash selects a single block
+	if limit.MaxBlockRange == 0 || filter.BlockHash != "" {
+		return nil
+	}
+
+	var head *uint64
+	from, err := p.resolveBlock(ctx, filter.FromBlock, &head)
+	if err != nil {
+		return err
+	}
+	to, err := p.resolveBlock(ctx, filter.ToBlock, &head)
+	if err != nil {
+		return err
+	}
+	if to > from && to-from > limit.MaxBlockRange {
+		return domain.NewValidationError(fmt.Sprintf("block range of %d exceeds the limit of %d blocks", to-from, limit.MaxBlockRange))
+	}
+	return nil
+}
+
+// resolveBlock converts a block number or tag to a number; the head is fetched at most once per filter
+// Tags other than "earliest" resolve to the head, which is exact for "latest" and close enough for the rest
+func (p *RPCPolicy) resolveBlock(ctx context.Context, block string, head **uint64) (uint64, error) {
+	switch block {
+	case "earliest":
+		return 0, nil
+	case "", "latest", "pending", "safe", "finalized":
+		if *head == nil {
+			if p.head == nil {
+				return 0, fmt.Errorf("cannot resolve block tag %q without the head block", block)
+			}
+			number, err := p.head(ctx)
+			if err != nil {
+				return 0, fmt.Errorf("failed to get head block: %w", err)
+			}
+			*head = &number
+		}
+		return **head, nil
+	}
+
+	if !strings.HasPrefix(block, "0x") {
+		return 0, domain.NewValidationError(fmt.Sprintf("invalid block %q", block))
+	}
+	number, err := strconv.ParseUint(block[2:], 16, 64)
+	if err != nil {
+		return 0, domain.NewValidationError(fmt.Sprintf("invalid block %q", block))
+	}
+	return number, nil
+}
+
+// matchesAny reports whether any of the patterns matches the method
+func matchesAny(patterns []string, method string) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" || pattern == method {
+			return true
+		}
+		if ok, _ := path.Match(pattern, method); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -3,36 +3,63 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 
 	"kokka.com/kokka/internal/applications/dtos"
+	"kokka.com/kokka/internal/applications/policy"
 	"kokka.com/kokka/internal/applications/validators"
+	diSvc "kokka.com/kokka/internal/core/di/services"
+	"kokka.com/kokka/internal/core/domain"
 	"kokka.com/kokka/internal/driven-adapter/external/blockchain"
-	"kokka.com/kokka/internal/shared/utils"
+	"kokka.com/kokka/internal/shared/logger"
+	"kokka.com/kokka/internal/shared/tracing"
 )
 
 // BlockchainService handles blockchain-related business logic
 type BlockchainService struct {
-	validator     validators.IBlockchainValidator
-	client        *blockchain.Client
-	decryptionKey string
+	validator      validators.IBlockchainValidator
+	client         *blockchain.Client
+	signerProvider diSvc.ISignerProvider
+	authorizer     policy.Authorizer
+	journal        diSvc.ITransactionJournal
+	rpcGuard       policy.RPCGuard
+	rpcAudit       diSvc.IRPCAuditService
 }
 
 // NewBlockchainService creates a new blockchain service
 func NewBlockchainService(
 	validator validators.IBlockchainValidator,
 	client *blockchain.Client,
-	decryptionKey string,
+	signerProvider diSvc.ISignerProvider,
+	authorizer policy.Authorizer,
+	journal diSvc.ITransactionJournal,
+	rpcGuard policy.RPCGuard,
+	rpcAudit diSvc.IRPCAuditService,
 ) *BlockchainService {
 	return &BlockchainService{
-		validator:     validator,
-		client:        client,
-		decryptionKey: decryptionKey,
+		validator:      validator,
+		client:         client,
+		signerProvider: signerProvider,
+		authorizer:     authorizer,
+		journal:        journal,
+		rpcGuard:       rpcGuard,
+		rpcAudit:       rpcAudit,
 	}
 }
 
 // GetBlockNumber returns the current block number
 func (s *BlockchainService) GetBlockNumber(ctx context.Context) (*dtos.GetBlockNumberResponse, error) {
+	ctx, span := tracing.Start(ctx, "BlockchainService.GetBlockNumber")
+	defer span.End()
+
 	blockNumber, err := s.client.GetBlockNumber(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get block number: %w", err)
@@ -45,6 +72,9 @@ func (s *BlockchainService) GetBlockNumber(ctx context.Context) (*dtos.GetBlockN
 
 // GetGasPrice returns the current gas price
 func (s *BlockchainService) GetGasPrice(ctx context.Context) (*dtos.GetGasPriceResponse, error) {
+	ctx, span := tracing.Start(ctx, "BlockchainService.GetGasPrice")
+	defer span.End()
+
 	gasPrice, err := s.client.GetGasPrice(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price: %w", err)
@@ -57,6 +87,9 @@ func (s *BlockchainService) GetGasPrice(ctx context.Context) (*dtos.GetGasPriceR
 
 // GetChainID returns the chain ID
 func (s *BlockchainService) GetChainID(ctx context.Context) (*dtos.GetChainIDResponse, error) {
+	ctx, span := tracing.Start(ctx, "BlockchainService.GetChainID")
+	defer span.End()
+
 	chainID, err := s.client.GetChainID(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chain ID: %w", err)
@@ -69,9 +102,12 @@ func (s *BlockchainService) GetChainID(ctx context.Context) (*dtos.GetChainIDRes
 
 // GetBalance returns the balance of an address
 func (s *BlockchainService) GetBalance(ctx context.Context, req *dtos.GetBalanceRequest) (*dtos.GetBalanceResponse, error) {
+	ctx, span := tracing.Start(ctx, "BlockchainService.GetBalance")
+	defer span.End()
+
 	// Validate request
 	if err := s.validator.ValidateGetBalanceRequest(req); err != nil {
-		return nil, err
+		return nil, domain.AsValidationError(err)
 	}
 
 	// Default to "latest" if block is not specified
@@ -94,55 +130,64 @@ func (s *BlockchainService) GetBalance(ctx context.Context, req *dtos.GetBalance
 
 // GetBlock returns block details by number
 func (s *BlockchainService) GetBlock(ctx context.Context, req *dtos.GetBlockRequest) (*dtos.GetBlockResponse, error) {
+	ctx, span := tracing.Start(ctx, "BlockchainService.GetBlock")
+	defer span.End()
+
 	// Validate request
 	if err := s.validator.ValidateGetBlockRequest(req); err != nil {
-		return nil, err
+		return nil, domain.AsValidationError(err)
 	}
 
-	resp, err := s.client.GetBlockByNumber(ctx, req.BlockNumber, req.FullTx)
+	block, err := s.client.GetBlockByNumber(ctx, req.BlockNumber, req.FullTx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get block: %w", err)
 	}
 
-	// Unmarshal the result into a generic interface
-	var blockData interface{}
-	if err := json.Unmarshal(resp.Result, &blockData); err != nil {
-		return nil, fmt.Errorf("failed to parse block data: %w", err)
-	}
-
 	return &dtos.GetBlockResponse{
-		Block: blockData,
+		Block: newChainFormat(req.Format).block(block),
 	}, nil
 }
 
 // GetTransaction returns transaction details by hash
 func (s *BlockchainService) GetTransaction(ctx context.Context, req *dtos.GetTransactionRequest) (*dtos.GetTransactionResponse, error) {
+	ctx, span := tracing.Start(ctx, "BlockchainService.GetTransaction")
+	defer span.End()
+
 	// Validate request
 	if err := s.validator.ValidateGetTransactionRequest(req); err != nil {
-		return nil, err
+		return nil, domain.AsValidationError(err)
 	}
 
-	resp, err := s.client.GetTransactionByHash(ctx, req.TxHash)
+	tx, err := s.client.GetTransactionByHash(ctx, req.TxHash)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transaction: %w", err)
 	}
 
-	// Unmarshal the result into a generic interface
-	var txData interface{}
-	if err := json.Unmarshal(resp.Result, &txData); err != nil {
-		return nil, fmt.Errorf("failed to parse transaction data: %w", err)
+	format := newChainFormat(req.Format)
+	resp := &dtos.GetTransactionResponse{
+		Transaction: format.transaction(tx),
+	}
+	if tx == nil || tx.Pending() || !(req.IncludeReceipt || format.human) {
+		return resp, nil
 	}
 
-	return &dtos.GetTransactionResponse{
-		Transaction: txData,
-	}, nil
+	receipt, err := s.client.GetTransactionReceipt(ctx, req.TxHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction receipt: %w", err)
+	}
+	resp.Receipt = format.receipt(receipt)
+	resp.Transfers = format.transfers(receipt)
+	return resp, nil
 }
 
 // CallContract calls a smart contract method (read-only)
 func (s *BlockchainService) CallContract(ctx context.Context, req *dtos.CallContractRequest) (*dtos.CallContractResponse, error) {
+	ctx, span := tracing.Start(ctx, "BlockchainService.CallContract")
+	defer span.End()
+
 	// Validate request
 	if err := s.validator.ValidateCallContractRequest(req); err != nil {
-		return nil, err
+		return nil, domain.AsValidationError(err)
 	}
 
 	// Default to "latest" if block is not specified
@@ -163,9 +208,12 @@ func (s *BlockchainService) CallContract(ctx context.Context, req *dtos.CallCont
 
 // EstimateGas estimates the gas required for a transaction
 func (s *BlockchainService) EstimateGas(ctx context.Context, req *dtos.EstimateGasRequest) (*dtos.EstimateGasResponse, error) {
+	ctx, span := tracing.Start(ctx, "BlockchainService.EstimateGas")
+	defer span.End()
+
 	// Validate request
 	if err := s.validator.ValidateEstimateGasRequest(req); err != nil {
-		return nil, err
+		return nil, domain.AsValidationError(err)
 	}
 
 	gasEstimate, err := s.client.EstimateGas(ctx, req.From, req.To, req.Value, "")
@@ -180,9 +228,12 @@ func (s *BlockchainService) EstimateGas(ctx context.Context, req *dtos.EstimateG
 
 // SendRawTransaction broadcasts a signed transaction to the network
 func (s *BlockchainService) SendRawTransaction(ctx context.Context, req *dtos.SendRawTransactionRequest) (*dtos.SendRawTransactionResponse, error) {
+	ctx, span := tracing.Start(ctx, "BlockchainService.SendRawTransaction")
+	defer span.End()
+
 	// Validate request
 	if err := s.validator.ValidateSendRawTransactionRequest(req); err != nil {
-		return nil, err
+		return nil, domain.AsValidationError(err)
 	}
 
 	txHash, err := s.client.SendRawTransaction(ctx, req.SignedTx)
@@ -197,21 +248,23 @@ func (s *BlockchainService) SendRawTransaction(ctx context.Context, req *dtos.Se
 
 // SignAndSendTransaction signs a transaction on the server and sends it to the blockchain
 func (s *BlockchainService) SignAndSendTransaction(ctx context.Context, req *dtos.SignAndSendTransactionRequest) (*dtos.SignAndSendTransactionResponse, error) {
+	ctx, span := tracing.Start(ctx, "BlockchainService.SignAndSendTransaction")
+	defer span.End()
+
 	// Validate request
 	if err := s.validator.ValidateSignAndSendTransactionRequest(req); err != nil {
-		return nil, err
+		return nil, domain.AsValidationError(err)
 	}
 
-	// Decrypt private key
-	privateKey, err := utils.DecryptCrypto(req.EncryptedPrivateKey, s.decryptionKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt private key: %w", err)
+	// Check the caller may send to this target before any signing
+	if err := s.authorizer.Authorize(ctx, policy.Action{Target: req.To}); err != nil {
+		return nil, err
 	}
 
-	// Create transaction signer
-	signer, err := blockchain.NewTransactionSigner(privateKey, s.client)
+	// Resolve transaction signer from the wallet (or the deprecated encrypted key)
+	signer, err := s.signerProvider.ResolveSigner(ctx, req.WalletID, req.EncryptedPrivateKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create transaction signer: %w", err)
+		return nil, err
 	}
 
 	// Convert DTO to signer request
@@ -224,26 +277,292 @@ func (s *BlockchainService) SignAndSendTransaction(ctx context.Context, req *dto
 		Nonce:    req.Nonce,
 	}
 
+	// Simulate instead of signing and sending; dry runs are not journaled
+	if req.DryRun {
+		sim, err := signer.Simulate(ctx, signerReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to simulate transaction: %w", err)
+		}
+		dryRun, err := dryRunResult(sim)
+		if err != nil {
+			return nil, err
+		}
+		return &dtos.SignAndSendTransactionResponse{
+			FromAddress: signer.GetAddress(),
+			DryRun:      dryRun,
+		}, nil
+	}
+
+	// Record the write in the journal before signing
+	journalID, err := s.journal.Begin(ctx, domain.OperationSignAndSend, req.WalletID, req)
+	if err != nil {
+		return nil, err
+	}
+
 	// Sign and send transaction
-	txHash, err := signer.SignAndSendTransaction(ctx, signerReq)
+	sent, err := signer.SignAndSend(ctx, signerReq)
 	if err != nil {
+		s.journal.Failed(ctx, journalID, sent, err)
 		return nil, fmt.Errorf("failed to sign and send transaction: %w", err)
 	}
+	s.journal.Submitted(ctx, journalID, sent)
 
 	return &dtos.SignAndSendTransactionResponse{
-		TxHash:      txHash,
+		TxHash:      sent.TxHash,
 		FromAddress: signer.GetAddress(),
 	}, nil
 }
 
-// GenericRPCCall allows calling any JSON-RPC method directly
+// SignTransaction signs a transaction on the server without sending it
+// The pending nonce is not reserved: when signing several transactions before sending them, pass explicit nonces
+func (s *BlockchainService) SignTransaction(ctx context.Context, req *dtos.SignTransactionRequest) (*dtos.SignTransactionResponse, error) {
+	ctx, span := tracing.Start(ctx, "BlockchainService.SignTransaction")
+	defer span.End()
+
+	// Validate request
+	if err := s.validator.ValidateSignTransactionRequest(req); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	// Check the caller may send to this target before any signing
+	if err := s.authorizer.Authorize(ctx, policy.Action{Target: req.To}); err != nil {
+		return nil, err
+	}
+
+	// Resolve transaction signer from the wallet (or the deprecated encrypted key)
+	signer, err := s.signerProvider.ResolveSigner(ctx, req.WalletID, req.EncryptedPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record the signature in the journal before signing; the client broadcasts it, so it stays unknown
+	journalID, err := s.journal.Begin(ctx, domain.OperationSign, req.WalletID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := signer.Sign(ctx, &blockchain.SignTransactionRequest{
+		To:       req.To,
+		Value:    req.Value,
+		Data:     req.Data,
+		GasLimit: req.GasLimit,
+		GasPrice: req.GasPrice,
+		Nonce:    req.Nonce,
+	})
+	if err != nil {
+		s.journal.Failed(ctx, journalID, nil, err)
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	s.journal.Signed(ctx, journalID, signed)
+
+	tx := signed.Tx
+	if log := logger.GetLogger(ctx); log != nil {
+		log.SetTxHash(tx.Hash().Hex())
+		log.Infof("signed transaction %s from %s with nonce %d without sending it", tx.Hash().Hex(), signed.From, tx.Nonce())
+	}
+
+	return &dtos.SignTransactionResponse{
+		SignedTx:  signed.Raw,
+		TxHash:    tx.Hash().Hex(),
+		From:      signed.From,
+		To:        tx.To().Hex(),
+		Nonce:     hexutil.EncodeUint64(tx.Nonce()),
+		GasLimit:  hexutil.EncodeUint64(tx.Gas()),
+		GasPrice:  hexutil.EncodeBig(tx.GasPrice()),
+		Value:     hexutil.EncodeBig(tx.Value()),
+		ChainID:   hexutil.EncodeBig(signed.ChainID),
+		MaxFeeWei: new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas()), tx.GasPrice()).String(),
+	}, nil
+}
+
+// BuildTransaction builds an unsigned transaction for an address whose key is not on the server
+// Sign signing_hash (or raw) externally and broadcast the result with SendRawTransaction
+func (s *BlockchainService) BuildTransaction(ctx context.Context, req *dtos.BuildTransactionRequest) (*dtos.BuildTransactionResponse, error) {
+	ctx, span := tracing.Start(ctx, "BlockchainService.BuildTransaction")
+	defer span.End()
+
+	// Validate request
+	if err := s.validator.ValidateBuildTransactionRequest(req); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	tx, chainID, err := s.client.BuildTransaction(ctx, req.From, &blockchain.SignTransactionRequest{
+		To:       req.To,
+		Value:    req.Value,
+		Data:     req.Data,
+		GasLimit: req.GasLimit,
+		GasPrice: req.GasPrice,
+		Nonce:    req.Nonce,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transaction: %w", err)
+	}
+
+	unsignedTx, err := unsignedTransaction(tx, common.HexToAddress(req.From).Hex(), chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas()), tx.GasPrice())
+	return &dtos.BuildTransactionResponse{
+		UnsignedTx:      unsignedTx,
+		EstimatedFeeWei: fee.String(),
+		EstimatedFee:    formatUnits(fee, nativeDecimals),
+	}, nil
+}
+
+// SignMessage signs an EIP-191 personal message, e.g. a login challenge
+func (s *BlockchainService) SignMessage(ctx context.Context, req *dtos.SignMessageRequest) (*dtos.SignatureResponse, error) {
+	ctx, span := tracing.Start(ctx, "BlockchainService.SignMessage")
+	defer span.End()
+
+	// Validate request
+	if err := s.validator.ValidateSignMessageRequest(req); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	message, err := messageBytes(req.Message, req.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	// A personal message has no contract or amount, only the route is checked
+	if err := s.authorizer.Authorize(ctx, policy.Action{}); err != nil {
+		return nil, err
+	}
+
+	// Resolve signer from the wallet (or the deprecated encrypted key)
+	signer, err := s.signerProvider.ResolveSigner(ctx, req.WalletID, req.EncryptedPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := signer.SignMessage(ctx, message)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := blockchain.MessageHash(message)
+	if log := logger.GetLogger(ctx); log != nil {
+		log.Infof("signed personal message %s for %s", hash.Hex(), signer.GetAddress())
+	}
+
+	return &dtos.SignatureResponse{
+		Address:   signer.GetAddress(),
+		Signature: hexutil.Encode(signature),
+		Hash:      hash.Hex(),
+	}, nil
+}
+
+// SignTypedData signs EIP-712 typed data, e.g. an off-chain order
+func (s *BlockchainService) SignTypedData(ctx context.Context, req *dtos.SignTypedDataRequest) (*dtos.SignatureResponse, error) {
+	ctx, span := tracing.Start(ctx, "BlockchainService.SignTypedData")
+	defer span.End()
+
+	// Validate request
+	if err := s.validator.ValidateSignTypedDataRequest(req); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	// Hash before resolving the signer, so malformed data fails without decrypting a key
+	typedData, hash, err := parseTypedData(req.TypedData)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeTypedData(ctx, typedData); err != nil {
+		return nil, err
+	}
+
+	// Resolve signer from the wallet (or the deprecated encrypted key)
+	signer, err := s.signerProvider.ResolveSigner(ctx, req.WalletID, req.EncryptedPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := signer.SignTypedData(ctx, typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	if log := logger.GetLogger(ctx); log != nil {
+		log.Infof("signed typed data %s (%s) for %s", hash.Hex(), typedData.PrimaryType, signer.GetAddress())
+	}
+
+	return &dtos.SignatureResponse{
+		Address:   signer.GetAddress(),
+		Signature: hexutil.Encode(signature),
+		Hash:      hash.Hex(),
+	}, nil
+}
+
+// VerifySignature recovers the address that signed a personal message or typed data
+func (s *BlockchainService) VerifySignature(ctx context.Context, req *dtos.VerifySignatureRequest) (*dtos.VerifySignatureResponse, error) {
+	ctx, span := tracing.Start(ctx, "BlockchainService.VerifySignature")
+	defer span.End()
+
+	// Validate request
+	if err := s.validator.ValidateVerifySignatureRequest(req); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	signature, err := hexutil.Decode(req.Signature)
+	if err != nil {
+		return nil, domain.NewValidationError("invalid signature format (must be hex string with 0x prefix)")
+	}
+
+	var hash common.Hash
+	if req.Message != "" {
+		message, err := messageBytes(req.Message, req.Encoding)
+		if err != nil {
+			return nil, err
+		}
+		hash = blockchain.MessageHash(message)
+	} else {
+		if _, hash, err = parseTypedData(req.TypedData); err != nil {
+			return nil, err
+		}
+	}
+
+	signer, err := blockchain.RecoverSigner(hash, signature)
+	if err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	resp := &dtos.VerifySignatureResponse{
+		Signer: signer.Hex(),
+		Hash:   hash.Hex(),
+	}
+	if req.Address != "" {
+		valid := common.HexToAddress(req.Address) == signer
+		resp.Valid = &valid
+	}
+	return resp, nil
+}
+
+// GenericRPCCall calls a JSON-RPC method the RPC policy allows
 func (s *BlockchainService) GenericRPCCall(ctx context.Context, req *dtos.GenericRPCRequest) (*dtos.GenericRPCResponse, error) {
+	ctx, span := tracing.Start(ctx, "BlockchainService.GenericRPCCall")
+	defer span.End()
+
 	// Validate request
 	if err := s.validator.ValidateGenericRPCRequest(req); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	entry := rpcAuditEntry(req, false)
+	if err := s.rpcGuard.Admit(ctx, 1); err != nil {
+		s.rpcAudit.Record(ctx, []*domain.RPCAuditEntry{entry.denied(err)})
+		return nil, err
+	}
+	if err := s.rpcGuard.CheckCall(ctx, req.Method, req.Params); err != nil {
+		s.rpcAudit.Record(ctx, []*domain.RPCAuditEntry{entry.denied(err)})
 		return nil, err
 	}
 
+	started := time.Now()
 	resp, err := s.client.Call(ctx, req.Method, req.Params)
+	s.rpcAudit.Record(ctx, []*domain.RPCAuditEntry{entry.sent(time.Since(started), err)})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute RPC call: %w", err)
 	}
@@ -258,3 +577,231 @@ func (s *BlockchainService) GenericRPCCall(ctx context.Context, req *dtos.Generi
 		Result: result,
 	}, nil
 }
+
+// GenericRPCBatch forwards a JSON-RPC batch to the node
+// Calls the RPC policy rejects are answered with an error without reaching the node; the rest go as one batch
+func (s *BlockchainService) GenericRPCBatch(ctx context.Context, reqs []*dtos.GenericRPCRequest) (*dtos.GenericRPCBatchResponse, error) {
+	ctx, span := tracing.Start(ctx, "BlockchainService.GenericRPCBatch")
+	defer span.End()
+
+	// Validate request
+	if err := s.validator.ValidateGenericRPCBatchRequest(reqs); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	entries := make([]*rpcAudit, len(reqs))
+	for i, req := range reqs {
+		entries[i] = rpcAuditEntry(req, true)
+	}
+
+	// The quota covers the whole batch
+	if err := s.rpcGuard.Admit(ctx, len(reqs)); err != nil {
+		audit := make([]*domain.RPCAuditEntry, len(entries))
+		for i, entry := range entries {
+			audit[i] = entry.denied(err)
+		}
+		s.rpcAudit.Record(ctx, audit)
+		return nil, err
+	}
+
+	results := make([]*dtos.GenericRPCBatchResult, len(reqs))
+	audit := make([]*domain.RPCAuditEntry, len(reqs))
+	var calls []blockchain.BatchCall
+	var sent []int // Indexes of the calls sent to the node
+	for i, req := range reqs {
+		results[i] = &dtos.GenericRPCBatchResult{}
+		if req != nil {
+			results[i].ID = req.ID
+		}
+
+		if err := s.validator.ValidateGenericRPCRequest(req); err != nil {
+			err = domain.AsValidationError(err)
+			results[i].Error = rejectedRPCError(-32600, err) // Invalid request
+			audit[i] = entries[i].denied(err)
+			continue
+		}
+		if err := s.rpcGuard.CheckCall(ctx, req.Method, req.Params); err != nil {
+			results[i].Error = rejectedRPCError(rejectedRPCCode(err), err)
+			audit[i] = entries[i].denied(err)
+			continue
+		}
+
+		calls = append(calls, blockchain.BatchCall{Method: req.Method, Params: req.Params})
+		sent = append(sent, i)
+	}
+
+	if len(calls) > 0 {
+		started := time.Now()
+		responses, err := s.client.CallBatch(ctx, calls)
+		elapsed := time.Since(started)
+		if err != nil {
+			for _, i := range sent {
+				audit[i] = entries[i].sent(elapsed, err)
+			}
+			s.rpcAudit.Record(ctx, audit)
+			return nil, fmt.Errorf("failed to execute RPC batch: %w", err)
+		}
+
+		for n, i := range sent {
+			resp := responses[n]
+			if resp.IsError() {
+				results[i].Error = &dtos.GenericRPCError{
+					Code:    resp.Error.Code,
+					Message: resp.Error.Message,
+					Data:    resp.Error.Data,
+				}
+				audit[i] = entries[i].sent(elapsed, errors.New(resp.Error.Message))
+				continue
+			}
+			results[i].Result = resp.Result
+			audit[i] = entries[i].sent(elapsed, nil)
+		}
+	}
+
+	s.rpcAudit.Record(ctx, audit)
+	return &dtos.GenericRPCBatchResponse{Results: results}, nil
+}
+
+// rpcAudit is the audit entry of a proxied call before its outcome is known
+type rpcAudit struct {
+	method      string
+	batch       bool
+	paramsBytes int
+}
+
+// rpcAuditEntry starts the audit entry of a proxied call
+func rpcAuditEntry(req *dtos.GenericRPCRequest, batch bool) *rpcAudit {
+	entry := &rpcAudit{batch: batch}
+	if req == nil {
+		return entry
+	}
+	entry.method = req.Method
+	if encoded, err := json.Marshal(req.Params); err == nil {
+		entry.paramsBytes = len(encoded)
+	}
+	return entry
+}
+
+// denied completes the audit entry of a call that was not sent to the node
+func (a *rpcAudit) denied(err error) *domain.RPCAuditEntry {
+	return &domain.RPCAuditEntry{
+		Method:      a.method,
+		Batch:       a.batch,
+		Outcome:     domain.RPCOutcomeDenied,
+		Error:       err.Error(),
+		ParamsBytes: a.paramsBytes,
+	}
+}
+
+// sent completes the audit entry of a call the node answered, or failed
+func (a *rpcAudit) sent(elapsed time.Duration, err error) *domain.RPCAuditEntry {
+	entry := &domain.RPCAuditEntry{
+		Method:      a.method,
+		Batch:       a.batch,
+		Outcome:     domain.RPCOutcomeOK,
+		ParamsBytes: a.paramsBytes,
+		DurationMs:  elapsed.Milliseconds(),
+	}
+	if err != nil {
+		entry.Outcome = domain.RPCOutcomeError
+		entry.Error = err.Error()
+	}
+	return entry
+}
+
+// rejectedRPCCode returns the JSON-RPC error code of a call the RPC policy rejected
+func rejectedRPCCode(err error) int {
+	switch domain.ErrorCodeOf(err) {
+	case domain.ErrorCodeMethodNotAllowed:
+		return -32601 // Method not found
+	case domain.ErrorCodeValidation:
+		return -32602 // Invalid params
+	}
+	return -32603 // Internal error
+}
+
+// rejectedRPCError converts an error that kept a batch call from the node into a JSON-RPC error
+func rejectedRPCError(code int, err error) *dtos.GenericRPCError {
+	return &dtos.GenericRPCError{
+		Code:      code,
+		Message:   err.Error(),
+		ErrorCode: string(domain.ErrorCodeOf(err)),
+	}
+}
+
+// unsignedTransaction converts an unsigned transaction into its DTO, with the payload and hash to sign
+func unsignedTransaction(tx *types.Transaction, from string, chainID *big.Int) (*dtos.UnsignedTransaction, error) {
+	raw, signingHash, err := blockchain.UnsignedPayload(tx, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	to := ""
+	if tx.To() != nil {
+		to = tx.To().Hex()
+	}
+
+	return &dtos.UnsignedTransaction{
+		From:        from,
+		To:          to,
+		Nonce:       hexutil.EncodeUint64(tx.Nonce()),
+		GasLimit:    hexutil.EncodeUint64(tx.Gas()),
+		GasPrice:    hexutil.EncodeBig(tx.GasPrice()),
+		Value:       hexutil.EncodeBig(tx.Value()),
+		Data:        hexutil.Encode(tx.Data()),
+		ChainID:     hexutil.EncodeBig(chainID),
+		Raw:         hexutil.Encode(raw),
+		SigningHash: signingHash.Hex(),
+	}, nil
+}
+
+// messageBytes decodes a personal message in the given encoding ("text" or "hex")
+func messageBytes(message string, encoding string) ([]byte, error) {
+	if encoding != "hex" {
+		return []byte(message), nil
+	}
+	decoded, err := hexutil.Decode(message)
+	if err != nil {
+		return nil, domain.NewValidationError(fmt.Sprintf("invalid hex message: %v", err))
+	}
+	return decoded, nil
+}
+
+// authorizeTypedData checks the caller may sign typed data for its domain
+// The verifying contract is checked like a raw target, and the domain must be bound to the connected chain,
+// so a signature cannot be replayed on a chain the policy does not cover
+func (s *BlockchainService) authorizeTypedData(ctx context.Context, typedData apitypes.TypedData) error {
+	if err := s.authorizer.Authorize(ctx, policy.Action{Target: typedData.Domain.VerifyingContract}); err != nil {
+		return err
+	}
+
+	if typedData.Domain.ChainId == nil {
+		return domain.NewValidationError("typed_data domain must set chainId")
+	}
+	chainIDHex, err := s.client.GetChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	chainID, err := hexutil.DecodeBig(chainIDHex)
+	if err != nil {
+		return fmt.Errorf("failed to parse chain ID: %w", err)
+	}
+	if (*big.Int)(typedData.Domain.ChainId).Cmp(chainID) != 0 {
+		return policy.Unauthorized(fmt.Sprintf("typed data is for chain %s, not the connected chain %s",
+			(*big.Int)(typedData.Domain.ChainId).String(), chainID.String()))
+	}
+	return nil
+}
+
+// parseTypedData parses EIP-712 typed data and returns it with its hash
+func parseTypedData(raw json.RawMessage) (apitypes.TypedData, common.Hash, error) {
+	var typedData apitypes.TypedData
+	if err := json.Unmarshal(raw, &typedData); err != nil {
+		return typedData, common.Hash{}, domain.NewValidationError(fmt.Sprintf("invalid typed_data: %v", err))
+	}
+	hash, err := blockchain.TypedDataHash(typedData)
+	if err != nil {
+		return typedData, common.Hash{}, domain.AsValidationError(err)
+	}
+	return typedData, hash, nil
+}
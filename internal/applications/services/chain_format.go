@@ -0,0 +1,181 @@
+package services
+
+import (
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"kokka.com/kokka/internal/applications/dtos"
+	"kokka.com/kokka/internal/core/domain"
+)
+
+// chainFormat converts chain models into DTOs, hex-encoded as the node returns them or human-friendly
+type chainFormat struct {
+	human bool
+}
+
+// newChainFormat returns the formatter of a request's format
+func newChainFormat(format string) chainFormat {
+	return chainFormat{human: format == dtos.FormatHuman}
+}
+
+// block converts a block, or returns nil for nil
+func (f chainFormat) block(block *domain.Block) *dtos.Block {
+	if block == nil {
+		return nil
+	}
+
+	result := &dtos.Block{
+		Hash:             block.Hash,
+		ParentHash:       block.ParentHash,
+		Timestamp:        f.timestamp(block.Timestamp),
+		Miner:            block.Miner,
+		GasLimit:         f.quantity(block.GasLimit),
+		GasUsed:          f.quantity(block.GasUsed),
+		BaseFeePerGas:    f.amount(block.BaseFeePerGas),
+		Size:             f.quantity(block.Size),
+		ExtraData:        block.ExtraData,
+		TransactionCount: len(block.TransactionHashes),
+	}
+	if block.Number != nil {
+		result.Number = f.quantity(*block.Number)
+	}
+
+	if block.Transactions == nil {
+		result.TransactionHashes = block.TransactionHashes
+		return result
+	}
+	result.Transactions = make([]*dtos.Transaction, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		result.Transactions[i] = f.transaction(tx)
+	}
+	return result
+}
+
+// transaction converts a transaction, or returns nil for nil
+func (f chainFormat) transaction(tx *domain.ChainTransaction) *dtos.Transaction {
+	if tx == nil {
+		return nil
+	}
+
+	result := &dtos.Transaction{
+		Hash:                 tx.Hash,
+		Type:                 f.quantity(tx.Type),
+		Nonce:                f.quantity(tx.Nonce),
+		From:                 tx.From,
+		To:                   tx.To,
+		Value:                f.amount(tx.Value),
+		Gas:                  f.quantity(tx.Gas),
+		GasPrice:             f.amount(tx.GasPrice),
+		MaxFeePerGas:         f.amount(tx.MaxFeePerGas),
+		MaxPriorityFeePerGas: f.amount(tx.MaxPriorityFeePerGas),
+		Input:                tx.Input,
+		ChainID:              f.amount(tx.ChainID),
+		Pending:              tx.Pending(),
+		BlockHash:            tx.BlockHash,
+	}
+	if tx.BlockNumber != nil {
+		result.BlockNumber = f.quantity(*tx.BlockNumber)
+	}
+	if tx.TransactionIndex != nil {
+		result.TransactionIndex = f.quantity(*tx.TransactionIndex)
+	}
+	return result
+}
+
+// receipt converts a receipt, or returns nil for nil
+func (f chainFormat) receipt(receipt *domain.Receipt) *dtos.Receipt {
+	if receipt == nil {
+		return nil
+	}
+
+	result := &dtos.Receipt{
+		TransactionHash:   receipt.TransactionHash,
+		TransactionIndex:  f.quantity(receipt.TransactionIndex),
+		BlockHash:         receipt.BlockHash,
+		BlockNumber:       f.quantity(receipt.BlockNumber),
+		From:              receipt.From,
+		To:                receipt.To,
+		ContractAddress:   receipt.ContractAddress,
+		Status:            f.quantity(receipt.Status),
+		Type:              f.quantity(receipt.Type),
+		GasUsed:           f.quantity(receipt.GasUsed),
+		CumulativeGasUsed: f.quantity(receipt.CumulativeGasUsed),
+		EffectiveGasPrice: f.amount(receipt.EffectiveGasPrice),
+		FeeWei:            f.amount(receipt.Fee()),
+		Logs:              make([]*dtos.EventLog, len(receipt.Logs)),
+	}
+	if f.human {
+		result.Status = "reverted"
+		if receipt.Succeeded() {
+			result.Status = "success"
+		}
+		if fee := receipt.Fee(); fee != nil {
+			result.Fee = formatUnits(fee, nativeDecimals)
+		}
+	}
+
+	for i, log := range receipt.Logs {
+		result.Logs[i] = &dtos.EventLog{
+			Address:         log.Address,
+			Topics:          log.Topics,
+			Data:            log.Data,
+			BlockNumber:     f.quantity(log.BlockNumber),
+			TransactionHash: log.TransactionHash,
+			LogIndex:        f.quantity(log.LogIndex),
+			Removed:         log.Removed,
+		}
+	}
+	return result
+}
+
+// transfers decodes the ERC-20 transfers a transaction emitted
+func (f chainFormat) transfers(receipt *domain.Receipt) []*dtos.TokenTransferEvent {
+	if receipt == nil {
+		return nil
+	}
+
+	transfers := []*dtos.TokenTransferEvent{}
+	for _, log := range receipt.Logs {
+		transfer, ok := decodeTransfer(log)
+		if !ok {
+			continue
+		}
+		transfers = append(transfers, &dtos.TokenTransferEvent{
+			Token:    transfer.Token,
+			From:     transfer.From,
+			To:       transfer.To,
+			Amount:   transfer.Amount,
+			LogIndex: f.quantity(transfer.LogIndex),
+		})
+	}
+	return transfers
+}
+
+// quantity formats a number as a hex quantity, or in decimal
+func (f chainFormat) quantity(value uint64) string {
+	if f.human {
+		return strconv.FormatUint(value, 10)
+	}
+	return hexutil.EncodeUint64(value)
+}
+
+// amount formats a big number as a hex quantity, or in decimal; nil formats as empty
+func (f chainFormat) amount(value *big.Int) string {
+	if value == nil {
+		return ""
+	}
+	if f.human {
+		return value.String()
+	}
+	return hexutil.EncodeBig(value)
+}
+
+// timestamp formats a time as hex-encoded unix seconds, or ISO 8601
+func (f chainFormat) timestamp(value time.Time) string {
+	if f.human {
+		return value.UTC().Format(time.RFC3339)
+	}
+	return hexutil.EncodeUint64(uint64(value.Unix()))
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"math/big"
+	"strings"
+
+	"kokka.com/kokka/internal/applications/dtos"
+	"kokka.com/kokka/internal/driven-adapter/external/blockchain"
+)
+
+// nativeDecimals is the number of decimals of the chain's native token
+const nativeDecimals = 18
+
+// dryRunResult converts a simulated transaction into its response DTO
+func dryRunResult(sim *blockchain.SimulatedTransaction) (*dtos.DryRunResult, error) {
+	tx := sim.Tx
+	unsignedTx, err := unsignedTransaction(tx, sim.From, sim.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dtos.DryRunResult{
+		Success:         sim.Success,
+		ReturnData:      sim.ReturnData,
+		Outputs:         sim.Outputs,
+		Error:           sim.Error,
+		ErrorCode:       string(sim.ErrorCode),
+		RevertReason:    sim.RevertReason,
+		Revert:          sim.Revert,
+		GasLimit:        new(big.Int).SetUint64(tx.Gas()).String(),
+		GasPrice:        tx.GasPrice().String(),
+		EstimatedFeeWei: sim.EstimatedFee.String(),
+		EstimatedFee:    formatUnits(sim.EstimatedFee, nativeDecimals),
+		UnsignedTx:      unsignedTx,
+	}
+
+	for _, simLog := range sim.Logs {
+		result.Events = append(result.Events, &dtos.SimulatedEvent{
+			Address: simLog.Address,
+			Event:   simLog.Event,
+			Args:    simLog.Args,
+			Topics:  simLog.Topics,
+			Data:    simLog.Data,
+		})
+	}
+
+	return result, nil
+}
+
+// formatUnits renders an integer amount with the given number of decimals, e.g. 42000000000000 wei as "0.000042"
+func formatUnits(amount *big.Int, decimals int) string {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	whole, frac := new(big.Int).QuoRem(amount, unit, new(big.Int))
+	if frac.Sign() == 0 {
+		return whole.String()
+	}
+
+	fracStr := frac.String()
+	fracStr = strings.Repeat("0", decimals-len(fracStr)) + fracStr
+	return whole.String() + "." + strings.TrimRight(fracStr, "0")
+}
@@ -0,0 +1,315 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"kokka.com/kokka/internal/applications/dtos"
+	diRepo "kokka.com/kokka/internal/core/di/repositories"
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/driven-adapter/external/blockchain"
+	"kokka.com/kokka/internal/shared/buildinfo"
+	"kokka.com/kokka/internal/shared/lifecycle"
+	"kokka.com/kokka/internal/shared/metrics"
+	"kokka.com/kokka/internal/shared/utils"
+)
+
+// Pinger checks the database connection; *database.DB implements it
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// HealthService reports liveness, readiness and diagnostics of the server and its dependencies
+type HealthService struct {
+	client       *blockchain.Client
+	db           Pinger                        // nil without a database
+	transactions diRepo.ITransactionRepository // nil without a database
+	keyring      *utils.Keyring
+	drainer      *lifecycle.Drainer
+	chainID      string // Expected chain ID in decimal, empty to skip the check
+	maxBlockAge  time.Duration
+	checkTimeout time.Duration
+	startedAt    time.Time
+}
+
+// NewHealthService creates a new health service; uptime is counted from its creation
+func NewHealthService(
+	client *blockchain.Client,
+	db Pinger,
+	transactions diRepo.ITransactionRepository,
+	keyring *utils.Keyring,
+	drainer *lifecycle.Drainer,
+	chainID string,
+	maxBlockAge time.Duration,
+	checkTimeout time.Duration,
+) (*HealthService, error) {
+	if chainID != "" {
+		if _, ok := new(big.Int).SetString(chainID, 10); !ok {
+			return nil, fmt.Errorf("invalid chain ID %q", chainID)
+		}
+	}
+	if maxBlockAge <= 0 || checkTimeout <= 0 {
+		return nil, fmt.Errorf("block age and check timeout must be positive")
+	}
+
+	return &HealthService{
+		client:       client,
+		db:           db,
+		transactions: transactions,
+		keyring:      keyring,
+		drainer:      drainer,
+		chainID:      chainID,
+		maxBlockAge:  maxBlockAge,
+		checkTimeout: checkTimeout,
+		startedAt:    time.Now().UTC(),
+	}, nil
+}
+
+// Liveness reports that the process is serving requests; it checks no dependency
+func (s *HealthService) Liveness(ctx context.Context) (*dtos.LivenessResponse, error) {
+	return &dtos.LivenessResponse{
+		Status:        "ok",
+		UptimeSeconds: int64(time.Since(s.startedAt).Seconds()),
+	}, nil
+}
+
+// Readiness checks the dependencies needed to serve traffic
+// The server is not ready when any check fails, and never while draining for shutdown
+func (s *HealthService) Readiness(ctx context.Context) (*dtos.ReadinessResponse, error) {
+	result := &dtos.ReadinessResponse{
+		Status:   dtos.ReadinessReady,
+		InFlight: s.drainer.InFlight(),
+	}
+	if s.drainer.Draining() {
+		result.Status = dtos.ReadinessDraining
+		return result, nil
+	}
+
+	var wg sync.WaitGroup
+	var rpcChecks []*dtos.HealthCheck
+	var dbCheck *dtos.HealthCheck
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		rpcChecks = s.checkChain(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		dbCheck = s.checkDatabase(ctx)
+	}()
+	wg.Wait()
+
+	result.Checks = append(rpcChecks, dbCheck, s.checkKeys())
+	for _, check := range result.Checks {
+		if check.Status == dtos.HealthCheckFailed {
+			result.Status = dtos.ReadinessNotReady
+		}
+	}
+	return result, nil
+}
+
+// Diagnostics returns build info, uptime, RPC endpoint latencies and the nonce state of active signers
+func (s *HealthService) Diagnostics(ctx context.Context) (*dtos.DiagnosticsResponse, error) {
+	build := buildinfo.Get()
+
+	return &dtos.DiagnosticsResponse{
+		Build: &dtos.BuildInfo{
+			Version:      build.Version,
+			Revision:     build.Revision,
+			RevisionTime: build.RevisionTime,
+			Modified:     build.Modified,
+			GoVersion:    build.GoVersion,
+		},
+		StartedAt:     s.startedAt,
+		UptimeSeconds: int64(time.Since(s.startedAt).Seconds()),
+		Draining:      s.drainer.Draining(),
+		InFlight:      s.drainer.InFlight(),
+		RPCEndpoints:  []*dtos.RPCEndpointDiagnostics{s.measureEndpoint(ctx)},
+		Nonces:        s.nonceState(ctx),
+	}, nil
+}
+
+// checkChain checks RPC reachability, the chain ID and the freshness of the head block
+func (s *HealthService) checkChain(ctx context.Context) []*dtos.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, s.checkTimeout)
+	defer cancel()
+
+	rpc := &dtos.HealthCheck{Name: "rpc", Status: dtos.HealthCheckOK, Detail: metrics.EndpointLabel(s.client.Endpoint())}
+	chain := &dtos.HealthCheck{Name: "chain_id", Status: dtos.HealthCheckSkipped}
+	head := &dtos.HealthCheck{Name: "head_block", Status: dtos.HealthCheckOK}
+	checks := []*dtos.HealthCheck{rpc, chain, head}
+
+	started := time.Now()
+	chainIDHex, err := s.client.GetChainID(ctx)
+	rpc.LatencyMs = time.Since(started).Milliseconds()
+	if err != nil {
+		rpc.Status, rpc.Error = dtos.HealthCheckFailed, err.Error()
+		chain.Status = dtos.HealthCheckFailed
+		head.Status = dtos.HealthCheckFailed
+		chain.Error = "rpc unreachable"
+		head.Error = "rpc unreachable"
+		return checks
+	}
+
+	actual, ok := new(big.Int).SetString(strings.TrimPrefix(chainIDHex, "0x"), 16)
+	switch {
+	case !ok:
+		chain.Status, chain.Error = dtos.HealthCheckFailed, fmt.Sprintf("invalid chain ID %q from the node", chainIDHex)
+	case s.chainID == "":
+		chain.Detail = "chain " + actual.String() + ", BLOCKCHAIN_CHAIN_ID not set"
+	case actual.String() != s.chainID:
+		chain.Status, chain.Error = dtos.HealthCheckFailed, fmt.Sprintf("node is on chain %s, expected %s", actual, s.chainID)
+	default:
+		chain.Status, chain.Detail = dtos.HealthCheckOK, "chain "+actual.String()
+	}
+
+	started = time.Now()
+	age, number, err := s.headBlockAge(ctx)
+	head.LatencyMs = time.Since(started).Milliseconds()
+	switch {
+	case err != nil:
+		head.Status, head.Error = dtos.HealthCheckFailed, err.Error()
+	case age > s.maxBlockAge:
+		head.Status, head.Error = dtos.HealthCheckFailed, fmt.Sprintf("head block %d is %s old, node may be out of sync", number, age.Round(time.Second))
+	default:
+		head.Detail = fmt.Sprintf("block %d, %s old", number, age.Round(time.Second))
+	}
+
+	return checks
+}
+
+// headBlockAge returns how long ago the latest block was produced, and its number
+func (s *HealthService) headBlockAge(ctx context.Context) (time.Duration, uint64, error) {
+	block, err := s.client.GetBlockByNumber(ctx, "latest", false)
+	if err != nil {
+		return 0, 0, err
+	}
+	if block == nil || block.Number == nil {
+		return 0, 0, fmt.Errorf("node returned no head block")
+	}
+
+	return time.Since(block.Timestamp), *block.Number, nil
+}
+
+// checkDatabase pings the database
+func (s *HealthService) checkDatabase(ctx context.Context) *dtos.HealthCheck {
+	check := &dtos.HealthCheck{Name: "database", Status: dtos.HealthCheckOK}
+	if s.db == nil {
+		check.Status, check.Detail = dtos.HealthCheckSkipped, "no database configured"
+		return check
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.checkTimeout)
+	defer cancel()
+
+	started := time.Now()
+	err := s.db.PingContext(ctx)
+	check.LatencyMs = time.Since(started).Milliseconds()
+	if err != nil {
+		check.Status, check.Error = dtos.HealthCheckFailed, err.Error()
+	}
+	return check
+}
+
+// checkKeys checks that a decryption key is configured for client-encrypted private keys
+func (s *HealthService) checkKeys() *dtos.HealthCheck {
+	check := &dtos.HealthCheck{Name: "decryption_keys", Status: dtos.HealthCheckOK}
+	if s.keyring.ActiveKeyID() == "" {
+		check.Status, check.Error = dtos.HealthCheckFailed, "no decryption key configured"
+		return check
+	}
+	check.Detail = fmt.Sprintf("active key-id %s, %d keys", s.keyring.ActiveKeyID(), len(s.keyring.KeyIDs()))
+	return check
+}
+
+// measureEndpoint measures the latency of the RPC endpoint
+func (s *HealthService) measureEndpoint(ctx context.Context) *dtos.RPCEndpointDiagnostics {
+	ctx, cancel := context.WithTimeout(ctx, s.checkTimeout)
+	defer cancel()
+
+	result := &dtos.RPCEndpointDiagnostics{Endpoint: metrics.EndpointLabel(s.client.Endpoint()), Status: dtos.HealthCheckOK}
+	started := time.Now()
+	blockNumber, err := s.client.GetBlockNumber(ctx)
+	result.LatencyMs = time.Since(started).Milliseconds()
+	if err != nil {
+		result.Status, result.Error = dtos.HealthCheckFailed, err.Error()
+		return result
+	}
+	result.BlockNumber = hexToDecimal(blockNumber)
+	return result
+}
+
+// nonceState compares the node's nonces with the unfinished journal entries of each signer
+// Signers take the node's pending nonce when they sign, so a pending nonce below the highest
+// journaled nonce means transactions were dropped from the mempool
+func (s *HealthService) nonceState(ctx context.Context) *dtos.NonceDiagnostics {
+	result := &dtos.NonceDiagnostics{
+		Source:  "node pending nonce (eth_getTransactionCount) at signing time",
+		Signers: []*dtos.SignerNonceDiagnostics{},
+	}
+	if s.transactions == nil {
+		result.Error = "transaction journal is disabled without a database"
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.checkTimeout)
+	defer cancel()
+
+	signers := map[string]*dtos.SignerNonceDiagnostics{}
+	for _, txStatus := range []string{domain.TransactionStatusPending, domain.TransactionStatusSubmitted, domain.TransactionStatusUnknown} {
+		transactions, err := s.transactions.List(ctx, &domain.TransactionFilter{Status: txStatus, Limit: monitorBatchSize})
+		if err != nil {
+			result.Error = fmt.Sprintf("failed to list %s transactions: %v", txStatus, err)
+			return result
+		}
+		for _, tx := range transactions {
+			if tx.SignerAddress == "" {
+				continue
+			}
+			address := strings.ToLower(tx.SignerAddress)
+			signer, ok := signers[address]
+			if !ok {
+				signer = &dtos.SignerNonceDiagnostics{Address: address}
+				signers[address] = signer
+			}
+			switch txStatus {
+			case domain.TransactionStatusPending:
+				signer.JournalPending++
+			case domain.TransactionStatusSubmitted:
+				signer.JournalSubmitted++
+			default:
+				signer.JournalUnknown++
+			}
+			if tx.Nonce != nil && (signer.HighestNonce == nil || *tx.Nonce > *signer.HighestNonce) {
+				nonce := *tx.Nonce
+				signer.HighestNonce = &nonce
+			}
+		}
+	}
+
+	for _, signer := range signers {
+		latest, err := s.client.GetTransactionCount(ctx, signer.Address, "latest")
+		if err != nil {
+			signer.Error = err.Error()
+		} else {
+			signer.LatestNonce = hexToDecimal(latest)
+		}
+		pending, err := s.client.GetTransactionCount(ctx, signer.Address, "pending")
+		if err != nil {
+			signer.Error = err.Error()
+		} else {
+			signer.PendingNonce = hexToDecimal(pending)
+		}
+		result.Signers = append(result.Signers, signer)
+	}
+	sort.Slice(result.Signers, func(i, j int) bool {
+		return result.Signers[i].Address < result.Signers[j].Address
+	})
+
+	return result
+}
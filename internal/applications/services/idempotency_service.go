@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"kokka.com/kokka/internal/applications/policy"
+	"kokka.com/kokka/internal/applications/validators"
+	diRepo "kokka.com/kokka/internal/core/di/repositories"
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/shared/logger"
+)
+
+// IdempotencyService stores the outcome of write requests by Idempotency-Key
+// Keys are scoped to the calling API client and kept for the configured TTL
+type IdempotencyService struct {
+	validator validators.IIdempotencyValidator
+	records   diRepo.IIdempotencyRepository
+	ttl       time.Duration
+}
+
+// NewIdempotencyService creates a new idempotency service
+func NewIdempotencyService(validator validators.IIdempotencyValidator, records diRepo.IIdempotencyRepository, ttl time.Duration) *IdempotencyService {
+	return &IdempotencyService{
+		validator: validator,
+		records:   records,
+		ttl:       ttl,
+	}
+}
+
+// Begin claims the key for this request, or returns the completed record to replay
+// A key held by a different request (by fingerprint) or by a request still running is a conflict
+func (s *IdempotencyService) Begin(ctx context.Context, key string, fingerprint string) (*domain.IdempotencyRecord, error) {
+	// Validate key
+	if err := s.validator.ValidateIdempotencyKey(key); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	now := time.Now().UTC()
+	existing, err := s.records.Reserve(ctx, &domain.IdempotencyRecord{
+		ClientID:    clientID(ctx),
+		Key:         key,
+		Fingerprint: fingerprint,
+		Status:      domain.IdempotencyStatusInProgress,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.ttl),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if existing == nil {
+		return nil, nil
+	}
+
+	if existing.Fingerprint != fingerprint {
+		return nil, domain.ErrIdempotencyKeyReused
+	}
+	if existing.Status != domain.IdempotencyStatusCompleted {
+		return nil, domain.ErrIdempotencyKeyInProgress
+	}
+	return existing, nil
+}
+
+// Complete stores the response so retries replay it
+func (s *IdempotencyService) Complete(ctx context.Context, key string, responseCode int, responseBody []byte) {
+	// Store even if the client went away, a retry must not sign again
+	if err := s.records.Complete(context.WithoutCancel(ctx), clientID(ctx), key, responseCode, responseBody); err != nil {
+		logger.Error("idempotency: failed to complete key %s: %v", key, err)
+	}
+}
+
+// Release frees the key when the request did not produce a response, so it can be retried
+func (s *IdempotencyService) Release(ctx context.Context, key string) {
+	if err := s.records.Release(context.WithoutCancel(ctx), clientID(ctx), key); err != nil {
+		logger.Error("idempotency: failed to release key %s: %v", key, err)
+	}
+}
+
+// PurgeExpired deletes records past their TTL
+func (s *IdempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	deleted, err := s.records.DeleteExpired(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge idempotency keys: %w", err)
+	}
+	return deleted, nil
+}
+
+// clientID returns the calling API client, keys of anonymous callers share one scope
+func clientID(ctx context.Context) string {
+	if identity := policy.GetIdentity(ctx); identity != nil {
+		return identity.ClientID
+	}
+	return policy.AnonymousClientID
+}
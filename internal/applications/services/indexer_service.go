@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"kokka.com/kokka/internal/applications/dtos"
+	diRepo "kokka.com/kokka/internal/core/di/repositories"
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/driven-adapter/external/blockchain"
+	"kokka.com/kokka/internal/shared/tracing"
+)
+
+// transferEventTopic is keccak256("Transfer(address,address,uint256)")
+const transferEventTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+// transferCursor names the indexer cursor of token transfers
+const transferCursor = "token_transfers"
+
+// IndexerService indexes ERC-20 Transfer events of the configured tokens
+// Only blocks with enough confirmations are indexed, so reorgs do not leave stale transfers
+type IndexerService struct {
+	client        *blockchain.Client
+	transfers     diRepo.ITokenTransferRepository
+	tokens        []string
+	startBlock    uint64
+	batchBlocks   uint64
+	confirmations uint64
+}
+
+// NewIndexerService creates a new token transfer indexer
+func NewIndexerService(
+	client *blockchain.Client,
+	transfers diRepo.ITokenTransferRepository,
+	tokens []string,
+	startBlock uint64,
+	batchBlocks uint64,
+	confirmations uint64,
+) (*IndexerService, error) {
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("at least one token is required")
+	}
+	for _, token := range tokens {
+		if !common.IsHexAddress(token) {
+			return nil, fmt.Errorf("invalid token address: %s", token)
+		}
+	}
+	if batchBlocks == 0 {
+		return nil, fmt.Errorf("batch size must be positive")
+	}
+
+	return &IndexerService{
+		client:        client,
+		transfers:     transfers,
+		tokens:        tokens,
+		startBlock:    startBlock,
+		batchBlocks:   batchBlocks,
+		confirmations: confirmations,
+	}, nil
+}
+
+// IndexTransfers indexes confirmed blocks since the last run, one batch at a time until caught up
+func (s *IndexerService) IndexTransfers(ctx context.Context) (*dtos.IndexResult, error) {
+	ctx, span := tracing.Start(ctx, "IndexerService.IndexTransfers")
+	defer span.End()
+
+	headHex, err := s.client.GetBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	head, err := parseQuantity(headHex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse block number: %w", err)
+	}
+	if head < s.confirmations {
+		return &dtos.IndexResult{}, nil
+	}
+	safe := head - s.confirmations
+
+	from := s.startBlock
+	last, ok, err := s.transfers.GetCursor(ctx, transferCursor)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		from = last + 1
+	}
+
+	result := &dtos.IndexResult{FromBlock: from}
+	for from <= safe && ctx.Err() == nil {
+		to := min(from+s.batchBlocks-1, safe)
+
+		transfers, err := s.fetchTransfers(ctx, from, to)
+		if err != nil {
+			return result, err
+		}
+		if err := s.transfers.SaveBatch(ctx, transfers, transferCursor, to); err != nil {
+			return result, err
+		}
+
+		result.ToBlock = to
+		result.Transfers += len(transfers)
+		from = to + 1
+	}
+
+	return result, nil
+}
+
+// fetchTransfers returns the Transfer events of the tokens in a block range
+func (s *IndexerService) fetchTransfers(ctx context.Context, from uint64, to uint64) ([]*domain.TokenTransfer, error) {
+	logs, err := s.client.GetLogs(ctx, hexQuantity(from), hexQuantity(to), s.tokens, []interface{}{transferEventTopic})
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := make([]*domain.TokenTransfer, 0, len(logs))
+	for _, log := range logs {
+		if transfer, ok := decodeTransfer(log); ok && !log.Removed {
+			transfers = append(transfers, transfer)
+		}
+	}
+
+	return transfers, nil
+}
+
+// decodeTransfer decodes an ERC-20 Transfer event
+// ok is false for other events, including ERC-721 transfers that carry the token id as a fourth topic
+func decodeTransfer(log *domain.EventLog) (*domain.TokenTransfer, bool) {
+	if len(log.Topics) != 3 || !strings.EqualFold(log.Topics[0], transferEventTopic) {
+		return nil, false
+	}
+
+	amount, ok := new(big.Int).SetString(strings.TrimPrefix(log.Data, "0x"), 16)
+	if !ok {
+		amount = new(big.Int)
+	}
+
+	return &domain.TokenTransfer{
+		ID:          fmt.Sprintf("%s:%d", strings.ToLower(log.TransactionHash), log.LogIndex),
+		Token:       strings.ToLower(log.Address),
+		From:        strings.ToLower(common.HexToAddress(log.Topics[1]).Hex()),
+		To:          strings.ToLower(common.HexToAddress(log.Topics[2]).Hex()),
+		Amount:      amount.String(),
+		BlockNumber: log.BlockNumber,
+		TxHash:      strings.ToLower(log.TransactionHash),
+		LogIndex:    log.LogIndex,
+	}, true
+}
+
+// parseQuantity parses a hex quantity such as "0x1b4"
+func parseQuantity(value string) (uint64, error) {
+	return strconv.ParseUint(strings.TrimPrefix(value, "0x"), 16, 64)
+}
+
+// hexQuantity formats a number as a hex quantity
+func hexQuantity(value uint64) string {
+	return "0x" + strconv.FormatUint(value, 16)
+}
@@ -0,0 +1,409 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"kokka.com/kokka/internal/applications/dtos"
+	"kokka.com/kokka/internal/applications/validators"
+	diRepo "kokka.com/kokka/internal/core/di/repositories"
+	diSvc "kokka.com/kokka/internal/core/di/services"
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/shared/logger"
+	"kokka.com/kokka/internal/shared/tracing"
+	"kokka.com/kokka/internal/shared/utils"
+)
+
+// jobLockGrace keeps a job locked a little past its timeout, so a slow run is not overlapped by another instance
+const jobLockGrace = time.Minute
+
+// ErrJobRunning is returned when a job is triggered while a run holds its lock
+var ErrJobRunning = errors.New("job is already running")
+
+// scheduledJob is a registered background job
+type scheduledJob struct {
+	name     string
+	spec     string
+	schedule cron.Schedule
+	timeout  time.Duration
+	run      diSvc.JobFunc
+}
+
+// JobService runs background jobs on cron or interval schedules
+// A job runs on one server instance at a time: each run takes a lock in the job store first
+// Paused jobs skip their scheduled runs but can still be triggered manually
+type JobService struct {
+	validator validators.IJobValidator
+	store     diRepo.IJobRepository
+	owner     string
+
+	mutex     sync.RWMutex
+	jobs      map[string]*scheduledJob
+	names     []string           // Registration order
+	ctx       context.Context    // Scheduling, cancelled when Stop starts
+	cancel    context.CancelFunc //
+	runCtx    context.Context    // Running jobs, cancelled when Stop gives up waiting
+	runCancel context.CancelFunc //
+	wg        sync.WaitGroup
+}
+
+// NewJobService creates a new job service; owner identifies this server instance in locks and run history
+func NewJobService(validator validators.IJobValidator, store diRepo.IJobRepository, owner string) *JobService {
+	return &JobService{
+		validator: validator,
+		store:     store,
+		owner:     owner,
+		jobs:      make(map[string]*scheduledJob),
+	}
+}
+
+// Register adds a job with a cron expression ("*/5 * * * *") or an interval ("@every 30s")
+func (s *JobService) Register(name string, spec string, timeout time.Duration, run diSvc.JobFunc) error {
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return fmt.Errorf("job %s: invalid schedule %q: %w", name, spec, err)
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("job %s: timeout must be positive", name)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.jobs[name]; ok {
+		return fmt.Errorf("job %s is already registered", name)
+	}
+	s.jobs[name] = &scheduledJob{name: name, spec: spec, schedule: schedule, timeout: timeout, run: run}
+	s.names = append(s.names, name)
+	return nil
+}
+
+// Start runs every registered job on its schedule until Stop
+func (s *JobService) Start() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.ctx != nil {
+		return
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.runCtx, s.runCancel = context.WithCancel(context.Background())
+
+	for _, name := range s.names {
+		job := s.jobs[name]
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.loop(s.ctx, s.runCtx, job)
+		}()
+	}
+}
+
+// Stop ends the schedules and waits for running jobs to finish
+// Jobs still running when ctx is done are cancelled, then Stop waits for them to return
+func (s *JobService) Stop(ctx context.Context) {
+	// Cancelling under the lock orders it with TriggerJob, so no run is added once Wait starts
+	s.mutex.Lock()
+	cancel, runCancel := s.cancel, s.runCancel
+	if cancel != nil {
+		cancel()
+	}
+	s.mutex.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		logger.Warn("jobs: shutdown deadline reached, cancelling running jobs")
+		runCancel()
+		<-done
+	}
+	runCancel()
+}
+
+// ListJobs returns the registered jobs with their schedule, state and last run
+func (s *JobService) ListJobs(ctx context.Context) (*dtos.ListJobsResponse, error) {
+	s.mutex.RLock()
+	names := append([]string(nil), s.names...)
+	s.mutex.RUnlock()
+
+	jobs := make([]*dtos.JobResponse, 0, len(names))
+	for _, name := range names {
+		job, err := s.jobResponse(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return &dtos.ListJobsResponse{Owner: s.owner, Jobs: jobs}, nil
+}
+
+// TriggerJob starts a run of the job now, in the background
+func (s *JobService) TriggerJob(ctx context.Context, req *dtos.JobRequest) (*dtos.JobRunResponse, error) {
+	// Validate request
+	if err := s.validator.ValidateJobRequest(req); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	job, err := s.job(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// The run is counted before Stop can cancel, so Stop waits for it
+	s.mutex.RLock()
+	if s.ctx == nil || s.ctx.Err() != nil {
+		s.mutex.RUnlock()
+		return nil, fmt.Errorf("job scheduler is not running")
+	}
+	runCtx := s.runCtx
+	s.wg.Add(1)
+	s.mutex.RUnlock()
+
+	run, err := s.begin(ctx, job, domain.JobTriggerManual)
+	if err != nil {
+		s.wg.Done()
+		return nil, err
+	}
+
+	// The run outlives the admin request
+	go func() {
+		defer s.wg.Done()
+		s.execute(runCtx, job, run)
+	}()
+
+	return &dtos.JobRunResponse{Run: run}, nil
+}
+
+// PauseJob stops scheduled runs of the job on every server instance
+func (s *JobService) PauseJob(ctx context.Context, req *dtos.JobRequest) (*dtos.JobResponse, error) {
+	return s.setPaused(ctx, req, true)
+}
+
+// ResumeJob restarts scheduled runs of a paused job
+func (s *JobService) ResumeJob(ctx context.Context, req *dtos.JobRequest) (*dtos.JobResponse, error) {
+	return s.setPaused(ctx, req, false)
+}
+
+// ListJobRuns returns the run history, newest first
+func (s *JobService) ListJobRuns(ctx context.Context, req *dtos.ListJobRunsRequest) (*dtos.ListJobRunsResponse, error) {
+	// Validate request
+	if err := s.validator.ValidateListJobRunsRequest(req); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	if req.Name != "" {
+		if _, err := s.job(req.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	runs, err := s.store.ListRuns(ctx, req.Name, req.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job runs: %w", err)
+	}
+
+	return &dtos.ListJobRunsResponse{Runs: runs}, nil
+}
+
+// PruneRuns deletes run history older than the retention
+func (s *JobService) PruneRuns(ctx context.Context, retention time.Duration) (int64, error) {
+	deleted, err := s.store.DeleteRunsBefore(ctx, time.Now().UTC().Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune job runs: %w", err)
+	}
+	return deleted, nil
+}
+
+// loop runs a job at each scheduled time until ctx is cancelled; runs get runCtx
+func (s *JobService) loop(ctx context.Context, runCtx context.Context, job *scheduledJob) {
+	for {
+		timer := time.NewTimer(time.Until(job.schedule.Next(time.Now())))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		state, err := s.store.GetState(ctx, job.name)
+		if err != nil {
+			logger.Error("job %s: failed to read state: %v", job.name, err)
+			continue
+		}
+		if state.Paused {
+			continue
+		}
+
+		run, err := s.begin(ctx, job, domain.JobTriggerSchedule)
+		if errors.Is(err, ErrJobRunning) {
+			// Another instance (or a manual trigger) has it
+			continue
+		}
+		if err != nil {
+			logger.Error("job %s: %v", job.name, err)
+			continue
+		}
+		s.execute(runCtx, job, run)
+	}
+}
+
+// begin takes the job lock and records the run
+func (s *JobService) begin(ctx context.Context, job *scheduledJob, trigger string) (*domain.JobRun, error) {
+	now := time.Now().UTC()
+	acquired, err := s.store.AcquireLock(ctx, job.name, s.owner, now.Add(job.timeout+jobLockGrace))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock job: %w", err)
+	}
+	if !acquired {
+		return nil, ErrJobRunning
+	}
+
+	id, err := utils.NewID("run_")
+	if err != nil {
+		s.release(job)
+		return nil, err
+	}
+
+	run := &domain.JobRun{
+		ID:        id,
+		Job:       job.name,
+		Trigger:   trigger,
+		Status:    domain.JobRunStatusRunning,
+		Owner:     s.owner,
+		StartedAt: now,
+	}
+	if err := s.store.CreateRun(ctx, run); err != nil {
+		s.release(job)
+		return nil, fmt.Errorf("failed to record job run: %w", err)
+	}
+
+	return run, nil
+}
+
+// execute runs the job with its timeout, records the outcome and releases the lock
+func (s *JobService) execute(ctx context.Context, job *scheduledJob, run *domain.JobRun) {
+	defer s.release(job)
+
+	ctx, span := tracing.Start(ctx, "job "+job.name, tracing.AttrJob.String(job.name))
+	defer span.End()
+
+	runCtx, cancel := context.WithTimeout(ctx, job.timeout)
+	detail, err := s.call(runCtx, job)
+	cancel()
+
+	finishedAt := time.Now().UTC()
+	run.FinishedAt = &finishedAt
+	run.Detail = detail
+	run.Status = domain.JobRunStatusSucceeded
+	if err != nil {
+		run.Status = domain.JobRunStatusFailed
+		run.Error = err.Error()
+		logger.Error("job %s: run %s failed: %v", job.name, run.ID, err)
+		tracing.Fail(ctx, err)
+	}
+
+	// Record the outcome even when the scheduler is stopping
+	if err := s.store.FinishRun(context.WithoutCancel(ctx), run); err != nil {
+		logger.Error("job %s: failed to record run %s: %v", job.name, run.ID, err)
+	}
+}
+
+// call runs the job body, turning a panic into a failed run
+func (s *JobService) call(ctx context.Context, job *scheduledJob) (detail string, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+	return job.run(ctx)
+}
+
+// release frees the job lock
+func (s *JobService) release(job *scheduledJob) {
+	if err := s.store.ReleaseLock(context.Background(), job.name, s.owner); err != nil {
+		logger.Error("job %s: failed to release lock: %v", job.name, err)
+	}
+}
+
+// setPaused pauses or resumes a job
+func (s *JobService) setPaused(ctx context.Context, req *dtos.JobRequest, paused bool) (*dtos.JobResponse, error) {
+	// Validate request
+	if err := s.validator.ValidateJobRequest(req); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	if _, err := s.job(req.Name); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.SetPaused(ctx, req.Name, paused); err != nil {
+		return nil, fmt.Errorf("failed to update job: %w", err)
+	}
+
+	return s.jobResponse(ctx, req.Name)
+}
+
+// job returns a registered job by name
+func (s *JobService) job(name string) (*scheduledJob, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	job, ok := s.jobs[name]
+	if !ok {
+		return nil, fmt.Errorf("job %s not found", name)
+	}
+	return job, nil
+}
+
+// jobResponse describes a job with its shared state and last run
+func (s *JobService) jobResponse(ctx context.Context, name string) (*dtos.JobResponse, error) {
+	job, err := s.job(name)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.store.GetState(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job state: %w", err)
+	}
+
+	runs, err := s.store.ListRuns(ctx, name, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job runs: %w", err)
+	}
+
+	now := time.Now().UTC()
+	result := &dtos.JobResponse{
+		Name:     job.name,
+		Schedule: job.spec,
+		Timeout:  job.timeout.String(),
+		Paused:   state.Paused,
+		Running:  state.LockedUntil.After(now),
+	}
+	if result.Running {
+		result.LockedBy = state.LockedBy
+	}
+	if !state.Paused {
+		next := job.schedule.Next(now)
+		result.NextRunAt = &next
+	}
+	if len(runs) > 0 {
+		result.LastRun = runs[0]
+	}
+	return result, nil
+}
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"kokka.com/kokka/internal/applications/dtos"
+	"kokka.com/kokka/internal/applications/policy"
+	"kokka.com/kokka/internal/applications/validators"
+	diRepo "kokka.com/kokka/internal/core/di/repositories"
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/driven-adapter/external/blockchain"
+	"kokka.com/kokka/internal/shared/logger"
+	"kokka.com/kokka/internal/shared/metrics"
+	"kokka.com/kokka/internal/shared/response"
+	"kokka.com/kokka/internal/shared/tracing"
+	"kokka.com/kokka/internal/shared/utils"
+)
+
+// secretPayloadFields are removed from request payloads before they are journaled
+var secretPayloadFields = []string{"encrypted_private_key", "private_key", "passphrase", "mnemonic"}
+
+// journalLabels are the metric labels of a write between Begin and its outcome
+type journalLabels struct {
+	kind  string
+	token string
+}
+
+// JournalService records kokka-initiated writes in the transaction journal
+// With a nil repository the journal is disabled and only metrics are recorded
+type JournalService struct {
+	validator    validators.IJournalValidator
+	transactions diRepo.ITransactionRepository
+
+	mutex  sync.Mutex
+	labels map[string]journalLabels // By journal ID, until Submitted or Failed
+}
+
+// NewJournalService creates a new journal service
+func NewJournalService(validator validators.IJournalValidator, transactions diRepo.ITransactionRepository) *JournalService {
+	return &JournalService{
+		validator:    validator,
+		transactions: transactions,
+		labels:       make(map[string]journalLabels),
+	}
+}
+
+// Begin records a pending write before it is signed
+// An error here aborts the write, so nothing is signed without a journal entry
+func (s *JournalService) Begin(ctx context.Context, operation string, walletID string, payload any) (string, error) {
+	id, err := utils.NewID("txn_")
+	if err != nil {
+		return "", err
+	}
+
+	sanitized, err := sanitizePayload(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode journal payload: %w", err)
+	}
+
+	s.mutex.Lock()
+	s.labels[id] = journalLabels{kind: operation, token: payloadToken(sanitized)}
+	s.mutex.Unlock()
+
+	if s.transactions == nil {
+		return id, nil
+	}
+
+	requestedBy := policy.AnonymousClientID
+	if identity := policy.GetIdentity(ctx); identity != nil {
+		requestedBy = identity.ClientID
+	}
+
+	err = s.transactions.Create(ctx, &domain.Transaction{
+		ID:          id,
+		Operation:   operation,
+		Status:      domain.TransactionStatusPending,
+		RequestedBy: requestedBy,
+		WalletID:    walletID,
+		Payload:     sanitized,
+	})
+	if err != nil {
+		s.untrack(id)
+		return "", fmt.Errorf("failed to journal transaction: %w", err)
+	}
+
+	return id, nil
+}
+
+// Submitted records that the transaction was broadcast
+func (s *JournalService) Submitted(ctx context.Context, id string, sent *blockchain.SentTransaction) {
+	if labels, ok := s.untrack(id); ok {
+		metrics.IncTransactionSubmitted(labels.kind, labels.token)
+	}
+
+	update := &domain.TransactionUpdate{Status: domain.TransactionStatusSubmitted}
+	if sent != nil {
+		update.SignerAddress = sent.From
+		update.Nonce = &sent.Nonce
+		update.TxHash = sent.TxHash
+		tracing.Annotate(ctx, tracing.AttrTxHash.String(sent.TxHash))
+		response.RecordTxHash(ctx, sent.TxHash)
+	}
+	tracing.Annotate(ctx, tracing.AttrJournalID.String(id))
+	s.transition(ctx, id, update)
+}
+
+// Signed records a transaction signed for the client to broadcast
+// It stays unknown until the transaction monitor sees it on the node, or reconciles it failed
+func (s *JournalService) Signed(ctx context.Context, id string, signed *blockchain.SignedTransaction) {
+	s.untrack(id)
+
+	nonce := signed.Tx.Nonce()
+	tracing.Annotate(ctx, tracing.AttrTxHash.String(signed.Tx.Hash().Hex()), tracing.AttrJournalID.String(id))
+	s.transition(ctx, id, &domain.TransactionUpdate{
+		Status:        domain.TransactionStatusUnknown,
+		SignerAddress: signed.From,
+		Nonce:         &nonce,
+		TxHash:        signed.Tx.Hash().Hex(),
+		Detail:        "signed, not broadcast",
+	})
+}
+
+// Failed records that the write failed; sent is set when the transaction was signed before the failure
+// A signed transaction may have reached the network even though sending failed, so it is recorded
+// unknown with its hash, for the transaction monitor to settle
+func (s *JournalService) Failed(ctx context.Context, id string, sent *blockchain.SentTransaction, cause error) {
+	s.untrack(id)
+	tracing.Fail(ctx, cause)
+
+	update := &domain.TransactionUpdate{Status: domain.TransactionStatusFailed, Error: cause.Error()}
+	if sent != nil {
+		update.Status = domain.TransactionStatusUnknown
+		update.SignerAddress = sent.From
+		update.Nonce = &sent.Nonce
+		update.TxHash = sent.TxHash
+		tracing.Annotate(ctx, tracing.AttrTxHash.String(sent.TxHash))
+		response.RecordTxHash(ctx, sent.TxHash)
+	}
+	s.transition(ctx, id, update)
+}
+
+// ListTransactions returns journal entries, newest first
+func (s *JournalService) ListTransactions(ctx context.Context, req *dtos.ListJournalTransactionsRequest) (*dtos.ListJournalTransactionsResponse, error) {
+	ctx, span := tracing.Start(ctx, "JournalService.ListTransactions")
+	defer span.End()
+
+	// Validate request
+	if err := s.validator.ValidateListJournalTransactionsRequest(req); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	if s.transactions == nil {
+		return nil, fmt.Errorf("transaction journal is not configured")
+	}
+
+	transactions, err := s.transactions.List(ctx, &domain.TransactionFilter{
+		Status:      req.Status,
+		Operation:   req.Operation,
+		RequestedBy: req.RequestedBy,
+		Limit:       req.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+
+	return &dtos.ListJournalTransactionsResponse{Transactions: transactions}, nil
+}
+
+// GetTransaction returns a journal entry and its status transitions
+func (s *JournalService) GetTransaction(ctx context.Context, req *dtos.GetJournalTransactionRequest) (*dtos.JournalTransactionResponse, error) {
+	ctx, span := tracing.Start(ctx, "JournalService.GetTransaction")
+	defer span.End()
+
+	// Validate request
+	if err := s.validator.ValidateGetJournalTransactionRequest(req); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	if s.transactions == nil {
+		return nil, fmt.Errorf("transaction journal is not configured")
+	}
+
+	var transaction *domain.Transaction
+	var err error
+	if req.ID != "" {
+		transaction, err = s.transactions.GetByID(ctx, req.ID)
+	} else {
+		transaction, err = s.transactions.GetByTxHash(ctx, req.TxHash)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	events, err := s.transactions.ListEvents(ctx, transaction.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction events: %w", err)
+	}
+
+	return &dtos.JournalTransactionResponse{Transaction: transaction, Events: events}, nil
+}
+
+// transition records a status transition; failures are logged, as the write has already happened
+func (s *JournalService) transition(ctx context.Context, id string, update *domain.TransactionUpdate) {
+	if s.transactions == nil || id == "" {
+		return
+	}
+
+	// The outcome must be recorded even if the client has gone away
+	if err := s.transactions.Transition(context.WithoutCancel(ctx), id, update); err != nil {
+		if log := logger.GetLogger(ctx); log != nil {
+			log.Errorf("failed to journal transaction %s status %s: %v", id, update.Status, err)
+		} else {
+			logger.Error("failed to journal transaction %s status %s: %v", id, update.Status, err)
+		}
+	}
+}
+
+// untrack returns and forgets the metric labels of a write
+func (s *JournalService) untrack(id string) (journalLabels, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	labels, ok := s.labels[id]
+	delete(s.labels, id)
+	return labels, ok
+}
+
+// payloadToken returns the contract a journaled write acts on, empty for plain transactions
+func payloadToken(payload string) string {
+	var fields struct {
+		ContractAddress string `json:"contract_address"`
+	}
+	if err := json.Unmarshal([]byte(payload), &fields); err != nil {
+		return ""
+	}
+	return strings.ToLower(fields.ContractAddress)
+}
+
+// sanitizePayload encodes a request as JSON without secret fields
+func sanitizePayload(payload any) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return string(data), nil // Not an object, nothing to strip
+	}
+	for _, field := range secretPayloadFields {
+		delete(fields, field)
+	}
+
+	data, err = json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+
+	"kokka.com/kokka/internal/applications/dtos"
+	"kokka.com/kokka/internal/applications/validators"
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/shared/tracing"
+	"kokka.com/kokka/internal/shared/utils"
+)
+
+// KeyService handles envelope key management
+type KeyService struct {
+	validator validators.IKeyValidator
+	keyring   *utils.Keyring
+}
+
+// NewKeyService creates a new key service
+func NewKeyService(validator validators.IKeyValidator, keyring *utils.Keyring) *KeyService {
+	return &KeyService{
+		validator: validator,
+		keyring:   keyring,
+	}
+}
+
+// GetKeyringInfo returns the configured key-ids and the active key-id
+func (s *KeyService) GetKeyringInfo(ctx context.Context) (*dtos.KeyringInfoResponse, error) {
+	ctx, span := tracing.Start(ctx, "KeyService.GetKeyringInfo")
+	defer span.End()
+
+	return &dtos.KeyringInfoResponse{
+		ActiveKeyID: s.keyring.ActiveKeyID(),
+		KeyIDs:      s.keyring.KeyIDs(),
+	}, nil
+}
+
+// RewrapKeys re-encrypts legacy or rotated ciphertexts under the active key
+// Each ciphertext succeeds or fails on its own so one bad input does not block a migration batch
+func (s *KeyService) RewrapKeys(ctx context.Context, req *dtos.RewrapKeysRequest) (*dtos.RewrapKeysResponse, error) {
+	ctx, span := tracing.Start(ctx, "KeyService.RewrapKeys")
+	defer span.End()
+
+	// Validate request
+	if err := s.validator.ValidateRewrapKeysRequest(req); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	result := &dtos.RewrapKeysResponse{
+		ActiveKeyID: s.keyring.ActiveKeyID(),
+		Results:     make([]*dtos.RewrapResult, 0, len(req.Ciphertexts)),
+	}
+	for _, ciphertext := range req.Ciphertexts {
+		rewrapped, info, err := s.keyring.Rewrap(ciphertext)
+		if err != nil {
+			result.Results = append(result.Results, &dtos.RewrapResult{Error: err.Error()})
+			continue
+		}
+		result.Results = append(result.Results, &dtos.RewrapResult{
+			Ciphertext:    rewrapped,
+			PreviousKeyID: info.KeyID,
+			Legacy:        info.Legacy,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+
+	"kokka.com/kokka/internal/applications/dtos"
+	"kokka.com/kokka/internal/applications/policy"
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/shared/logger"
+)
+
+// LogService changes the process-wide log level at runtime
+// The change is not persisted; a restart goes back to LOG_LEVEL
+type LogService struct{}
+
+// NewLogService creates a new log service
+func NewLogService() *LogService {
+	return &LogService{}
+}
+
+// GetLogLevel returns the current minimum log level
+func (s *LogService) GetLogLevel(ctx context.Context) (*dtos.LogLevelResponse, error) {
+	return &dtos.LogLevelResponse{Level: logger.Level()}, nil
+}
+
+// SetLogLevel changes the minimum log level of all loggers
+func (s *LogService) SetLogLevel(ctx context.Context, req *dtos.SetLogLevelRequest) (*dtos.LogLevelResponse, error) {
+	if req == nil || req.Level == "" {
+		return nil, domain.NewValidationError("level is required")
+	}
+
+	previous := logger.Level()
+	if err := logger.SetLevel(req.Level); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	client := "anonymous"
+	if identity := policy.GetIdentity(ctx); identity != nil {
+		client = identity.ClientID
+	}
+	logger.Warn("log level changed from %s to %s by %s", previous, logger.Level(), client)
+
+	return &dtos.LogLevelResponse{Level: logger.Level(), PreviousLevel: previous}, nil
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"kokka.com/kokka/internal/applications/dtos"
+	"kokka.com/kokka/internal/applications/policy"
+	"kokka.com/kokka/internal/applications/validators"
+	diRepo "kokka.com/kokka/internal/core/di/repositories"
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/shared/logger"
+	"kokka.com/kokka/internal/shared/tracing"
+	"kokka.com/kokka/internal/shared/utils"
+)
+
+// RPCAuditService records which client called which JSON-RPC methods through the proxy
+// Every call is logged; with a repository the calls are also kept for GET /admin/rpc-audit
+type RPCAuditService struct {
+	validator validators.IRPCAuditValidator
+	entries   diRepo.IRPCAuditRepository
+}
+
+// NewRPCAuditService creates a new RPC audit service
+func NewRPCAuditService(validator validators.IRPCAuditValidator, entries diRepo.IRPCAuditRepository) *RPCAuditService {
+	return &RPCAuditService{
+		validator: validator,
+		entries:   entries,
+	}
+}
+
+// Record fills in the caller of the entries, then logs and stores them
+// The calls were already made, so a failure to store them is only logged
+func (s *RPCAuditService) Record(ctx context.Context, entries []*domain.RPCAuditEntry) {
+	clientID := policy.AnonymousClientID
+	if identity := policy.GetIdentity(ctx); identity != nil {
+		clientID = identity.ClientID
+	}
+	requestID := logger.RequestID(ctx)
+	now := time.Now().UTC()
+
+	log := logger.GetLogger(ctx)
+	for _, entry := range entries {
+		entry.ClientID = clientID
+		entry.RequestID = requestID
+		entry.CreatedAt = now
+		if id, err := utils.NewID("rpc_"); err == nil {
+			entry.ID = id
+		}
+
+		message := fmt.Sprintf("rpc audit: client %s called %s: %s in %dms", clientID, entry.Method, entry.Outcome, entry.DurationMs)
+		if entry.Error != "" {
+			message += ": " + entry.Error
+		}
+		if log != nil {
+			log.Infof("%s", message)
+		} else {
+			logger.Info("%s", message)
+		}
+	}
+
+	if s.entries == nil || len(entries) == 0 {
+		return
+	}
+
+	// The calls must be recorded even if the client has gone away
+	if err := s.entries.Insert(context.WithoutCancel(ctx), entries); err != nil {
+		if log != nil {
+			log.Errorf("failed to record rpc audit entries: %v", err)
+		} else {
+			logger.Error("failed to record rpc audit entries: %v", err)
+		}
+	}
+}
+
+// ListEntries returns the recorded calls, newest first
+func (s *RPCAuditService) ListEntries(ctx context.Context, req *dtos.ListRPCAuditRequest) (*dtos.ListRPCAuditResponse, error) {
+	ctx, span := tracing.Start(ctx, "RPCAuditService.ListEntries")
+	defer span.End()
+
+	// Validate request
+	if err := s.validator.ValidateListRPCAuditRequest(req); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	if s.entries == nil {
+		return nil, fmt.Errorf("rpc audit trail is not configured")
+	}
+
+	entries, err := s.entries.List(ctx, &domain.RPCAuditFilter{
+		ClientID: req.ClientID,
+		Method:   req.Method,
+		Outcome:  req.Outcome,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rpc audit entries: %w", err)
+	}
+
+	return &dtos.ListRPCAuditResponse{Entries: entries}, nil
+}
+
+// Prune removes recorded calls older than the retention
+func (s *RPCAuditService) Prune(ctx context.Context, retention time.Duration) (int64, error) {
+	if s.entries == nil {
+		return 0, nil
+	}
+
+	deleted, err := s.entries.DeleteBefore(ctx, time.Now().UTC().Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune rpc audit entries: %w", err)
+	}
+	return deleted, nil
+}
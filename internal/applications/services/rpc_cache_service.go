@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"kokka.com/kokka/internal/applications/dtos"
+	"kokka.com/kokka/internal/applications/policy"
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/driven-adapter/external/blockchain"
+	"kokka.com/kokka/internal/shared/logger"
+)
+
+// RPCCacheService reports on and purges the JSON-RPC read cache
+type RPCCacheService struct {
+	cache *blockchain.Cache // nil when RPC_CACHE_ENABLED is off
+}
+
+// NewRPCCacheService creates a new RPC cache service
+func NewRPCCacheService(cache *blockchain.Cache) *RPCCacheService {
+	return &RPCCacheService{
+		cache: cache,
+	}
+}
+
+// GetStats returns the content and hit rate of the cache
+func (s *RPCCacheService) GetStats(ctx context.Context) (*dtos.RPCCacheStatsResponse, error) {
+	if s.cache == nil {
+		return &dtos.RPCCacheStatsResponse{Enabled: false}, nil
+	}
+
+	stats := s.cache.Stats()
+	resp := &dtos.RPCCacheStatsResponse{
+		Enabled:   true,
+		Entries:   stats.Entries,
+		Contracts: stats.Contracts,
+		Hits:      stats.Hits,
+		Misses:    stats.Misses,
+		HeadBlock: stats.HeadBlock,
+	}
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		resp.HitRate = float64(stats.Hits) / float64(lookups)
+	}
+	return resp, nil
+}
+
+// Purge drops cached results, all of them or the contract reads of one address
+func (s *RPCCacheService) Purge(ctx context.Context, req *dtos.PurgeRPCCacheRequest) (*dtos.PurgeRPCCacheResponse, error) {
+	if req == nil {
+		return nil, domain.NewValidationError("request cannot be nil")
+	}
+	if req.Address != "" && !common.IsHexAddress(req.Address) {
+		return nil, domain.NewValidationError("invalid address format")
+	}
+	if s.cache == nil {
+		return &dtos.PurgeRPCCacheResponse{Purged: 0}, nil
+	}
+
+	purged := s.cache.Purge(req.Address)
+
+	client := "anonymous"
+	if identity := policy.GetIdentity(ctx); identity != nil {
+		client = identity.ClientID
+	}
+	scope := "all entries"
+	if req.Address != "" {
+		scope = "entries of " + req.Address
+	}
+	logger.Warn("rpc cache: %s purged by %s (%d dropped)", scope, client, purged)
+
+	return &dtos.PurgeRPCCacheResponse{Purged: purged}, nil
+}
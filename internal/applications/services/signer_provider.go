@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"kokka.com/kokka/internal/applications/policy"
+	diRepo "kokka.com/kokka/internal/core/di/repositories"
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/driven-adapter/external/blockchain"
+	"kokka.com/kokka/internal/shared/logger"
+	"kokka.com/kokka/internal/shared/metrics"
+	"kokka.com/kokka/internal/shared/utils"
+)
+
+// SignerProvider resolves transaction signers from custodial wallets or client-sent encrypted keys
+type SignerProvider struct {
+	client             *blockchain.Client
+	authorizer         policy.Authorizer
+	wallets            diRepo.IWalletRepository // nil when the wallet subsystem is not configured
+	hdWallet           *blockchain.HDWallet     // nil when no HD mnemonic is configured
+	masterKey          []byte
+	keyring            *utils.Keyring // Decrypts client-sent encrypted keys
+	keystoreDir        string         // Directory holding keystore wallet files
+	keystorePassphrase string         // Passphrase for keystore wallets
+	remoteURL          string         // kokka-signer endpoint for remote wallets
+	remoteAuthToken    string
+}
+
+// NewSignerProvider creates a new signer provider
+func NewSignerProvider(
+	client *blockchain.Client,
+	authorizer policy.Authorizer,
+	wallets diRepo.IWalletRepository,
+	hdWallet *blockchain.HDWallet,
+	masterKey []byte,
+	keyring *utils.Keyring,
+	keystoreDir string,
+	keystorePassphrase string,
+	remoteURL string,
+	remoteAuthToken string,
+) *SignerProvider {
+	return &SignerProvider{
+		client:             client,
+		authorizer:         authorizer,
+		wallets:            wallets,
+		hdWallet:           hdWallet,
+		masterKey:          masterKey,
+		keyring:            keyring,
+		keystoreDir:        keystoreDir,
+		keystorePassphrase: keystorePassphrase,
+		remoteURL:          remoteURL,
+		remoteAuthToken:    remoteAuthToken,
+	}
+}
+
+// ResolveSigner returns a transaction signer for the wallet ID or the encrypted private key
+// A wallet may only be used by the client that created it, or by a role that lists it
+func (p *SignerProvider) ResolveSigner(ctx context.Context, walletID string, encryptedPrivateKey string) (*blockchain.TransactionSigner, error) {
+	if walletID != "" {
+		wallet, err := p.loadWallet(ctx, walletID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := authorizeWallet(ctx, p.authorizer, wallet); err != nil {
+			return nil, err
+		}
+
+		signer, err := p.WalletSigner(ctx, wallet)
+		if err != nil {
+			return nil, err
+		}
+		return blockchain.NewTransactionSignerWithSigner(signer, p.client), nil
+	}
+
+	if log := logger.GetLogger(ctx); log != nil {
+		log.Warn("encrypted_private_key is deprecated, use wallet_id instead")
+	}
+
+	// Decrypt private key
+	privateKey, err := p.keyring.OpenString(encryptedPrivateKey)
+	if err != nil {
+		metrics.IncDecryptionFailure("client_key")
+		return nil, fmt.Errorf("failed to decrypt private key: %w", err)
+	}
+
+	// Create transaction signer
+	signer, err := blockchain.NewTransactionSigner(privateKey, p.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction signer: %w", err)
+	}
+
+	return signer, nil
+}
+
+// ResolveWalletSigner returns a transaction signer for a wallet the server itself signs with,
+// such as deposit and gas wallets of the sweeper; the caller is not checked
+func (p *SignerProvider) ResolveWalletSigner(ctx context.Context, walletID string) (*blockchain.TransactionSigner, error) {
+	wallet, err := p.loadWallet(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := p.WalletSigner(ctx, wallet)
+	if err != nil {
+		return nil, err
+	}
+	return blockchain.NewTransactionSignerWithSigner(signer, p.client), nil
+}
+
+// loadWallet returns the wallet with the given ID
+func (p *SignerProvider) loadWallet(ctx context.Context, walletID string) (*domain.Wallet, error) {
+	if p.wallets == nil {
+		return nil, fmt.Errorf("wallet subsystem is not configured")
+	}
+
+	wallet, err := p.wallets.GetByID(ctx, walletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load wallet %s: %w", walletID, err)
+	}
+	return wallet, nil
+}
+
+// WalletSigner returns the signer backing a wallet, based on its kind
+func (p *SignerProvider) WalletSigner(ctx context.Context, wallet *domain.Wallet) (blockchain.Signer, error) {
+	switch wallet.Kind {
+	case domain.WalletKindGenerated, domain.WalletKindImported:
+		privateKey, err := openPrivateKey(wallet.EncryptedKey, p.masterKey)
+		if err != nil {
+			metrics.IncDecryptionFailure("wallet_key")
+			return nil, fmt.Errorf("failed to decrypt wallet key: %w", err)
+		}
+		signer, err := blockchain.NewLocalSigner(privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create local signer: %w", err)
+		}
+		return signer, nil
+
+	case domain.WalletKindDerived:
+		if p.hdWallet == nil {
+			return nil, fmt.Errorf("HD wallet is not configured")
+		}
+		if wallet.DerivationIndex == nil {
+			return nil, fmt.Errorf("wallet %s has no derivation index", wallet.ID)
+		}
+		privateKey, err := p.hdWallet.DeriveKey(*wallet.DerivationIndex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive wallet key: %w", err)
+		}
+		if crypto.PubkeyToAddress(privateKey.PublicKey).Hex() != wallet.Address {
+			return nil, fmt.Errorf("wallet %s does not match the configured mnemonic", wallet.ID)
+		}
+		signer, err := blockchain.NewLocalSigner(hex.EncodeToString(crypto.FromECDSA(privateKey)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create local signer: %w", err)
+		}
+		return signer, nil
+
+	case domain.WalletKindKeystore:
+		if p.keystorePassphrase == "" {
+			return nil, fmt.Errorf("keystore signer is not configured")
+		}
+		signer, err := blockchain.NewKeystoreSigner(p.keystoreDir, wallet.KeystorePath, p.keystorePassphrase)
+		if err != nil {
+			metrics.IncDecryptionFailure("keystore")
+			return nil, fmt.Errorf("failed to open keystore: %w", err)
+		}
+		return signer, nil
+
+	case domain.WalletKindRemote:
+		if p.remoteURL == "" {
+			return nil, fmt.Errorf("remote signer is not configured")
+		}
+		signer, err := blockchain.NewRemoteSigner(p.remoteURL, p.remoteAuthToken, wallet.Address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create remote signer: %w", err)
+		}
+		return signer, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported wallet kind %q", wallet.Kind)
+	}
+}
+
+// authorizeWallet checks the caller may use the wallet: its creator, or a role that lists it
+// Without a policy no caller is identified, so no one may use a custodial wallet
+func authorizeWallet(ctx context.Context, authorizer policy.Authorizer, wallet *domain.Wallet) error {
+	if policy.GetIdentity(ctx) == nil {
+		return policy.Unauthorized("custodial wallets require an identified client")
+	}
+	return authorizer.Authorize(ctx, policy.Action{Wallet: wallet.ID, WalletOwner: wallet.CreatedBy})
+}
+
+// sealPrivateKey encrypts a hex private key under the wallet master key
+func sealPrivateKey(privateKeyHex string, masterKey []byte) (string, error) {
+	sealed, err := utils.EncryptAESGCM([]byte(privateKeyHex), masterKey)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// openPrivateKey decrypts a private key sealed by sealPrivateKey
+func openPrivateKey(encryptedKey string, masterKey []byte) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(encryptedKey)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := utils.DecryptAESGCM(sealed, masterKey)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
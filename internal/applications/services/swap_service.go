@@ -6,16 +6,21 @@ import (
 	"math/big"
 
 	"kokka.com/kokka/internal/applications/dtos"
+	"kokka.com/kokka/internal/applications/policy"
 	"kokka.com/kokka/internal/applications/validators"
+	diSvc "kokka.com/kokka/internal/core/di/services"
+	"kokka.com/kokka/internal/core/domain"
 	"kokka.com/kokka/internal/driven-adapter/external/blockchain"
-	"kokka.com/kokka/internal/shared/utils"
+	"kokka.com/kokka/internal/shared/tracing"
 )
 
 // SwapService handles swap business logic
 type SwapService struct {
 	validator          validators.ISwapValidator
 	client             *blockchain.Client
-	decryptionKey      string
+	signerProvider     diSvc.ISignerProvider
+	authorizer         policy.Authorizer
+	journal            diSvc.ITransactionJournal
 	readOnlySwapClient *blockchain.SwapClient
 }
 
@@ -23,7 +28,9 @@ type SwapService struct {
 func NewSwapService(
 	validator validators.ISwapValidator,
 	client *blockchain.Client,
-	decryptionKey string,
+	signerProvider diSvc.ISignerProvider,
+	authorizer policy.Authorizer,
+	journal diSvc.ITransactionJournal,
 ) (*SwapService, error) {
 	// Create read-only swap client for quote queries (no signer needed)
 	readOnlyClient, err := blockchain.NewSwapClient(client, nil)
@@ -34,16 +41,21 @@ func NewSwapService(
 	return &SwapService{
 		validator:          validator,
 		client:             client,
-		decryptionKey:      decryptionKey,
+		signerProvider:     signerProvider,
+		authorizer:         authorizer,
+		journal:            journal,
 		readOnlySwapClient: readOnlyClient,
 	}, nil
 }
 
 // Swap executes a token swap
 func (s *SwapService) Swap(ctx context.Context, req *dtos.SwapTokenRequest) (*dtos.SwapTokenResponse, error) {
+	ctx, span := tracing.Start(ctx, "SwapService.Swap")
+	defer span.End()
+
 	// Validate request
 	if err := s.validator.ValidateSwapTokenRequest(req); err != nil {
-		return nil, err
+		return nil, domain.AsValidationError(err)
 	}
 
 	// Parse amount
@@ -52,16 +64,15 @@ func (s *SwapService) Swap(ctx context.Context, req *dtos.SwapTokenRequest) (*dt
 		return nil, fmt.Errorf("failed to parse amount_in: %w", err)
 	}
 
-	// Decrypt private key
-	privateKey, err := utils.DecryptCrypto(req.EncryptedPrivateKey, s.decryptionKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt private key: %w", err)
+	// Check the caller may use this pool and amount before any signing
+	if err := s.authorizer.Authorize(ctx, policy.Action{Pool: req.ContractAddress, Amount: amountIn}); err != nil {
+		return nil, err
 	}
 
-	// Create transaction signer
-	signer, err := blockchain.NewTransactionSigner(privateKey, s.client)
+	// Resolve transaction signer from the wallet (or the deprecated encrypted key)
+	signer, err := s.signerProvider.ResolveSigner(ctx, req.WalletID, req.EncryptedPrivateKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create transaction signer: %w", err)
+		return nil, err
 	}
 
 	// Create swap client
@@ -71,7 +82,8 @@ func (s *SwapService) Swap(ctx context.Context, req *dtos.SwapTokenRequest) (*dt
 	}
 
 	// Execute swap transaction based on direction
-	var txHash string
+	var sent *blockchain.SentTransaction
+	var sim *blockchain.SimulatedTransaction
 	var amountOut *big.Int
 
 	if req.Direction == "AtoB" {
@@ -81,10 +93,24 @@ func (s *SwapService) Swap(ctx context.Context, req *dtos.SwapTokenRequest) (*dt
 			return nil, fmt.Errorf("failed to get quote for AtoB swap: %w", err)
 		}
 
-		// Execute swap A for B
-		txHash, err = swapClient.SwapAforB(ctx, req.ContractAddress, amountIn)
-		if err != nil {
-			return nil, fmt.Errorf("failed to execute AtoB swap: %w", err)
+		if req.DryRun {
+			// Simulate instead of signing and sending; dry runs are not journaled
+			sim, err = swapClient.SimulateSwapAforB(ctx, req.ContractAddress, amountIn)
+			if err != nil {
+				return nil, fmt.Errorf("failed to simulate AtoB swap: %w", err)
+			}
+		} else {
+			// Execute swap A for B
+			journalID, err := s.journal.Begin(ctx, domain.OperationSwap, req.WalletID, req)
+			if err != nil {
+				return nil, err
+			}
+			sent, err = swapClient.SwapAforB(ctx, req.ContractAddress, amountIn)
+			if err != nil {
+				s.journal.Failed(ctx, journalID, sent, err)
+				return nil, fmt.Errorf("failed to execute AtoB swap: %w", err)
+			}
+			s.journal.Submitted(ctx, journalID, sent)
 		}
 	} else { // BtoA
 		// Get expected output amount before swapping
@@ -93,10 +119,24 @@ func (s *SwapService) Swap(ctx context.Context, req *dtos.SwapTokenRequest) (*dt
 			return nil, fmt.Errorf("failed to get quote for BtoA swap: %w", err)
 		}
 
-		// Execute swap B for A
-		txHash, err = swapClient.SwapBforA(ctx, req.ContractAddress, amountIn)
-		if err != nil {
-			return nil, fmt.Errorf("failed to execute BtoA swap: %w", err)
+		if req.DryRun {
+			// Simulate instead of signing and sending; dry runs are not journaled
+			sim, err = swapClient.SimulateSwapBforA(ctx, req.ContractAddress, amountIn)
+			if err != nil {
+				return nil, fmt.Errorf("failed to simulate BtoA swap: %w", err)
+			}
+		} else {
+			// Execute swap B for A
+			journalID, err := s.journal.Begin(ctx, domain.OperationSwap, req.WalletID, req)
+			if err != nil {
+				return nil, err
+			}
+			sent, err = swapClient.SwapBforA(ctx, req.ContractAddress, amountIn)
+			if err != nil {
+				s.journal.Failed(ctx, journalID, sent, err)
+				return nil, fmt.Errorf("failed to execute BtoA swap: %w", err)
+			}
+			s.journal.Submitted(ctx, journalID, sent)
 		}
 	}
 
@@ -121,22 +161,33 @@ func (s *SwapService) Swap(ctx context.Context, req *dtos.SwapTokenRequest) (*dt
 		toToken = tokenA
 	}
 
-	return &dtos.SwapTokenResponse{
-		TxHash:          txHash,
+	resp := &dtos.SwapTokenResponse{
 		ContractAddress: req.ContractAddress,
 		AmountIn:        amountIn.String(),
 		AmountOut:       amountOut.String(),
 		FromToken:       fromToken,
 		ToToken:         toToken,
 		Direction:       req.Direction,
-	}, nil
+	}
+	if sim != nil {
+		if resp.DryRun, err = dryRunResult(sim); err != nil {
+			return nil, err
+		}
+	} else {
+		resp.TxHash = sent.TxHash
+	}
+
+	return resp, nil
 }
 
 // GetQuote returns a quote for a swap without executing it
 func (s *SwapService) GetQuote(ctx context.Context, req *dtos.GetSwapQuoteRequest) (*dtos.GetSwapQuoteResponse, error) {
+	ctx, span := tracing.Start(ctx, "SwapService.GetQuote")
+	defer span.End()
+
 	// Validate request
 	if err := s.validator.ValidateGetSwapQuoteRequest(req); err != nil {
-		return nil, err
+		return nil, domain.AsValidationError(err)
 	}
 
 	// Parse amount
@@ -176,9 +227,12 @@ func (s *SwapService) GetQuote(ctx context.Context, req *dtos.GetSwapQuoteReques
 
 // GetSwapInfo returns information about a swap contract
 func (s *SwapService) GetSwapInfo(ctx context.Context, req *dtos.GetSwapInfoRequest) (*dtos.GetSwapInfoResponse, error) {
+	ctx, span := tracing.Start(ctx, "SwapService.GetSwapInfo")
+	defer span.End()
+
 	// Validate request
 	if err := s.validator.ValidateGetSwapInfoRequest(req); err != nil {
-		return nil, err
+		return nil, domain.AsValidationError(err)
 	}
 
 	// Get token addresses
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"kokka.com/kokka/internal/applications/dtos"
+	"kokka.com/kokka/internal/applications/policy"
+	diRepo "kokka.com/kokka/internal/core/di/repositories"
+	diSvc "kokka.com/kokka/internal/core/di/services"
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/driven-adapter/external/blockchain"
+	"kokka.com/kokka/internal/shared/logger"
+	"kokka.com/kokka/internal/shared/tracing"
+)
+
+// SweeperClientID is the requester recorded for sweeper writes
+const SweeperClientID = "sweeper"
+
+// sweepGasLimit is the gas budgeted for one ERC20 transfer when checking deposit gas balances
+const sweepGasLimit = 100000
+
+// SweeperService moves token balances from derived deposit addresses to the treasury
+type SweeperService struct {
+	client         *blockchain.Client
+	wallets        diRepo.IWalletRepository
+	signerProvider diSvc.ISignerProvider
+	treasury       string
+	tokens         []string
+	threshold      *big.Int // Minimum balance (wei) worth sweeping
+	gasWalletID    string   // Optional wallet that funds deposit addresses for gas
+	journal        diSvc.ITransactionJournal
+	tokenClient    *blockchain.TokenClient
+}
+
+// NewSweeperService creates a new sweeper service
+func NewSweeperService(
+	client *blockchain.Client,
+	wallets diRepo.IWalletRepository,
+	signerProvider diSvc.ISignerProvider,
+	treasury string,
+	tokens []string,
+	threshold string,
+	gasWalletID string,
+	journal diSvc.ITransactionJournal,
+) (*SweeperService, error) {
+	// Parse threshold (token units); zero sweeps any non-zero balance
+	thresholdWei := new(big.Int)
+	if threshold != "" && threshold != "0" {
+		parsed, err := parseAmount(threshold)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse sweep threshold: %w", err)
+		}
+		thresholdWei = parsed
+	}
+
+	// Create read-only token client for balance queries (no signer needed)
+	tokenClient, err := blockchain.NewTokenClient(client, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create read-only token client: %w", err)
+	}
+
+	return &SweeperService{
+		client:         client,
+		wallets:        wallets,
+		signerProvider: signerProvider,
+		treasury:       treasury,
+		tokens:         tokens,
+		threshold:      thresholdWei,
+		gasWalletID:    gasWalletID,
+		journal:        journal,
+		tokenClient:    tokenClient,
+	}, nil
+}
+
+// Sweep checks every derived wallet and transfers token balances at or above the threshold to the treasury
+// A deposit address without enough gas is topped up from the gas wallet (if configured) and swept on the next run
+func (s *SweeperService) Sweep(ctx context.Context) (*dtos.SweepResult, error) {
+	ctx, span := tracing.Start(ctx, "SweeperService.Sweep")
+	defer span.End()
+
+	// Journal entries of the sweeper are attributed to it
+	ctx = policy.WithIdentity(ctx, &policy.Identity{ClientID: SweeperClientID})
+
+	wallets, err := s.wallets.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wallets: %w", err)
+	}
+
+	gasPrice, err := s.gasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fee := new(big.Int).Mul(gasPrice, big.NewInt(sweepGasLimit))
+
+	result := &dtos.SweepResult{Transfers: []*dtos.SweepTransfer{}}
+	for _, wallet := range wallets {
+		if wallet.Kind != domain.WalletKindDerived {
+			continue
+		}
+		result.Checked++
+
+		for _, token := range s.tokens {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+
+			transfer := s.sweepToken(ctx, wallet, token, fee)
+			if transfer != nil {
+				result.Transfers = append(result.Transfers, transfer)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// sweepToken sweeps one token from one wallet; it returns nil when there is nothing to sweep
+func (s *SweeperService) sweepToken(ctx context.Context, wallet *domain.Wallet, token string, fee *big.Int) *dtos.SweepTransfer {
+	balance, err := s.tokenClient.BalanceOf(ctx, token, wallet.Address)
+	if err != nil {
+		logger.Warn("sweeper: failed to get %s balance of %s: %v", token, wallet.Address, err)
+		return nil
+	}
+	if balance.Sign() == 0 || balance.Cmp(s.threshold) < 0 {
+		return nil
+	}
+
+	transfer := &dtos.SweepTransfer{
+		WalletID:        wallet.ID,
+		From:            wallet.Address,
+		ContractAddress: token,
+		Amount:          balance.String(),
+	}
+
+	// Make sure the deposit address can pay for the transfer
+	nativeBalance, err := s.nativeBalance(ctx, wallet.Address)
+	if err != nil {
+		transfer.Error = err.Error()
+		return transfer
+	}
+	if nativeBalance.Cmp(fee) < 0 {
+		txHash, err := s.topUpGas(ctx, wallet.Address, new(big.Int).Sub(fee, nativeBalance))
+		if err != nil {
+			transfer.Error = err.Error()
+		} else {
+			transfer.GasTopUpTxHash = txHash
+		}
+		return transfer
+	}
+
+	signer, err := s.signerProvider.ResolveWalletSigner(ctx, wallet.ID)
+	if err != nil {
+		transfer.Error = err.Error()
+		return transfer
+	}
+
+	tokenClient, err := blockchain.NewTokenClient(s.client, signer)
+	if err != nil {
+		transfer.Error = err.Error()
+		return transfer
+	}
+
+	journalID, err := s.journal.Begin(ctx, domain.OperationSweep, wallet.ID, transfer)
+	if err != nil {
+		transfer.Error = err.Error()
+		return transfer
+	}
+
+	sent, err := tokenClient.Transfer(ctx, token, s.treasury, balance)
+	if err != nil {
+		s.journal.Failed(ctx, journalID, sent, err)
+		transfer.Error = err.Error()
+		return transfer
+	}
+	s.journal.Submitted(ctx, journalID, sent)
+	transfer.TxHash = sent.TxHash
+
+	logger.Info("sweeper: swept %s of %s from %s to %s (tx %s)", balance.String(), token, wallet.Address, s.treasury, sent.TxHash)
+	return transfer
+}
+
+// topUpGas sends native currency from the gas wallet to a deposit address
+func (s *SweeperService) topUpGas(ctx context.Context, address string, amount *big.Int) (string, error) {
+	if s.gasWalletID == "" {
+		return "", fmt.Errorf("insufficient gas at %s and no gas wallet is configured", address)
+	}
+
+	signer, err := s.signerProvider.ResolveWalletSigner(ctx, s.gasWalletID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve gas wallet: %w", err)
+	}
+
+	req := &blockchain.SignTransactionRequest{
+		To:    address,
+		Value: hexutil.EncodeBig(amount),
+	}
+
+	journalID, err := s.journal.Begin(ctx, domain.OperationGasTopUp, s.gasWalletID, req)
+	if err != nil {
+		return "", err
+	}
+
+	sent, err := signer.SignAndSend(ctx, req)
+	if err != nil {
+		s.journal.Failed(ctx, journalID, sent, err)
+		return "", fmt.Errorf("failed to top up gas: %w", err)
+	}
+	s.journal.Submitted(ctx, journalID, sent)
+
+	logger.Info("sweeper: topped up %s wei of gas to %s (tx %s)", amount.String(), address, sent.TxHash)
+	return sent.TxHash, nil
+}
+
+// gasPrice returns the current gas price in wei
+func (s *SweeperService) gasPrice(ctx context.Context) (*big.Int, error) {
+	gasPriceHex, err := s.client.GetGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+	gasPrice, err := hexutil.DecodeBig(gasPriceHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas price %s: %w", gasPriceHex, err)
+	}
+	return gasPrice, nil
+}
+
+// nativeBalance returns the native balance of an address in wei
+func (s *SweeperService) nativeBalance(ctx context.Context, address string) (*big.Int, error) {
+	balanceHex, err := s.client.GetBalance(ctx, address, "pending") // Pending so an in-flight top-up is not sent twice
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance of %s: %w", address, err)
+	}
+	balance, err := hexutil.DecodeBig(balanceHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid balance %s: %w", balanceHex, err)
+	}
+	return balance, nil
+}
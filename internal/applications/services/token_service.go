@@ -6,8 +6,12 @@ import (
 	"math/big"
 
 	"kokka.com/kokka/internal/applications/dtos"
+	"kokka.com/kokka/internal/applications/policy"
 	"kokka.com/kokka/internal/applications/validators"
+	diSvc "kokka.com/kokka/internal/core/di/services"
+	"kokka.com/kokka/internal/core/domain"
 	"kokka.com/kokka/internal/driven-adapter/external/blockchain"
+	"kokka.com/kokka/internal/shared/tracing"
 	"kokka.com/kokka/internal/shared/utils"
 )
 
@@ -15,7 +19,9 @@ import (
 type TokenService struct {
 	validator           validators.ITokenValidator
 	client              *blockchain.Client
-	decryptionKey       string
+	signerProvider      diSvc.ISignerProvider
+	authorizer          policy.Authorizer
+	journal             diSvc.ITransactionJournal
 	readOnlyTokenClient *blockchain.TokenClient
 }
 
@@ -23,7 +29,9 @@ type TokenService struct {
 func NewTokenService(
 	validator validators.ITokenValidator,
 	client *blockchain.Client,
-	decryptionKey string,
+	signerProvider diSvc.ISignerProvider,
+	authorizer policy.Authorizer,
+	journal diSvc.ITransactionJournal,
 ) (*TokenService, error) {
 	// Create read-only token client for balance queries (no signer needed)
 	readOnlyClient, err := blockchain.NewTokenClient(client, nil)
@@ -34,16 +42,21 @@ func NewTokenService(
 	return &TokenService{
 		validator:           validator,
 		client:              client,
-		decryptionKey:       decryptionKey,
+		signerProvider:      signerProvider,
+		authorizer:          authorizer,
+		journal:             journal,
 		readOnlyTokenClient: readOnlyClient,
 	}, nil
 }
 
 // Mint mints new tokens to a specified address
 func (s *TokenService) Mint(ctx context.Context, req *dtos.MintTokenRequest) (*dtos.MintTokenResponse, error) {
+	ctx, span := tracing.Start(ctx, "TokenService.Mint")
+	defer span.End()
+
 	// Validate request
 	if err := s.validator.ValidateMintTokenRequest(req); err != nil {
-		return nil, err
+		return nil, domain.AsValidationError(err)
 	}
 
 	// Parse amount
@@ -52,16 +65,15 @@ func (s *TokenService) Mint(ctx context.Context, req *dtos.MintTokenRequest) (*d
 		return nil, fmt.Errorf("failed to parse amount: %w", err)
 	}
 
-	// Decrypt private key
-	privateKey, err := utils.DecryptCrypto(req.EncryptedPrivateKey, s.decryptionKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt private key: %w", err)
+	// Check the caller may use this token and amount before any signing
+	if err := s.authorizer.Authorize(ctx, policy.Action{Token: req.ContractAddress, Amount: amount}); err != nil {
+		return nil, err
 	}
 
-	// Create transaction signer
-	signer, err := blockchain.NewTransactionSigner(privateKey, s.client)
+	// Resolve transaction signer from the wallet (or the deprecated encrypted key)
+	signer, err := s.signerProvider.ResolveSigner(ctx, req.WalletID, req.EncryptedPrivateKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create transaction signer: %w", err)
+		return nil, err
 	}
 
 	// Create token client
@@ -70,11 +82,37 @@ func (s *TokenService) Mint(ctx context.Context, req *dtos.MintTokenRequest) (*d
 		return nil, fmt.Errorf("failed to create token client: %w", err)
 	}
 
+	// Simulate instead of signing and sending; dry runs are not journaled
+	if req.DryRun {
+		sim, err := tokenClient.SimulateMint(ctx, req.ContractAddress, req.To, amount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to simulate mint: %w", err)
+		}
+		dryRun, err := dryRunResult(sim)
+		if err != nil {
+			return nil, err
+		}
+		return &dtos.MintTokenResponse{
+			ContractAddress: req.ContractAddress,
+			To:              req.To,
+			Amount:          amount.String(),
+			DryRun:          dryRun,
+		}, nil
+	}
+
 	// Execute mint transaction
-	txHash, err := tokenClient.Mint(ctx, req.ContractAddress, req.To, amount)
+	// Record the write in the journal before signing
+	journalID, err := s.journal.Begin(ctx, domain.OperationMint, req.WalletID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	sent, err := tokenClient.Mint(ctx, req.ContractAddress, req.To, amount)
 	if err != nil {
+		s.journal.Failed(ctx, journalID, sent, err)
 		return nil, fmt.Errorf("failed to mint tokens: %w", err)
 	}
+	s.journal.Submitted(ctx, journalID, sent)
 
 	// // Query new balance (best effort - don't fail if balance query fails)
 	// newBalance, err := tokenClient.BalanceOf(ctx, req.ContractAddress, req.To)
@@ -84,7 +122,7 @@ func (s *TokenService) Mint(ctx context.Context, req *dtos.MintTokenRequest) (*d
 	// }
 
 	return &dtos.MintTokenResponse{
-		TxHash:          txHash,
+		TxHash:          sent.TxHash,
 		ContractAddress: req.ContractAddress,
 		To:              req.To,
 		Amount:          amount.String(),
@@ -94,9 +132,12 @@ func (s *TokenService) Mint(ctx context.Context, req *dtos.MintTokenRequest) (*d
 
 // Burn burns tokens from the caller's account
 func (s *TokenService) Burn(ctx context.Context, req *dtos.BurnTokenRequest) (*dtos.BurnTokenResponse, error) {
+	ctx, span := tracing.Start(ctx, "TokenService.Burn")
+	defer span.End()
+
 	// Validate request
 	if err := s.validator.ValidateBurnTokenRequest(req); err != nil {
-		return nil, err
+		return nil, domain.AsValidationError(err)
 	}
 
 	// Parse amount
@@ -105,16 +146,15 @@ func (s *TokenService) Burn(ctx context.Context, req *dtos.BurnTokenRequest) (*d
 		return nil, fmt.Errorf("failed to parse amount: %w", err)
 	}
 
-	// Decrypt private key
-	privateKey, err := utils.DecryptCrypto(req.EncryptedPrivateKey, s.decryptionKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt private key: %w", err)
+	// Check the caller may use this token and amount before any signing
+	if err := s.authorizer.Authorize(ctx, policy.Action{Token: req.ContractAddress, Amount: amount}); err != nil {
+		return nil, err
 	}
 
-	// Create transaction signer
-	signer, err := blockchain.NewTransactionSigner(privateKey, s.client)
+	// Resolve transaction signer from the wallet (or the deprecated encrypted key)
+	signer, err := s.signerProvider.ResolveSigner(ctx, req.WalletID, req.EncryptedPrivateKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create transaction signer: %w", err)
+		return nil, err
 	}
 
 	// Create token client
@@ -123,11 +163,36 @@ func (s *TokenService) Burn(ctx context.Context, req *dtos.BurnTokenRequest) (*d
 		return nil, fmt.Errorf("failed to create token client: %w", err)
 	}
 
+	// Simulate instead of signing and sending; dry runs are not journaled
+	if req.DryRun {
+		sim, err := tokenClient.SimulateBurn(ctx, req.ContractAddress, amount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to simulate burn: %w", err)
+		}
+		dryRun, err := dryRunResult(sim)
+		if err != nil {
+			return nil, err
+		}
+		return &dtos.BurnTokenResponse{
+			ContractAddress: req.ContractAddress,
+			Amount:          amount.String(),
+			DryRun:          dryRun,
+		}, nil
+	}
+
 	// Execute burn transaction
-	txHash, err := tokenClient.Burn(ctx, req.ContractAddress, amount)
+	// Record the write in the journal before signing
+	journalID, err := s.journal.Begin(ctx, domain.OperationBurn, req.WalletID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	sent, err := tokenClient.Burn(ctx, req.ContractAddress, amount)
 	if err != nil {
+		s.journal.Failed(ctx, journalID, sent, err)
 		return nil, fmt.Errorf("failed to burn tokens: %w", err)
 	}
+	s.journal.Submitted(ctx, journalID, sent)
 
 	// // Query new balance (best effort - don't fail if balance query fails)
 	// newBalance, err := tokenClient.BalanceOf(ctx, req.ContractAddress, signer.GetAddress())
@@ -137,7 +202,7 @@ func (s *TokenService) Burn(ctx context.Context, req *dtos.BurnTokenRequest) (*d
 	// }
 
 	return &dtos.BurnTokenResponse{
-		TxHash:          txHash,
+		TxHash:          sent.TxHash,
 		ContractAddress: req.ContractAddress,
 		Amount:          amount.String(),
 		// NewBalance:      newBalanceStr,
@@ -146,9 +211,12 @@ func (s *TokenService) Burn(ctx context.Context, req *dtos.BurnTokenRequest) (*d
 
 // Transfer transfers tokens to a specified address
 func (s *TokenService) Transfer(ctx context.Context, req *dtos.TransferTokenRequest) (*dtos.TransferTokenResponse, error) {
+	ctx, span := tracing.Start(ctx, "TokenService.Transfer")
+	defer span.End()
+
 	// Validate request
 	if err := s.validator.ValidateTransferTokenRequest(req); err != nil {
-		return nil, err
+		return nil, domain.AsValidationError(err)
 	}
 
 	// Parse amount
@@ -157,16 +225,15 @@ func (s *TokenService) Transfer(ctx context.Context, req *dtos.TransferTokenRequ
 		return nil, fmt.Errorf("failed to parse amount: %w", err)
 	}
 
-	// Decrypt private key
-	privateKey, err := utils.DecryptCrypto(req.EncryptedPrivateKey, s.decryptionKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt private key: %w", err)
+	// Check the caller may use this token and amount before any signing
+	if err := s.authorizer.Authorize(ctx, policy.Action{Token: req.ContractAddress, Amount: amount}); err != nil {
+		return nil, err
 	}
 
-	// Create transaction signer
-	signer, err := blockchain.NewTransactionSigner(privateKey, s.client)
+	// Resolve transaction signer from the wallet (or the deprecated encrypted key)
+	signer, err := s.signerProvider.ResolveSigner(ctx, req.WalletID, req.EncryptedPrivateKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create transaction signer: %w", err)
+		return nil, err
 	}
 
 	// Create token client
@@ -175,14 +242,41 @@ func (s *TokenService) Transfer(ctx context.Context, req *dtos.TransferTokenRequ
 		return nil, fmt.Errorf("failed to create token client: %w", err)
 	}
 
+	// Simulate instead of signing and sending; dry runs are not journaled
+	if req.DryRun {
+		sim, err := tokenClient.SimulateTransfer(ctx, req.ContractAddress, req.To, amount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to simulate transfer: %w", err)
+		}
+		dryRun, err := dryRunResult(sim)
+		if err != nil {
+			return nil, err
+		}
+		return &dtos.TransferTokenResponse{
+			ContractAddress: req.ContractAddress,
+			From:            signer.GetAddress(),
+			To:              req.To,
+			Amount:          amount.String(),
+			DryRun:          dryRun,
+		}, nil
+	}
+
 	// Execute transfer transaction
-	txHash, err := tokenClient.Transfer(ctx, req.ContractAddress, req.To, amount)
+	// Record the write in the journal before signing
+	journalID, err := s.journal.Begin(ctx, domain.OperationTransfer, req.WalletID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	sent, err := tokenClient.Transfer(ctx, req.ContractAddress, req.To, amount)
 	if err != nil {
+		s.journal.Failed(ctx, journalID, sent, err)
 		return nil, fmt.Errorf("failed to transfer tokens: %w", err)
 	}
+	s.journal.Submitted(ctx, journalID, sent)
 
 	return &dtos.TransferTokenResponse{
-		TxHash:          txHash,
+		TxHash:          sent.TxHash,
 		ContractAddress: req.ContractAddress,
 		From:            signer.GetAddress(),
 		To:              req.To,
@@ -192,9 +286,12 @@ func (s *TokenService) Transfer(ctx context.Context, req *dtos.TransferTokenRequ
 
 // GetBalance returns the token balance of an address
 func (s *TokenService) GetBalance(ctx context.Context, req *dtos.GetTokenBalanceRequest) (*dtos.GetTokenBalanceResponse, error) {
+	ctx, span := tracing.Start(ctx, "TokenService.GetBalance")
+	defer span.End()
+
 	// Validate request
 	if err := s.validator.ValidateGetTokenBalanceRequest(req); err != nil {
-		return nil, err
+		return nil, domain.AsValidationError(err)
 	}
 
 	// Query balance using read-only client (no signing required)
@@ -210,39 +307,28 @@ func (s *TokenService) GetBalance(ctx context.Context, req *dtos.GetTokenBalance
 	}, nil
 }
 
-// parseAmount parses an amount string and converts token units to wei (multiplies by 10^18)
+// parseAmount parses a positive amount in token units and converts it to wei
 // Input: "2" or "2.5" (token units)
 // Output: big.Int representing wei (e.g., "2" -> 2000000000000000000)
 func parseAmount(amount string) (*big.Int, error) {
-	// Parse the amount as a floating point number
-	amountFloat := new(big.Float)
-	_, ok := amountFloat.SetString(amount)
-	if !ok {
-		return nil, fmt.Errorf("invalid amount format: %s", amount)
+	wei, err := utils.TokenUnitsToWei(amount)
+	if err != nil {
+		return nil, err
 	}
-
-	// Check if amount is positive
-	if amountFloat.Cmp(big.NewFloat(0)) <= 0 {
+	if wei.Sign() <= 0 {
 		return nil, fmt.Errorf("amount must be positive: %s", amount)
 	}
-
-	// Multiply by 10^18 to convert to wei (18 decimals for ERC20 tokens)
-	decimals := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
-	decimalFloat := new(big.Float).SetInt(decimals)
-	weiFloat := new(big.Float).Mul(amountFloat, decimalFloat)
-
-	// Convert to big.Int (truncate any remaining decimals)
-	weiInt := new(big.Int)
-	weiFloat.Int(weiInt)
-
-	return weiInt, nil
+	return wei, nil
 }
 
 // GetAddressInfo retrieves basic information about the token contract at the given address
 func (s *TokenService) GetAddressInfo(ctx context.Context, req *dtos.GetAddressInfoRequest) (*dtos.GetAddressInfoResponse, error) {
+	ctx, span := tracing.Start(ctx, "TokenService.GetAddressInfo")
+	defer span.End()
+
 	// Validate request
 	if err := s.validator.ValidateGetAddressInfoRequest(req); err != nil {
-		return nil, err
+		return nil, domain.AsValidationError(err)
 	}
 
 	// Query address info using read-only client (no signing required)
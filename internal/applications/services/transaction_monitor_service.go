@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"kokka.com/kokka/internal/applications/dtos"
+	diRepo "kokka.com/kokka/internal/core/di/repositories"
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/driven-adapter/external/blockchain"
+	"kokka.com/kokka/internal/shared/logger"
+	"kokka.com/kokka/internal/shared/metrics"
+	"kokka.com/kokka/internal/shared/tracing"
+)
+
+// monitorBatchSize caps the journal entries checked per run
+const monitorBatchSize = 500
+
+// TransactionMonitorService moves journaled transactions to their final status
+type TransactionMonitorService struct {
+	client         *blockchain.Client
+	transactions   diRepo.ITransactionRepository
+	reconcileAfter time.Duration
+}
+
+// NewTransactionMonitorService creates a new transaction monitor
+// Entries not updated for reconcileAfter are checked by Reconcile
+func NewTransactionMonitorService(client *blockchain.Client, transactions diRepo.ITransactionRepository, reconcileAfter time.Duration) *TransactionMonitorService {
+	return &TransactionMonitorService{
+		client:         client,
+		transactions:   transactions,
+		reconcileAfter: reconcileAfter,
+	}
+}
+
+// PollConfirmations marks submitted and unknown transactions confirmed or reverted once they are mined
+// Unknown transactions the node has in its pool are marked submitted
+func (s *TransactionMonitorService) PollConfirmations(ctx context.Context) (*dtos.ConfirmationResult, error) {
+	ctx, span := tracing.Start(ctx, "TransactionMonitorService.PollConfirmations")
+	defer span.End()
+
+	var outstanding []*domain.Transaction
+	for _, txStatus := range []string{domain.TransactionStatusSubmitted, domain.TransactionStatusUnknown} {
+		transactions, err := s.transactions.List(ctx, &domain.TransactionFilter{
+			Status: txStatus,
+			Limit:  monitorBatchSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s transactions: %w", txStatus, err)
+		}
+		outstanding = append(outstanding, transactions...)
+	}
+
+	result := &dtos.ConfirmationResult{}
+	for _, tx := range outstanding {
+		if ctx.Err() != nil {
+			break
+		}
+		result.Checked++
+
+		receipt, err := s.client.GetTransactionReceipt(ctx, tx.TxHash)
+		if err != nil {
+			logger.Warn("confirmations: %s: %v", tx.ID, err)
+			continue
+		}
+		if receipt == nil {
+			if tx.Status == domain.TransactionStatusUnknown && s.markSeen(ctx, tx) {
+				result.Submitted++
+			}
+			continue
+		}
+
+		update := &domain.TransactionUpdate{
+			Status: domain.TransactionStatusConfirmed,
+			Detail: fmt.Sprintf("mined in block %d", receipt.BlockNumber),
+		}
+		if !receipt.Succeeded() {
+			update.Status = domain.TransactionStatusReverted
+			update.Error = fmt.Sprintf("transaction reverted in block %d", receipt.BlockNumber)
+		}
+		if err := s.transactions.Transition(ctx, tx.ID, update); err != nil {
+			logger.Error("confirmations: failed to update %s: %v", tx.ID, err)
+			continue
+		}
+
+		metrics.IncTransactionConfirmed(tx.Operation, payloadToken(tx.Payload), update.Status)
+		if update.Status == domain.TransactionStatusConfirmed {
+			result.Confirmed++
+		} else {
+			result.Reverted++
+		}
+	}
+
+	return result, nil
+}
+
+// markSeen marks an unknown transaction submitted if the node has it, and reports whether it did
+func (s *TransactionMonitorService) markSeen(ctx context.Context, tx *domain.Transaction) bool {
+	onChain, err := s.client.GetTransactionByHash(ctx, tx.TxHash)
+	if err != nil {
+		logger.Warn("confirmations: %s: %v", tx.ID, err)
+		return false
+	}
+	if onChain == nil {
+		return false
+	}
+
+	err = s.transactions.Transition(ctx, tx.ID, &domain.TransactionUpdate{
+		Status: domain.TransactionStatusSubmitted,
+		Detail: "seen by the node after the broadcast failed",
+	})
+	if err != nil {
+		logger.Error("confirmations: failed to update %s: %v", tx.ID, err)
+		return false
+	}
+	metrics.IncTransactionSubmitted(tx.Operation, payloadToken(tx.Payload))
+	return true
+}
+
+// Reconcile checks journal entries that have not moved for a while
+// Submitted transactions the node no longer knows were dropped, and unknown transactions it never
+// saw were not broadcast; both are marked failed. Entries still pending were interrupted between
+// journaling and sending and are reported, not changed, because the transaction may have been broadcast
+func (s *TransactionMonitorService) Reconcile(ctx context.Context) (*dtos.ReconciliationResult, error) {
+	ctx, span := tracing.Start(ctx, "TransactionMonitorService.Reconcile")
+	defer span.End()
+
+	staleBefore := time.Now().UTC().Add(-s.reconcileAfter)
+	result := &dtos.ReconciliationResult{StalePending: []string{}}
+
+	var outstanding []*domain.Transaction
+	for _, txStatus := range []string{domain.TransactionStatusSubmitted, domain.TransactionStatusUnknown} {
+		transactions, err := s.transactions.List(ctx, &domain.TransactionFilter{
+			Status:        txStatus,
+			UpdatedBefore: staleBefore,
+			Limit:         monitorBatchSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s transactions: %w", txStatus, err)
+		}
+		outstanding = append(outstanding, transactions...)
+	}
+
+	for _, tx := range outstanding {
+		if ctx.Err() != nil {
+			break
+		}
+		result.Checked++
+
+		onChain, err := s.client.GetTransactionByHash(ctx, tx.TxHash)
+		if err != nil {
+			logger.Warn("reconcile: %s: %v", tx.ID, err)
+			continue
+		}
+		if onChain != nil {
+			// Still known to the node, confirmation polling will pick it up
+			continue
+		}
+
+		reason := "transaction dropped: unknown to the node"
+		if tx.Status == domain.TransactionStatusUnknown {
+			reason = "transaction not broadcast: never seen by the node"
+		}
+		err = s.transactions.Transition(ctx, tx.ID, &domain.TransactionUpdate{
+			Status: domain.TransactionStatusFailed,
+			Error:  reason,
+		})
+		if err != nil {
+			logger.Error("reconcile: failed to update %s: %v", tx.ID, err)
+			continue
+		}
+		result.Dropped++
+	}
+
+	pending, err := s.transactions.List(ctx, &domain.TransactionFilter{
+		Status:        domain.TransactionStatusPending,
+		UpdatedBefore: staleBefore,
+		Limit:         monitorBatchSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending transactions: %w", err)
+	}
+	for _, tx := range pending {
+		result.Checked++
+		result.StalePending = append(result.StalePending, tx.ID)
+	}
+	if len(pending) > 0 {
+		logger.Warn("reconcile: %d journal entries stuck in pending, review them manually", len(pending))
+	}
+
+	return result, nil
+}
+
+// hexToDecimal formats a hex quantity in decimal, leaving unparsable values as they are
+func hexToDecimal(value string) string {
+	number, err := parseQuantity(value)
+	if err != nil {
+		return value
+	}
+	return strconv.FormatUint(number, 10)
+}
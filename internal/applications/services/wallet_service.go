@@ -0,0 +1,355 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"kokka.com/kokka/internal/applications/dtos"
+	"kokka.com/kokka/internal/applications/policy"
+	"kokka.com/kokka/internal/applications/validators"
+	diRepo "kokka.com/kokka/internal/core/di/repositories"
+	diSvc "kokka.com/kokka/internal/core/di/services"
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/driven-adapter/external/blockchain"
+	"kokka.com/kokka/internal/shared/tracing"
+	"kokka.com/kokka/internal/shared/utils"
+)
+
+// WalletService handles custodial wallet business logic
+type WalletService struct {
+	validator      validators.IWalletValidator
+	authorizer     policy.Authorizer
+	wallets        diRepo.IWalletRepository
+	signerProvider diSvc.ISignerProvider
+	hdWallet       *blockchain.HDWallet // nil when no HD mnemonic is configured
+	masterKey      []byte
+	keyring        *utils.Keyring
+}
+
+// NewWalletService creates a new wallet service
+func NewWalletService(
+	validator validators.IWalletValidator,
+	authorizer policy.Authorizer,
+	wallets diRepo.IWalletRepository,
+	signerProvider diSvc.ISignerProvider,
+	hdWallet *blockchain.HDWallet,
+	masterKey []byte,
+	keyring *utils.Keyring,
+) *WalletService {
+	return &WalletService{
+		validator:      validator,
+		authorizer:     authorizer,
+		wallets:        wallets,
+		signerProvider: signerProvider,
+		hdWallet:       hdWallet,
+		masterKey:      masterKey,
+		keyring:        keyring,
+	}
+}
+
+// CreateWallet generates a new key and stores it as a custodial wallet
+func (s *WalletService) CreateWallet(ctx context.Context, req *dtos.CreateWalletRequest) (*dtos.WalletResponse, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.CreateWallet")
+	defer span.End()
+
+	// Validate request
+	if err := s.validator.ValidateCreateWalletRequest(req); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	// Generate a new private key
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate private key: %w", err)
+	}
+
+	return s.storeWallet(ctx, hex.EncodeToString(crypto.FromECDSA(privateKey)), domain.WalletKindGenerated, req.Label)
+}
+
+// ImportWallet stores a client-provided key as a custodial wallet
+func (s *WalletService) ImportWallet(ctx context.Context, req *dtos.ImportWalletRequest) (*dtos.WalletResponse, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.ImportWallet")
+	defer span.End()
+
+	// Validate request
+	if err := s.validator.ValidateImportWalletRequest(req); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	// Decrypt private key
+	privateKey, err := s.keyring.OpenString(req.EncryptedPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt private key: %w", err)
+	}
+
+	return s.storeWallet(ctx, strings.TrimPrefix(privateKey, "0x"), domain.WalletKindImported, req.Label)
+}
+
+// RegisterWallet registers a keystore or remote signer key as a wallet
+// The signer is opened once so that a wrong path, passphrase or address is rejected up front
+func (s *WalletService) RegisterWallet(ctx context.Context, req *dtos.RegisterWalletRequest) (*dtos.WalletResponse, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.RegisterWallet")
+	defer span.End()
+
+	// Validate request
+	if err := s.validator.ValidateRegisterWalletRequest(req); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	if s.wallets == nil {
+		return nil, fmt.Errorf("wallet subsystem is not configured")
+	}
+
+	walletID, err := utils.NewID("wal_")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate wallet id: %w", err)
+	}
+
+	wallet := &domain.Wallet{
+		ID:           walletID,
+		Address:      common.HexToAddress(req.Address).Hex(),
+		Label:        req.Label,
+		Kind:         req.Kind,
+		KeystorePath: req.KeystorePath,
+		CreatedAt:    time.Now().UTC(),
+	}
+	if identity := policy.GetIdentity(ctx); identity != nil {
+		wallet.CreatedBy = identity.ClientID
+	}
+
+	// Check the backing signer is reachable and holds the expected key
+	signer, err := s.signerProvider.WalletSigner(ctx, wallet)
+	if err != nil {
+		return nil, err
+	}
+	if remote, ok := signer.(*blockchain.RemoteSigner); ok {
+		accounts, err := remote.Accounts(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list remote signer accounts: %w", err)
+		}
+		if !containsAddress(accounts, wallet.Address) {
+			return nil, fmt.Errorf("remote signer does not hold %s", wallet.Address)
+		}
+	} else if signer.Address().Hex() != wallet.Address {
+		return nil, fmt.Errorf("keystore address %s does not match %s", signer.Address().Hex(), wallet.Address)
+	}
+
+	if err := s.wallets.Create(ctx, wallet); err != nil {
+		return nil, fmt.Errorf("failed to store wallet: %w", err)
+	}
+
+	return toWalletResponse(wallet), nil
+}
+
+// DeriveWallet returns the deposit wallet for an HD index, creating it on first use
+func (s *WalletService) DeriveWallet(ctx context.Context, req *dtos.DeriveWalletRequest) (*dtos.WalletResponse, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.DeriveWallet")
+	defer span.End()
+
+	// Validate request
+	if err := s.validator.ValidateDeriveWalletRequest(req); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	if s.wallets == nil {
+		return nil, fmt.Errorf("wallet subsystem is not configured")
+	}
+	if s.hdWallet == nil {
+		return nil, fmt.Errorf("HD wallet is not configured")
+	}
+
+	index := *req.Index
+
+	// Deriving the same index twice returns the same wallet
+	wallet, err := s.wallets.GetByDerivationIndex(ctx, index)
+	if err == nil {
+		return toWalletResponse(wallet), nil
+	}
+	if !errors.Is(err, domain.ErrWalletNotFound) {
+		return nil, fmt.Errorf("failed to look up derivation index %d: %w", index, err)
+	}
+
+	address, err := s.hdWallet.DeriveAddress(index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive address: %w", err)
+	}
+
+	walletID, err := utils.NewID("wal_")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate wallet id: %w", err)
+	}
+
+	wallet = &domain.Wallet{
+		ID:              walletID,
+		Address:         address.Hex(),
+		Label:           req.Label,
+		Kind:            domain.WalletKindDerived,
+		DerivationIndex: &index,
+		DerivationPath:  blockchain.DerivationPath(index),
+		CreatedAt:       time.Now().UTC(),
+	}
+	if identity := policy.GetIdentity(ctx); identity != nil {
+		wallet.CreatedBy = identity.ClientID
+	}
+
+	if err := s.wallets.Create(ctx, wallet); err != nil {
+		// A concurrent request may have derived the same index first
+		if existing, getErr := s.wallets.GetByDerivationIndex(ctx, index); getErr == nil {
+			return toWalletResponse(existing), nil
+		}
+		return nil, fmt.Errorf("failed to store wallet: %w", err)
+	}
+
+	return toWalletResponse(wallet), nil
+}
+
+// ResolveDerivedAddress returns the HD index of a deposit address
+func (s *WalletService) ResolveDerivedAddress(ctx context.Context, req *dtos.ResolveDerivedAddressRequest) (*dtos.DerivedAddressResponse, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.ResolveDerivedAddress")
+	defer span.End()
+
+	// Validate request
+	if err := s.validator.ValidateResolveDerivedAddressRequest(req); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	if s.wallets == nil {
+		return nil, fmt.Errorf("wallet subsystem is not configured")
+	}
+
+	wallet, err := s.wallets.GetByAddress(ctx, req.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve address: %w", err)
+	}
+	if wallet.Kind != domain.WalletKindDerived || wallet.DerivationIndex == nil {
+		return nil, fmt.Errorf("address %s is not a derived deposit address", req.Address)
+	}
+
+	return &dtos.DerivedAddressResponse{
+		WalletID: wallet.ID,
+		Address:  wallet.Address,
+		Index:    *wallet.DerivationIndex,
+		Path:     wallet.DerivationPath,
+	}, nil
+}
+
+// GetWallet returns a custodial wallet by ID, if the caller may use it
+func (s *WalletService) GetWallet(ctx context.Context, req *dtos.GetWalletRequest) (*dtos.WalletResponse, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.GetWallet")
+	defer span.End()
+
+	// Validate request
+	if err := s.validator.ValidateGetWalletRequest(req); err != nil {
+		return nil, domain.AsValidationError(err)
+	}
+
+	if s.wallets == nil {
+		return nil, fmt.Errorf("wallet subsystem is not configured")
+	}
+
+	wallet, err := s.wallets.GetByID(ctx, req.WalletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+	if err := authorizeWallet(ctx, s.authorizer, wallet); err != nil {
+		return nil, err
+	}
+
+	return toWalletResponse(wallet), nil
+}
+
+// ListWallets returns the custodial wallets the caller may use: its own, plus those its roles list
+func (s *WalletService) ListWallets(ctx context.Context) (*dtos.ListWalletsResponse, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.ListWallets")
+	defer span.End()
+
+	if s.wallets == nil {
+		return nil, fmt.Errorf("wallet subsystem is not configured")
+	}
+
+	wallets, err := s.wallets.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wallets: %w", err)
+	}
+
+	result := &dtos.ListWalletsResponse{Wallets: make([]*dtos.WalletResponse, 0, len(wallets))}
+	for _, wallet := range wallets {
+		if authorizeWallet(ctx, s.authorizer, wallet) != nil {
+			continue
+		}
+		result.Wallets = append(result.Wallets, toWalletResponse(wallet))
+	}
+
+	return result, nil
+}
+
+// storeWallet encrypts the private key under the master key and persists the wallet
+func (s *WalletService) storeWallet(ctx context.Context, privateKeyHex string, kind string, label string) (*dtos.WalletResponse, error) {
+	if s.wallets == nil {
+		return nil, fmt.Errorf("wallet subsystem is not configured")
+	}
+
+	// Derive the address (also validates the key)
+	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	encryptedKey, err := sealPrivateKey(privateKeyHex, s.masterKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt private key: %w", err)
+	}
+
+	walletID, err := utils.NewID("wal_")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate wallet id: %w", err)
+	}
+
+	wallet := &domain.Wallet{
+		ID:           walletID,
+		Address:      crypto.PubkeyToAddress(privateKey.PublicKey).Hex(),
+		Label:        label,
+		Kind:         kind,
+		EncryptedKey: encryptedKey,
+		CreatedAt:    time.Now().UTC(),
+	}
+	if identity := policy.GetIdentity(ctx); identity != nil {
+		wallet.CreatedBy = identity.ClientID
+	}
+
+	if err := s.wallets.Create(ctx, wallet); err != nil {
+		return nil, fmt.Errorf("failed to store wallet: %w", err)
+	}
+
+	return toWalletResponse(wallet), nil
+}
+
+// containsAddress reports whether the address is in the list (case-insensitive)
+func containsAddress(addresses []string, address string) bool {
+	for _, candidate := range addresses {
+		if strings.EqualFold(candidate, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// toWalletResponse maps a wallet to its public representation
+func toWalletResponse(wallet *domain.Wallet) *dtos.WalletResponse {
+	return &dtos.WalletResponse{
+		WalletID:        wallet.ID,
+		Address:         wallet.Address,
+		Label:           wallet.Label,
+		Kind:            wallet.Kind,
+		DerivationIndex: wallet.DerivationIndex,
+		DerivationPath:  wallet.DerivationPath,
+		CreatedBy:       wallet.CreatedBy,
+		CreatedAt:       wallet.CreatedAt,
+	}
+}
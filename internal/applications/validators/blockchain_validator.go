@@ -2,6 +2,7 @@ package validators
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	"kokka.com/kokka/internal/applications/dtos"
@@ -15,7 +16,13 @@ type IBlockchainValidator interface {
 	ValidateEstimateGasRequest(req *dtos.EstimateGasRequest) error
 	ValidateSendRawTransactionRequest(req *dtos.SendRawTransactionRequest) error
 	ValidateSignAndSendTransactionRequest(req *dtos.SignAndSendTransactionRequest) error
+	ValidateSignTransactionRequest(req *dtos.SignTransactionRequest) error
+	ValidateBuildTransactionRequest(req *dtos.BuildTransactionRequest) error
+	ValidateSignMessageRequest(req *dtos.SignMessageRequest) error
+	ValidateSignTypedDataRequest(req *dtos.SignTypedDataRequest) error
+	ValidateVerifySignatureRequest(req *dtos.VerifySignatureRequest) error
 	ValidateGenericRPCRequest(req *dtos.GenericRPCRequest) error
+	ValidateGenericRPCBatchRequest(reqs []*dtos.GenericRPCRequest) error
 }
 
 type blockchainValidator struct{}
@@ -60,7 +67,7 @@ func (v *blockchainValidator) ValidateGetBlockRequest(req *dtos.GetBlockRequest)
 		return errors.New("invalid block_number format")
 	}
 
-	return nil
+	return validateChainFormat(req.Format)
 }
 
 // ValidateGetTransactionRequest validates a get transaction request
@@ -77,7 +84,17 @@ func (v *blockchainValidator) ValidateGetTransactionRequest(req *dtos.GetTransac
 		return errors.New("invalid transaction hash format")
 	}
 
-	return nil
+	return validateChainFormat(req.Format)
+}
+
+// validateChainFormat validates the optional format of chain data
+func validateChainFormat(format string) error {
+	switch format {
+	case "", dtos.FormatHex, dtos.FormatHuman:
+		return nil
+	default:
+		return fmt.Errorf("format must be %q or %q", dtos.FormatHex, dtos.FormatHuman)
+	}
 }
 
 // ValidateCallContractRequest validates a call contract request
@@ -167,29 +184,120 @@ func (v *blockchainValidator) ValidateSignAndSendTransactionRequest(req *dtos.Si
 		return errors.New("invalid to address format")
 	}
 
-	// Value is optional, but if provided, should be valid hex
-	if req.Value != "" && !isValidHexData(req.Value) {
-		return errors.New("invalid value format (must be hex string with 0x prefix)")
+	if err := validateSignerRef(req.WalletID, req.EncryptedPrivateKey); err != nil {
+		return err
 	}
 
-	// Data is optional, but if provided, should be valid hex
-	if req.Data != "" && !isValidHexData(req.Data) {
-		return errors.New("invalid data format (must be hex string with 0x prefix)")
+	return validateTransactionFields(req.Value, req.Data, req.GasLimit, req.GasPrice, req.Nonce)
+}
+
+// ValidateSignTransactionRequest validates a sign-only transaction request
+func (v *blockchainValidator) ValidateSignTransactionRequest(req *dtos.SignTransactionRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
 	}
 
-	// GasLimit is optional, but if provided, should be valid hex
-	if req.GasLimit != "" && !isValidHexData(req.GasLimit) {
-		return errors.New("invalid gas_limit format (must be hex string with 0x prefix)")
+	if req.To == "" {
+		return errors.New("to address is required")
 	}
 
-	// GasPrice is optional, but if provided, should be valid hex
-	if req.GasPrice != "" && !isValidHexData(req.GasPrice) {
-		return errors.New("invalid gas_price format (must be hex string with 0x prefix)")
+	if !isValidEthereumAddress(req.To) {
+		return errors.New("invalid to address format")
 	}
 
-	// Nonce is optional, but if provided, should be valid hex
-	if req.Nonce != "" && !isValidHexData(req.Nonce) {
-		return errors.New("invalid nonce format (must be hex string with 0x prefix)")
+	if err := validateSignerRef(req.WalletID, req.EncryptedPrivateKey); err != nil {
+		return err
+	}
+
+	return validateTransactionFields(req.Value, req.Data, req.GasLimit, req.GasPrice, req.Nonce)
+}
+
+// ValidateBuildTransactionRequest validates a build unsigned transaction request
+func (v *blockchainValidator) ValidateBuildTransactionRequest(req *dtos.BuildTransactionRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if req.From == "" {
+		return errors.New("from address is required")
+	}
+
+	if !isValidEthereumAddress(req.From) {
+		return errors.New("invalid from address format")
+	}
+
+	if req.To == "" {
+		return errors.New("to address is required")
+	}
+
+	if !isValidEthereumAddress(req.To) {
+		return errors.New("invalid to address format")
+	}
+
+	return validateTransactionFields(req.Value, req.Data, req.GasLimit, req.GasPrice, req.Nonce)
+}
+
+// ValidateSignMessageRequest validates a sign personal message request
+func (v *blockchainValidator) ValidateSignMessageRequest(req *dtos.SignMessageRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if err := validateSignerRef(req.WalletID, req.EncryptedPrivateKey); err != nil {
+		return err
+	}
+
+	if req.Message == "" {
+		return errors.New("message is required")
+	}
+
+	return validateMessageEncoding(req.Message, req.Encoding)
+}
+
+// ValidateSignTypedDataRequest validates a sign typed data request
+func (v *blockchainValidator) ValidateSignTypedDataRequest(req *dtos.SignTypedDataRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if err := validateSignerRef(req.WalletID, req.EncryptedPrivateKey); err != nil {
+		return err
+	}
+
+	if len(req.TypedData) == 0 || string(req.TypedData) == "null" {
+		return errors.New("typed_data is required")
+	}
+
+	return nil
+}
+
+// ValidateVerifySignatureRequest validates a verify signature request
+func (v *blockchainValidator) ValidateVerifySignatureRequest(req *dtos.VerifySignatureRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if req.Signature == "" {
+		return errors.New("signature is required")
+	}
+
+	if !isValidHexData(req.Signature) {
+		return errors.New("invalid signature format (must be hex string with 0x prefix)")
+	}
+
+	hasTypedData := len(req.TypedData) > 0 && string(req.TypedData) != "null"
+	if (req.Message == "") == !hasTypedData {
+		return errors.New("exactly one of message or typed_data is required")
+	}
+
+	if req.Message != "" {
+		if err := validateMessageEncoding(req.Message, req.Encoding); err != nil {
+			return err
+		}
+	}
+
+	if req.Address != "" && !isValidEthereumAddress(req.Address) {
+		return errors.New("invalid address format")
 	}
 
 	return nil
@@ -208,10 +316,77 @@ func (v *blockchainValidator) ValidateGenericRPCRequest(req *dtos.GenericRPCRequ
 	return nil
 }
 
+// ValidateGenericRPCBatchRequest validates a generic RPC batch; its calls are validated one by one
+func (v *blockchainValidator) ValidateGenericRPCBatchRequest(reqs []*dtos.GenericRPCRequest) error {
+	if len(reqs) == 0 {
+		return errors.New("batch must not be empty")
+	}
+
+	return nil
+}
+
 // ========================================
 // Helper validation functions
 // ========================================
 
+// validateTransactionFields checks the optional hex fields of a transaction to sign or build
+func validateTransactionFields(value, data, gasLimit, gasPrice, nonce string) error {
+	// Value is optional, but if provided, should be valid hex
+	if value != "" && !isValidHexData(value) {
+		return errors.New("invalid value format (must be hex string with 0x prefix)")
+	}
+
+	// Data is optional, but if provided, should be valid hex
+	if data != "" && !isValidHexData(data) {
+		return errors.New("invalid data format (must be hex string with 0x prefix)")
+	}
+
+	// GasLimit is optional, but if provided, should be valid hex
+	if gasLimit != "" && !isValidHexData(gasLimit) {
+		return errors.New("invalid gas_limit format (must be hex string with 0x prefix)")
+	}
+
+	// GasPrice is optional, but if provided, should be valid hex
+	if gasPrice != "" && !isValidHexData(gasPrice) {
+		return errors.New("invalid gas_price format (must be hex string with 0x prefix)")
+	}
+
+	// Nonce is optional, but if provided, should be valid hex
+	if nonce != "" && !isValidHexData(nonce) {
+		return errors.New("invalid nonce format (must be hex string with 0x prefix)")
+	}
+
+	return nil
+}
+
+// validateMessageEncoding checks the encoding of a personal message
+func validateMessageEncoding(message string, encoding string) error {
+	switch encoding {
+	case "", "text":
+		return nil
+	case "hex":
+		if !isValidHexData(message) {
+			return errors.New("invalid message format (must be hex string with 0x prefix when encoding is hex)")
+		}
+		return nil
+	default:
+		return errors.New("invalid encoding (must be text or hex)")
+	}
+}
+
+// validateSignerRef checks that exactly one way of resolving the signer is provided
+func validateSignerRef(walletID string, encryptedPrivateKey string) error {
+	if walletID == "" && encryptedPrivateKey == "" {
+		return errors.New("wallet_id or encrypted_private_key is required")
+	}
+
+	if walletID != "" && encryptedPrivateKey != "" {
+		return errors.New("only one of wallet_id or encrypted_private_key may be provided")
+	}
+
+	return nil
+}
+
 // isValidEthereumAddress checks if a string is a valid Ethereum address
 func isValidEthereumAddress(address string) bool {
 	if len(address) != 42 {
@@ -0,0 +1,37 @@
+package validators
+
+import (
+	"errors"
+)
+
+// maxIdempotencyKeyLength matches the idempotency_keys.idempotency_key column
+const maxIdempotencyKeyLength = 255
+
+type IIdempotencyValidator interface {
+	ValidateIdempotencyKey(key string) error
+}
+
+type idempotencyValidator struct{}
+
+func NewIdempotencyValidator() *idempotencyValidator {
+	return &idempotencyValidator{}
+}
+
+// ValidateIdempotencyKey validates an Idempotency-Key header value
+func (v *idempotencyValidator) ValidateIdempotencyKey(key string) error {
+	if key == "" {
+		return errors.New("idempotency key is required")
+	}
+
+	if len(key) > maxIdempotencyKeyLength {
+		return errors.New("idempotency key must be at most 255 characters")
+	}
+
+	for _, c := range key {
+		if c < 0x21 || c > 0x7e {
+			return errors.New("idempotency key must be printable ASCII without spaces")
+		}
+	}
+
+	return nil
+}
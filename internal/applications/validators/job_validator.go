@@ -0,0 +1,44 @@
+package validators
+
+import (
+	"errors"
+
+	"kokka.com/kokka/internal/applications/dtos"
+)
+
+type IJobValidator interface {
+	ValidateJobRequest(req *dtos.JobRequest) error
+	ValidateListJobRunsRequest(req *dtos.ListJobRunsRequest) error
+}
+
+type jobValidator struct{}
+
+func NewJobValidator() *jobValidator {
+	return &jobValidator{}
+}
+
+// ValidateJobRequest validates a trigger, pause or resume job request
+func (v *jobValidator) ValidateJobRequest(req *dtos.JobRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if req.Name == "" {
+		return errors.New("name is required")
+	}
+
+	return nil
+}
+
+// ValidateListJobRunsRequest validates a list job runs request
+func (v *jobValidator) ValidateListJobRunsRequest(req *dtos.ListJobRunsRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if req.Limit < 0 || req.Limit > 1000 {
+		return errors.New("limit must be between 0 and 1000")
+	}
+
+	return nil
+}
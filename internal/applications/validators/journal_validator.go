@@ -0,0 +1,44 @@
+package validators
+
+import (
+	"errors"
+
+	"kokka.com/kokka/internal/applications/dtos"
+)
+
+type IJournalValidator interface {
+	ValidateListJournalTransactionsRequest(req *dtos.ListJournalTransactionsRequest) error
+	ValidateGetJournalTransactionRequest(req *dtos.GetJournalTransactionRequest) error
+}
+
+type journalValidator struct{}
+
+func NewJournalValidator() *journalValidator {
+	return &journalValidator{}
+}
+
+// ValidateListJournalTransactionsRequest validates a list journal transactions request
+func (v *journalValidator) ValidateListJournalTransactionsRequest(req *dtos.ListJournalTransactionsRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if req.Limit < 0 || req.Limit > 1000 {
+		return errors.New("limit must be between 0 and 1000")
+	}
+
+	return nil
+}
+
+// ValidateGetJournalTransactionRequest validates a get journal transaction request
+func (v *journalValidator) ValidateGetJournalTransactionRequest(req *dtos.GetJournalTransactionRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if (req.ID == "") == (req.TxHash == "") {
+		return errors.New("exactly one of id or tx_hash is required")
+	}
+
+	return nil
+}
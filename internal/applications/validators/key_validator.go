@@ -0,0 +1,37 @@
+package validators
+
+import (
+	"errors"
+
+	"kokka.com/kokka/internal/applications/dtos"
+)
+
+// maxRewrapBatch limits the ciphertexts per rewrap request (each one runs a KDF)
+const maxRewrapBatch = 100
+
+type IKeyValidator interface {
+	ValidateRewrapKeysRequest(req *dtos.RewrapKeysRequest) error
+}
+
+type keyValidator struct{}
+
+func NewKeyValidator() *keyValidator {
+	return &keyValidator{}
+}
+
+// ValidateRewrapKeysRequest validates a rewrap keys request
+func (v *keyValidator) ValidateRewrapKeysRequest(req *dtos.RewrapKeysRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if len(req.Ciphertexts) == 0 {
+		return errors.New("ciphertexts is required")
+	}
+
+	if len(req.Ciphertexts) > maxRewrapBatch {
+		return errors.New("at most 100 ciphertexts can be rewrapped per request")
+	}
+
+	return nil
+}
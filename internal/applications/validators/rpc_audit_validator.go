@@ -0,0 +1,37 @@
+package validators
+
+import (
+	"errors"
+
+	"kokka.com/kokka/internal/applications/dtos"
+	"kokka.com/kokka/internal/core/domain"
+)
+
+type IRPCAuditValidator interface {
+	ValidateListRPCAuditRequest(req *dtos.ListRPCAuditRequest) error
+}
+
+type rpcAuditValidator struct{}
+
+func NewRPCAuditValidator() *rpcAuditValidator {
+	return &rpcAuditValidator{}
+}
+
+// ValidateListRPCAuditRequest validates a list RPC audit request
+func (v *rpcAuditValidator) ValidateListRPCAuditRequest(req *dtos.ListRPCAuditRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	switch req.Outcome {
+	case "", domain.RPCOutcomeOK, domain.RPCOutcomeDenied, domain.RPCOutcomeError:
+	default:
+		return errors.New("outcome must be ok, denied or error")
+	}
+
+	if req.Limit < 0 || req.Limit > 1000 {
+		return errors.New("limit must be between 0 and 1000")
+	}
+
+	return nil
+}
@@ -48,8 +48,8 @@ func (v *swapValidator) ValidateSwapTokenRequest(req *dtos.SwapTokenRequest) err
 		return errors.New("direction must be either 'AtoB' or 'BtoA'")
 	}
 
-	if req.EncryptedPrivateKey == "" {
-		return errors.New("encrypted_private_key is required")
+	if err := validateSignerRef(req.WalletID, req.EncryptedPrivateKey); err != nil {
+		return err
 	}
 
 	return nil
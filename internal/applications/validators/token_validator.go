@@ -51,8 +51,8 @@ func (v *tokenValidator) ValidateMintTokenRequest(req *dtos.MintTokenRequest) er
 		return errors.New("invalid amount format")
 	}
 
-	if req.EncryptedPrivateKey == "" {
-		return errors.New("encrypted_private_key is required")
+	if err := validateSignerRef(req.WalletID, req.EncryptedPrivateKey); err != nil {
+		return err
 	}
 
 	return nil
@@ -80,8 +80,8 @@ func (v *tokenValidator) ValidateBurnTokenRequest(req *dtos.BurnTokenRequest) er
 		return errors.New("invalid amount format")
 	}
 
-	if req.EncryptedPrivateKey == "" {
-		return errors.New("encrypted_private_key is required")
+	if err := validateSignerRef(req.WalletID, req.EncryptedPrivateKey); err != nil {
+		return err
 	}
 
 	return nil
@@ -117,8 +117,8 @@ func (v *tokenValidator) ValidateTransferTokenRequest(req *dtos.TransferTokenReq
 		return errors.New("invalid amount format")
 	}
 
-	if req.EncryptedPrivateKey == "" {
-		return errors.New("encrypted_private_key is required")
+	if err := validateSignerRef(req.WalletID, req.EncryptedPrivateKey); err != nil {
+		return err
 	}
 
 	return nil
@@ -0,0 +1,132 @@
+package validators
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"kokka.com/kokka/internal/applications/dtos"
+	"kokka.com/kokka/internal/core/domain"
+)
+
+type IWalletValidator interface {
+	ValidateCreateWalletRequest(req *dtos.CreateWalletRequest) error
+	ValidateImportWalletRequest(req *dtos.ImportWalletRequest) error
+	ValidateRegisterWalletRequest(req *dtos.RegisterWalletRequest) error
+	ValidateDeriveWalletRequest(req *dtos.DeriveWalletRequest) error
+	ValidateResolveDerivedAddressRequest(req *dtos.ResolveDerivedAddressRequest) error
+	ValidateGetWalletRequest(req *dtos.GetWalletRequest) error
+}
+
+type walletValidator struct{}
+
+func NewWalletValidator() *walletValidator {
+	return &walletValidator{}
+}
+
+// ValidateCreateWalletRequest validates a create wallet request
+func (v *walletValidator) ValidateCreateWalletRequest(req *dtos.CreateWalletRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if len(req.Label) > 128 {
+		return errors.New("label must be at most 128 characters")
+	}
+
+	return nil
+}
+
+// ValidateImportWalletRequest validates an import wallet request
+func (v *walletValidator) ValidateImportWalletRequest(req *dtos.ImportWalletRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if req.EncryptedPrivateKey == "" {
+		return errors.New("encrypted_private_key is required")
+	}
+
+	if len(req.Label) > 128 {
+		return errors.New("label must be at most 128 characters")
+	}
+
+	return nil
+}
+
+// ValidateRegisterWalletRequest validates a register wallet request
+func (v *walletValidator) ValidateRegisterWalletRequest(req *dtos.RegisterWalletRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	switch req.Kind {
+	case domain.WalletKindKeystore:
+		if req.KeystorePath == "" {
+			return errors.New("keystore_path is required for keystore wallets")
+		}
+		if !filepath.IsLocal(req.KeystorePath) {
+			return errors.New("keystore_path must be relative to the keystore directory, without ..")
+		}
+	case domain.WalletKindRemote:
+	default:
+		return errors.New("kind must be keystore or remote")
+	}
+
+	if !common.IsHexAddress(req.Address) {
+		return errors.New("invalid address")
+	}
+
+	if len(req.Label) > 128 {
+		return errors.New("label must be at most 128 characters")
+	}
+
+	return nil
+}
+
+// ValidateDeriveWalletRequest validates a derive wallet request
+func (v *walletValidator) ValidateDeriveWalletRequest(req *dtos.DeriveWalletRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if req.Index == nil {
+		return errors.New("index is required")
+	}
+
+	if *req.Index >= 1<<31 {
+		return errors.New("index must be less than 2147483648")
+	}
+
+	if len(req.Label) > 128 {
+		return errors.New("label must be at most 128 characters")
+	}
+
+	return nil
+}
+
+// ValidateResolveDerivedAddressRequest validates a resolve derived address request
+func (v *walletValidator) ValidateResolveDerivedAddressRequest(req *dtos.ResolveDerivedAddressRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if !common.IsHexAddress(req.Address) {
+		return errors.New("invalid address")
+	}
+
+	return nil
+}
+
+// ValidateGetWalletRequest validates a get wallet request
+func (v *walletValidator) ValidateGetWalletRequest(req *dtos.GetWalletRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if req.WalletID == "" {
+		return errors.New("wallet_id is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package di
+
+import (
+	"context"
+	"time"
+
+	"kokka.com/kokka/internal/core/domain"
+)
+
+// IIdempotencyRepository stores Idempotency-Key records
+// Reserve atomically claims a key: it returns nil when the record was stored,
+// or the live record that already holds the key (expired records are replaced)
+type IIdempotencyRepository interface {
+	Reserve(ctx context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error)
+	Complete(ctx context.Context, clientID string, key string, responseCode int, responseBody []byte) error
+	Release(ctx context.Context, clientID string, key string) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,21 @@
+package di
+
+import (
+	"context"
+	"time"
+
+	"kokka.com/kokka/internal/core/domain"
+)
+
+// IJobRepository stores background job state and run history
+// AcquireLock succeeds only when no owner holds an unexpired lock, it is not re-entrant
+type IJobRepository interface {
+	AcquireLock(ctx context.Context, name string, owner string, until time.Time) (bool, error)
+	ReleaseLock(ctx context.Context, name string, owner string) error
+	SetPaused(ctx context.Context, name string, paused bool) error
+	GetState(ctx context.Context, name string) (*domain.JobState, error)
+	CreateRun(ctx context.Context, run *domain.JobRun) error
+	FinishRun(ctx context.Context, run *domain.JobRun) error
+	ListRuns(ctx context.Context, job string, limit int) ([]*domain.JobRun, error)
+	DeleteRunsBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,15 @@
+package di
+
+import (
+	"context"
+	"time"
+
+	"kokka.com/kokka/internal/core/domain"
+)
+
+// IRPCAuditRepository stores the audit trail of JSON-RPC proxy calls
+type IRPCAuditRepository interface {
+	Insert(ctx context.Context, entries []*domain.RPCAuditEntry) error
+	List(ctx context.Context, filter *domain.RPCAuditFilter) ([]*domain.RPCAuditEntry, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,14 @@
+package di
+
+import (
+	"context"
+
+	"kokka.com/kokka/internal/core/domain"
+)
+
+// ITokenTransferRepository stores indexed token transfers and indexer progress
+// SaveBatch stores the transfers and advances the cursor atomically
+type ITokenTransferRepository interface {
+	SaveBatch(ctx context.Context, transfers []*domain.TokenTransfer, cursor string, blockNumber uint64) error
+	GetCursor(ctx context.Context, cursor string) (uint64, bool, error)
+}
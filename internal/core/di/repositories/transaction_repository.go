@@ -0,0 +1,16 @@
+package di
+
+import (
+	"context"
+
+	"kokka.com/kokka/internal/core/domain"
+)
+
+type ITransactionRepository interface {
+	Create(ctx context.Context, tx *domain.Transaction) error
+	Transition(ctx context.Context, id string, update *domain.TransactionUpdate) error
+	GetByID(ctx context.Context, id string) (*domain.Transaction, error)
+	GetByTxHash(ctx context.Context, txHash string) (*domain.Transaction, error)
+	List(ctx context.Context, filter *domain.TransactionFilter) ([]*domain.Transaction, error)
+	ListEvents(ctx context.Context, id string) ([]*domain.TransactionEvent, error)
+}
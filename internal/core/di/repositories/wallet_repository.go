@@ -0,0 +1,17 @@
+package di
+
+import (
+	"context"
+
+	"kokka.com/kokka/internal/core/domain"
+)
+
+// IWalletRepository persists custodial wallets; the Get methods return domain.ErrWalletNotFound
+// when no wallet matches
+type IWalletRepository interface {
+	Create(ctx context.Context, wallet *domain.Wallet) error
+	GetByID(ctx context.Context, id string) (*domain.Wallet, error)
+	GetByAddress(ctx context.Context, address string) (*domain.Wallet, error)
+	GetByDerivationIndex(ctx context.Context, index uint32) (*domain.Wallet, error)
+	List(ctx context.Context) ([]*domain.Wallet, error)
+}
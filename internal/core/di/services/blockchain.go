@@ -15,7 +15,13 @@ type IBlockChainService interface {
 	EstimateGas(ctx context.Context, req *dtos.EstimateGasRequest) (*dtos.EstimateGasResponse, error)
 	SendRawTransaction(ctx context.Context, req *dtos.SendRawTransactionRequest) (*dtos.SendRawTransactionResponse, error)
 	SignAndSendTransaction(ctx context.Context, req *dtos.SignAndSendTransactionRequest) (*dtos.SignAndSendTransactionResponse, error)
+	SignTransaction(ctx context.Context, req *dtos.SignTransactionRequest) (*dtos.SignTransactionResponse, error)
+	BuildTransaction(ctx context.Context, req *dtos.BuildTransactionRequest) (*dtos.BuildTransactionResponse, error)
+	SignMessage(ctx context.Context, req *dtos.SignMessageRequest) (*dtos.SignatureResponse, error)
+	SignTypedData(ctx context.Context, req *dtos.SignTypedDataRequest) (*dtos.SignatureResponse, error)
+	VerifySignature(ctx context.Context, req *dtos.VerifySignatureRequest) (*dtos.VerifySignatureResponse, error)
 	GetGasPrice(ctx context.Context) (*dtos.GetGasPriceResponse, error)
 	GetChainID(ctx context.Context) (*dtos.GetChainIDResponse, error)
 	GenericRPCCall(ctx context.Context, req *dtos.GenericRPCRequest) (*dtos.GenericRPCResponse, error)
+	GenericRPCBatch(ctx context.Context, reqs []*dtos.GenericRPCRequest) (*dtos.GenericRPCBatchResponse, error)
 }
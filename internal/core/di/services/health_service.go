@@ -0,0 +1,13 @@
+package di
+
+import (
+	"context"
+
+	"kokka.com/kokka/internal/applications/dtos"
+)
+
+type IHealthService interface {
+	Liveness(ctx context.Context) (*dtos.LivenessResponse, error)
+	Readiness(ctx context.Context) (*dtos.ReadinessResponse, error)
+	Diagnostics(ctx context.Context) (*dtos.DiagnosticsResponse, error)
+}
@@ -0,0 +1,17 @@
+package di
+
+import (
+	"context"
+
+	"kokka.com/kokka/internal/core/domain"
+)
+
+// IIdempotencyService deduplicates write requests sent with an Idempotency-Key
+// Begin returns nil when the caller now holds the key and should run the request,
+// or the completed record to replay; Complete/Release settle the key afterwards
+type IIdempotencyService interface {
+	Begin(ctx context.Context, key string, fingerprint string) (*domain.IdempotencyRecord, error)
+	Complete(ctx context.Context, key string, responseCode int, responseBody []byte)
+	Release(ctx context.Context, key string)
+	PurgeExpired(ctx context.Context) (int64, error)
+}
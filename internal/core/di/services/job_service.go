@@ -0,0 +1,25 @@
+package di
+
+import (
+	"context"
+	"time"
+
+	"kokka.com/kokka/internal/applications/dtos"
+)
+
+// JobFunc is the body of a background job; the returned detail is kept in the run history
+type JobFunc func(ctx context.Context) (string, error)
+
+// IJobService schedules background jobs and exposes them to the admin API
+// Register every job before Start; Stop ends the schedule and waits for running jobs until ctx is done
+type IJobService interface {
+	Register(name string, schedule string, timeout time.Duration, run JobFunc) error
+	Start()
+	Stop(ctx context.Context)
+	ListJobs(ctx context.Context) (*dtos.ListJobsResponse, error)
+	TriggerJob(ctx context.Context, req *dtos.JobRequest) (*dtos.JobRunResponse, error)
+	PauseJob(ctx context.Context, req *dtos.JobRequest) (*dtos.JobResponse, error)
+	ResumeJob(ctx context.Context, req *dtos.JobRequest) (*dtos.JobResponse, error)
+	ListJobRuns(ctx context.Context, req *dtos.ListJobRunsRequest) (*dtos.ListJobRunsResponse, error)
+	PruneRuns(ctx context.Context, retention time.Duration) (int64, error)
+}
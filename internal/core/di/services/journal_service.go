@@ -0,0 +1,22 @@
+package di
+
+import (
+	"context"
+
+	"kokka.com/kokka/internal/applications/dtos"
+	"kokka.com/kokka/internal/driven-adapter/external/blockchain"
+)
+
+// ITransactionJournal records kokka-initiated writes and their status transitions
+// Begin runs before signing; Submitted/Signed/Failed record the outcome
+type ITransactionJournal interface {
+	Begin(ctx context.Context, operation string, walletID string, payload any) (string, error)
+	Submitted(ctx context.Context, id string, sent *blockchain.SentTransaction)
+	Signed(ctx context.Context, id string, signed *blockchain.SignedTransaction)
+	Failed(ctx context.Context, id string, sent *blockchain.SentTransaction, cause error)
+}
+
+type IJournalService interface {
+	ListTransactions(ctx context.Context, req *dtos.ListJournalTransactionsRequest) (*dtos.ListJournalTransactionsResponse, error)
+	GetTransaction(ctx context.Context, req *dtos.GetJournalTransactionRequest) (*dtos.JournalTransactionResponse, error)
+}
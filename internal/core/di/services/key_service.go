@@ -0,0 +1,12 @@
+package di
+
+import (
+	"context"
+
+	"kokka.com/kokka/internal/applications/dtos"
+)
+
+type IKeyService interface {
+	GetKeyringInfo(ctx context.Context) (*dtos.KeyringInfoResponse, error)
+	RewrapKeys(ctx context.Context, req *dtos.RewrapKeysRequest) (*dtos.RewrapKeysResponse, error)
+}
@@ -0,0 +1,12 @@
+package di
+
+import (
+	"context"
+
+	"kokka.com/kokka/internal/applications/dtos"
+)
+
+type ILogService interface {
+	GetLogLevel(ctx context.Context) (*dtos.LogLevelResponse, error)
+	SetLogLevel(ctx context.Context, req *dtos.SetLogLevelRequest) (*dtos.LogLevelResponse, error)
+}
@@ -0,0 +1,18 @@
+package di
+
+import (
+	"context"
+
+	"kokka.com/kokka/internal/applications/dtos"
+)
+
+// ITransactionMonitorService follows journaled transactions after they are broadcast
+type ITransactionMonitorService interface {
+	PollConfirmations(ctx context.Context) (*dtos.ConfirmationResult, error)
+	Reconcile(ctx context.Context) (*dtos.ReconciliationResult, error)
+}
+
+// IIndexerService indexes token events from the chain
+type IIndexerService interface {
+	IndexTransfers(ctx context.Context) (*dtos.IndexResult, error)
+}
@@ -0,0 +1,16 @@
+package di
+
+import (
+	"context"
+	"time"
+
+	"kokka.com/kokka/internal/applications/dtos"
+	"kokka.com/kokka/internal/core/domain"
+)
+
+// IRPCAuditService records which client called which JSON-RPC methods through the proxy
+type IRPCAuditService interface {
+	Record(ctx context.Context, entries []*domain.RPCAuditEntry)
+	ListEntries(ctx context.Context, req *dtos.ListRPCAuditRequest) (*dtos.ListRPCAuditResponse, error)
+	Prune(ctx context.Context, retention time.Duration) (int64, error)
+}
@@ -0,0 +1,12 @@
+package di
+
+import (
+	"context"
+
+	"kokka.com/kokka/internal/applications/dtos"
+)
+
+type IRPCCacheService interface {
+	GetStats(ctx context.Context) (*dtos.RPCCacheStatsResponse, error)
+	Purge(ctx context.Context, req *dtos.PurgeRPCCacheRequest) (*dtos.PurgeRPCCacheResponse, error)
+}
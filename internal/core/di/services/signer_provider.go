@@ -0,0 +1,17 @@
+package di
+
+import (
+	"context"
+
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/driven-adapter/external/blockchain"
+)
+
+// ISignerProvider resolves the transaction signer for a request
+// walletID is preferred; encryptedPrivateKey is the deprecated client-sent key path
+// ResolveWalletSigner is for wallets the server signs with on its own behalf (sweeps, gas top-ups)
+type ISignerProvider interface {
+	ResolveSigner(ctx context.Context, walletID string, encryptedPrivateKey string) (*blockchain.TransactionSigner, error)
+	ResolveWalletSigner(ctx context.Context, walletID string) (*blockchain.TransactionSigner, error)
+	WalletSigner(ctx context.Context, wallet *domain.Wallet) (blockchain.Signer, error)
+}
@@ -0,0 +1,11 @@
+package di
+
+import (
+	"context"
+
+	"kokka.com/kokka/internal/applications/dtos"
+)
+
+type ISweeperService interface {
+	Sweep(ctx context.Context) (*dtos.SweepResult, error)
+}
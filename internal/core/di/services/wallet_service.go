@@ -0,0 +1,17 @@
+package di
+
+import (
+	"context"
+
+	"kokka.com/kokka/internal/applications/dtos"
+)
+
+type IWalletService interface {
+	CreateWallet(ctx context.Context, req *dtos.CreateWalletRequest) (*dtos.WalletResponse, error)
+	ImportWallet(ctx context.Context, req *dtos.ImportWalletRequest) (*dtos.WalletResponse, error)
+	RegisterWallet(ctx context.Context, req *dtos.RegisterWalletRequest) (*dtos.WalletResponse, error)
+	DeriveWallet(ctx context.Context, req *dtos.DeriveWalletRequest) (*dtos.WalletResponse, error)
+	ResolveDerivedAddress(ctx context.Context, req *dtos.ResolveDerivedAddressRequest) (*dtos.DerivedAddressResponse, error)
+	GetWallet(ctx context.Context, req *dtos.GetWalletRequest) (*dtos.WalletResponse, error)
+	ListWallets(ctx context.Context) (*dtos.ListWalletsResponse, error)
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"math/big"
+	"time"
+)
+
+// Block is a block as reported by the node
+// Hashes, addresses and byte strings are hex-encoded as the node returns them
+type Block struct {
+	Number            *uint64 // nil for a pending block
+	Hash              string  // Empty for a pending block
+	ParentHash        string
+	Timestamp         time.Time
+	Miner             string
+	GasLimit          uint64
+	GasUsed           uint64
+	BaseFeePerGas     *big.Int // nil before London
+	Size              uint64
+	ExtraData         string
+	TransactionHashes []string
+	Transactions      []*ChainTransaction // Only when the block was fetched with full transactions
+}
+
+// ChainTransaction is a transaction as reported by the node
+// Not to be confused with Transaction, the journal entry of a kokka-initiated write
+type ChainTransaction struct {
+	Hash                 string
+	Type                 uint64
+	Nonce                uint64
+	From                 string
+	To                   string // Empty for a contract creation
+	Value                *big.Int
+	Gas                  uint64
+	GasPrice             *big.Int
+	MaxFeePerGas         *big.Int // nil for legacy transactions
+	MaxPriorityFeePerGas *big.Int // nil for legacy transactions
+	Input                string
+	ChainID              *big.Int // nil for pre-EIP-155 transactions
+	BlockHash            string
+	BlockNumber          *uint64 // nil while pending
+	TransactionIndex     *uint64 // nil while pending
+}
+
+// Pending reports whether the transaction is not mined yet
+func (t *ChainTransaction) Pending() bool {
+	return t.BlockNumber == nil
+}
+
+// Receipt is the outcome of a mined transaction
+type Receipt struct {
+	TransactionHash   string
+	TransactionIndex  uint64
+	BlockHash         string
+	BlockNumber       uint64
+	From              string
+	To                string // Empty for a contract creation
+	ContractAddress   string // Set for a contract creation
+	Status            uint64 // 1 on success, 0 when reverted
+	Type              uint64
+	GasUsed           uint64
+	CumulativeGasUsed uint64
+	EffectiveGasPrice *big.Int
+	Logs              []*EventLog
+}
+
+// Succeeded reports whether the transaction did not revert
+func (r *Receipt) Succeeded() bool {
+	return r.Status == 1
+}
+
+// Fee returns the fee paid in wei, or nil when the node did not report the effective gas price
+func (r *Receipt) Fee() *big.Int {
+	if r.EffectiveGasPrice == nil {
+		return nil
+	}
+	return new(big.Int).Mul(new(big.Int).SetUint64(r.GasUsed), r.EffectiveGasPrice)
+}
+
+// EventLog is an event emitted by a contract
+type EventLog struct {
+	Address          string
+	Topics           []string
+	Data             string
+	BlockNumber      uint64
+	BlockHash        string
+	TransactionHash  string
+	TransactionIndex uint64
+	LogIndex         uint64
+	Removed          bool // Set when a reorg removed the log
+}
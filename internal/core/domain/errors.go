@@ -0,0 +1,103 @@
+package domain
+
+import "errors"
+
+// ErrorCode is a stable, machine-readable error identifier returned to clients as error_code
+// Codes never change meaning; clients should branch on them instead of on error messages
+type ErrorCode string
+
+const (
+	ErrorCodeValidation            ErrorCode = "VALIDATION_FAILED"      // The request is malformed or fails validation
+	ErrorCodeInsufficientBalance   ErrorCode = "INSUFFICIENT_BALANCE"   // Not enough native balance for value + gas, or token balance
+	ErrorCodeInsufficientAllowance ErrorCode = "INSUFFICIENT_ALLOWANCE" // The spender's token allowance is too low
+	ErrorCodeNonceConflict         ErrorCode = "NONCE_CONFLICT"         // The nonce was used, skipped or is being replaced
+	ErrorCodeRPCUnavailable        ErrorCode = "RPC_UNAVAILABLE"        // The node could not be reached or is overloaded
+	ErrorCodeExecutionReverted     ErrorCode = "EXECUTION_REVERTED"     // The EVM reverted the call or transaction
+	ErrorCodeUnauthorized          ErrorCode = "UNAUTHORIZED"           // The caller may not perform the action
+	ErrorCodeMethodNotAllowed      ErrorCode = "METHOD_NOT_ALLOWED"     // The JSON-RPC method may not be called through the proxy
+	ErrorCodeQuotaExceeded         ErrorCode = "QUOTA_EXCEEDED"         // The caller used up its call quota for now
+)
+
+// Error is a typed domain error
+type Error struct {
+	Code      ErrorCode
+	Message   string
+	Reason    string  // Revert reason of EXECUTION_REVERTED (and reverts classified more precisely), when known
+	Revert    *Revert // Decoded revert data, when the node returned it
+	Retryable bool    // Whether repeating the same request later may succeed
+	Err       error   // Underlying cause, if any
+}
+
+// Revert is the decoded revert data of a failed call or transaction
+type Revert struct {
+	Error    string         `json:"error"`              // "Error", "Panic" or the custom error name, e.g. "OwnableUnauthorizedAccount"
+	Reason   string         `json:"reason"`             // Human-readable reason
+	Selector string         `json:"selector,omitempty"` // 4-byte error selector
+	Args     map[string]any `json:"args,omitempty"`     // Decoded arguments by name; integers are decimal strings
+	Data     string         `json:"data,omitempty"`     // Raw revert data
+}
+
+// NewError creates a domain error; the retryable flag follows the code
+func NewError(code ErrorCode, message string, cause error) *Error {
+	return &Error{
+		Code:      code,
+		Message:   message,
+		Retryable: code == ErrorCodeNonceConflict || code == ErrorCodeRPCUnavailable || code == ErrorCodeQuotaExceeded,
+		Err:       cause,
+	}
+}
+
+// NewValidationError creates a VALIDATION_FAILED error
+func NewValidationError(message string) error {
+	return NewError(ErrorCodeValidation, message, nil)
+}
+
+// AsValidationError marks a validator error as VALIDATION_FAILED, keeping its message
+func AsValidationError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return NewError(ErrorCodeValidation, err.Error(), err)
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying cause
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode returns the stable error code
+func (e *Error) ErrorCode() string {
+	return string(e.Code)
+}
+
+// IsRetryable reports whether repeating the same request later may succeed
+func (e *Error) IsRetryable() bool {
+	return e.Retryable
+}
+
+// RevertReason returns the revert reason, if any
+func (e *Error) RevertReason() string {
+	return e.Reason
+}
+
+// RevertDetails returns the decoded revert data, or nil
+func (e *Error) RevertDetails() any {
+	if e.Revert == nil {
+		return nil
+	}
+	return e.Revert
+}
+
+// ErrorCodeOf returns the code of the domain error err is or wraps, or "" for other errors
+func ErrorCodeOf(err error) ErrorCode {
+	var target *Error
+	if errors.As(err, &target) {
+		return target.Code
+	}
+	return ""
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Idempotency record statuses
+const (
+	IdempotencyStatusInProgress = "in_progress" // First request is still running
+	IdempotencyStatusCompleted  = "completed"   // Response stored for replay
+)
+
+// Idempotency-Key errors, both reported to the client as a conflict
+var (
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still in progress")
+)
+
+// IdempotencyRecord is the stored outcome of a request sent with an Idempotency-Key
+// Keys are scoped per API client
+type IdempotencyRecord struct {
+	ClientID     string
+	Key          string
+	Fingerprint  string // Hex SHA-256 of method, path and body
+	Status       string
+	ResponseCode int
+	ResponseBody []byte
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	ExpiresAt    time.Time
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// Job run triggers
+const (
+	JobTriggerSchedule = "schedule"
+	JobTriggerManual   = "manual"
+)
+
+// Job run statuses
+const (
+	JobRunStatusRunning   = "running"
+	JobRunStatusSucceeded = "succeeded"
+	JobRunStatusFailed    = "failed"
+)
+
+// JobState is the state of a background job shared by all server instances
+// A job is locked while LockedUntil is in the future
+type JobState struct {
+	Name        string
+	Paused      bool
+	LockedBy    string
+	LockedUntil time.Time
+	UpdatedAt   time.Time
+}
+
+// JobRun is one execution of a background job
+type JobRun struct {
+	ID         string     `json:"id"`
+	Job        string     `json:"job"`
+	Trigger    string     `json:"trigger"`
+	Status     string     `json:"status"`
+	Owner      string     `json:"owner"` // Server instance that ran the job
+	Detail     string     `json:"detail,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
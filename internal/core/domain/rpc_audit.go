@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// RPC proxy call outcomes
+const (
+	RPCOutcomeOK     = "ok"
+	RPCOutcomeDenied = "denied" // Rejected by the RPC policy or quota, never sent to the node
+	RPCOutcomeError  = "error"  // Sent to the node, which (or the transport) failed it
+)
+
+// RPCAuditEntry records one JSON-RPC call made through the proxy
+// Calls of a batch share the request ID
+type RPCAuditEntry struct {
+	ID          string    `json:"id"`
+	ClientID    string    `json:"client_id"`
+	RequestID   string    `json:"request_id,omitempty"`
+	Method      string    `json:"method"`
+	Batch       bool      `json:"batch"`
+	Outcome     string    `json:"outcome"`
+	Error       string    `json:"error,omitempty"`
+	ParamsBytes int       `json:"params_bytes"`
+	DurationMs  int64     `json:"duration_ms"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// RPCAuditFilter narrows an RPC audit listing
+type RPCAuditFilter struct {
+	ClientID string
+	Method   string
+	Outcome  string
+	Limit    int
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// TokenTransfer is an indexed ERC-20 Transfer event
+type TokenTransfer struct {
+	ID          string    `json:"id"` // "<tx_hash>:<log_index>"
+	Token       string    `json:"token"`
+	From        string    `json:"from"`
+	To          string    `json:"to"`
+	Amount      string    `json:"amount"` // In wei
+	BlockNumber uint64    `json:"block_number"`
+	TxHash      string    `json:"tx_hash"`
+	LogIndex    uint64    `json:"log_index"`
+	CreatedAt   time.Time `json:"created_at"`
+}
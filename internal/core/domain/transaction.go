@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Journaled operations
+const (
+	OperationMint        = "mint"
+	OperationBurn        = "burn"
+	OperationTransfer    = "transfer"
+	OperationSwap        = "swap"
+	OperationSignAndSend = "sign_and_send"
+	OperationSign        = "sign" // Signed for the client to broadcast
+	OperationSweep       = "sweep"
+	OperationGasTopUp    = "gas_top_up"
+)
+
+// Transaction statuses
+// pending -> submitted -> confirmed | reverted, or pending -> failed when nothing was broadcast
+// A signed transaction whose broadcast failed is unknown until the node is seen to have it
+// (submitted, confirmed or reverted) or not to have it (failed)
+// Sign-only transactions are unknown from signing, as the client broadcasts them
+const (
+	TransactionStatusPending   = "pending"   // Recorded, not yet signed/sent
+	TransactionStatusSubmitted = "submitted" // Broadcast to the network
+	TransactionStatusUnknown   = "unknown"   // Signed, but the broadcast failed and may still have reached the network
+	TransactionStatusConfirmed = "confirmed" // Mined successfully
+	TransactionStatusReverted  = "reverted"  // Mined but reverted
+	TransactionStatusFailed    = "failed"    // Failed before broadcast, or dropped by the network
+)
+
+// ErrInvalidTransition is returned when a journal entry cannot move to the requested status
+var ErrInvalidTransition = errors.New("invalid transaction status transition")
+
+// transactionTransitions lists the statuses each status may move to; confirmed, reverted and failed are final
+var transactionTransitions = map[string][]string{
+	TransactionStatusPending:   {TransactionStatusSubmitted, TransactionStatusUnknown, TransactionStatusFailed},
+	TransactionStatusUnknown:   {TransactionStatusSubmitted, TransactionStatusConfirmed, TransactionStatusReverted, TransactionStatusFailed},
+	TransactionStatusSubmitted: {TransactionStatusConfirmed, TransactionStatusReverted, TransactionStatusFailed},
+}
+
+// TransactionStatusesBefore returns the statuses a journal entry may move to status from
+func TransactionStatusesBefore(status string) []string {
+	var before []string
+	for from, next := range transactionTransitions {
+		for _, to := range next {
+			if to == status {
+				before = append(before, from)
+			}
+		}
+	}
+	return before
+}
+
+// Transaction is a journal entry for a kokka-initiated write
+type Transaction struct {
+	ID            string    `json:"id"`
+	Operation     string    `json:"operation"`
+	Status        string    `json:"status"`
+	RequestedBy   string    `json:"requested_by"`
+	WalletID      string    `json:"wallet_id,omitempty"`
+	Payload       string    `json:"payload"` // Request JSON with secrets removed
+	SignerAddress string    `json:"signer_address,omitempty"`
+	Nonce         *uint64   `json:"nonce,omitempty"`
+	TxHash        string    `json:"tx_hash,omitempty"`
+	Error         string    `json:"error,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// TransactionEvent is a status transition of a journal entry
+type TransactionEvent struct {
+	ID            string    `json:"id"`
+	TransactionID string    `json:"transaction_id"`
+	Status        string    `json:"status"`
+	Detail        string    `json:"detail,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// TransactionUpdate is a status transition; empty fields keep their current value
+type TransactionUpdate struct {
+	Status        string
+	SignerAddress string
+	Nonce         *uint64
+	TxHash        string
+	Error         string
+	Detail        string // Recorded on the event only
+}
+
+// TransactionFilter narrows a journal listing; empty fields are not filtered
+type TransactionFilter struct {
+	Status        string
+	Operation     string
+	RequestedBy   string
+	UpdatedBefore time.Time // Only entries not updated since
+	Limit         int
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrWalletNotFound is returned by wallet repositories when a wallet does not exist
+var ErrWalletNotFound = errors.New("wallet not found")
+
+// Wallet kinds
+const (
+	WalletKindGenerated = "generated" // Key generated by the server
+	WalletKindImported  = "imported"  // Key imported from a client
+	WalletKindKeystore  = "keystore"  // Key held in a go-ethereum keystore file on this host
+	WalletKindRemote    = "remote"    // Key held by the kokka-signer daemon
+	WalletKindDerived   = "derived"   // Deposit address derived from the HD wallet mnemonic
+)
+
+// Wallet is a server-managed custodial wallet
+// Generated and imported keys are only ever held encrypted under the wallet master key;
+// keystore, remote and derived wallets hold no key material here
+type Wallet struct {
+	ID              string    `json:"id"`
+	Address         string    `json:"address"`
+	Label           string    `json:"label,omitempty"`
+	Kind            string    `json:"kind"`
+	EncryptedKey    string    `json:"encrypted_key,omitempty"`    // Base64 AES-256-GCM ciphertext of the hex private key
+	KeystorePath    string    `json:"keystore_path,omitempty"`    // Keystore file path (keystore wallets only)
+	DerivationIndex *uint32   `json:"derivation_index,omitempty"` // BIP-44 address index (derived wallets only)
+	DerivationPath  string    `json:"derivation_path,omitempty"`  // e.g. m/44'/60'/0'/0/7 (derived wallets only)
+	CreatedBy       string    `json:"created_by,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
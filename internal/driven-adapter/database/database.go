@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // postgres driver
+	"kokka.com/kokka/internal/shared/config"
+	_ "modernc.org/sqlite" // pure-Go sqlite driver
+)
+
+// Supported drivers
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// DB wraps *sql.DB with the driver name so repositories can write portable SQL
+// Queries use "?" placeholders; Rebind converts them for postgres
+type DB struct {
+	*sql.DB
+	Driver string
+}
+
+// Open opens the database described by the config
+// DB_DRIVER=sqlite uses DB_NAME as the database file path (":memory:" for an in-memory database)
+func Open(cfg *config.DBConfig) (*DB, error) {
+	driver := cfg.Driver
+	if driver == "" {
+		driver = DriverPostgres
+	}
+
+	var sqlDriver, dsn string
+	switch driver {
+	case DriverPostgres:
+		sqlDriver, dsn = "pgx", postgresDSN(cfg)
+	case DriverSQLite:
+		if cfg.Name == "" {
+			return nil, fmt.Errorf("DB_NAME (database file) is required for sqlite")
+		}
+		if cfg.Name != ":memory:" {
+			if err := os.MkdirAll(filepath.Dir(cfg.Name), 0700); err != nil {
+				return nil, fmt.Errorf("failed to create database directory: %w", err)
+			}
+		}
+		sqlDriver, dsn = "sqlite", "file:"+cfg.Name+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+	default:
+		return nil, fmt.Errorf("unsupported DB_DRIVER %q", driver)
+	}
+
+	sqlDB, err := sql.Open(sqlDriver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if driver == DriverSQLite {
+		sqlDB.SetMaxOpenConns(1) // sqlite allows a single writer
+	}
+
+	if err := sqlDB.PingContext(context.Background()); err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return &DB{DB: sqlDB, Driver: driver}, nil
+}
+
+// Rebind converts "?" placeholders to the driver's placeholder style
+func (db *DB) Rebind(query string) string {
+	if db.Driver != DriverPostgres {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// WithTx runs fn in a transaction, committing on success and rolling back on error
+func (db *DB) WithTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// postgresDSN builds a postgres connection URL from the config
+func postgresDSN(cfg *config.DBConfig) string {
+	dsn := url.URL{
+		Scheme: "postgres",
+		Host:   cfg.Host,
+		Path:   "/" + cfg.Name,
+	}
+	if cfg.Port != "" {
+		dsn.Host = cfg.Host + ":" + cfg.Port
+	}
+	if cfg.Password != "" {
+		dsn.User = url.UserPassword(cfg.User, cfg.Password)
+	} else {
+		dsn.User = url.User(cfg.User)
+	}
+
+	query := url.Values{}
+	if cfg.SSLMode != "" {
+		query.Set("sslmode", cfg.SSLMode)
+	}
+	dsn.RawQuery = query.Encode()
+
+	return dsn.String()
+}
@@ -0,0 +1,349 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// migrationFilePattern matches "<version>_<name>.sql", e.g. 20261019090000_create_transactions.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.sql$`)
+
+const createSchemaMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version VARCHAR(32) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    applied_at TIMESTAMP NOT NULL
+)`
+
+const createSchemaMigrationsLockTable = `CREATE TABLE IF NOT EXISTS schema_migrations_lock (
+    id INTEGER PRIMARY KEY,
+    locked_at TIMESTAMP NOT NULL
+)`
+
+// migrationLockKey is the postgres advisory lock held while migrations are applied or reverted
+const migrationLockKey int64 = 7240118230612409
+
+// migrationLockPoll is how often a waiting migrator retries the sqlite lock row
+const migrationLockPoll = 500 * time.Millisecond
+
+// Migration is a pair of up/down SQL files sharing a version
+type Migration struct {
+	Version   string
+	Name      string
+	UpPath    string
+	DownPath  string
+	AppliedAt *time.Time // nil when pending
+}
+
+// Migrator applies the SQL files in <dir>/up and <dir>/down and records applied versions in schema_migrations
+type Migrator struct {
+	db  *DB
+	dir string
+}
+
+// NewMigrator creates a migrator for the migrations directory
+func NewMigrator(db *DB, dir string) *Migrator {
+	return &Migrator{db: db, dir: dir}
+}
+
+// Status returns every migration on disk with its applied time, oldest first
+// Versions recorded in the database but missing on disk are included with empty paths
+func (m *Migrator) Status(ctx context.Context) ([]*Migration, error) {
+	migrations, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[string]*Migration, len(migrations))
+	for _, migration := range migrations {
+		byVersion[migration.Version] = migration
+	}
+	for version, record := range applied {
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: record.name}
+			migrations = append(migrations, migration)
+		}
+		appliedAt := record.appliedAt
+		migration.AppliedAt = &appliedAt
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Pending returns the migrations that have not been applied, oldest first
+func (m *Migrator) Pending(ctx context.Context) ([]*Migration, error) {
+	migrations, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*Migration
+	for _, migration := range migrations {
+		if migration.AppliedAt == nil {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies up to n pending migrations (all when n <= 0), each in its own transaction
+// Concurrent migrators wait for each other, so each migration is applied once
+func (m *Migrator) Up(ctx context.Context, n int) (done []*Migration, err error) {
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if unlockErr := unlock(); unlockErr != nil && err == nil {
+			err = unlockErr
+		}
+	}()
+
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 && n < len(pending) {
+		pending = pending[:n]
+	}
+
+	for _, migration := range pending {
+		script, err := os.ReadFile(migration.UpPath)
+		if err != nil {
+			return done, fmt.Errorf("failed to read %s: %w", migration.UpPath, err)
+		}
+
+		err = m.db.WithTx(ctx, func(tx *sql.Tx) error {
+			if err := execScript(ctx, tx, string(script)); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, m.db.Rebind("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)"),
+				migration.Version, migration.Name, time.Now().UTC())
+			return err
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %s_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// Down reverts the last n applied migrations (n <= 0 reverts one), newest first, each in its own transaction
+func (m *Migrator) Down(ctx context.Context, n int) (done []*Migration, err error) {
+	if n <= 0 {
+		n = 1
+	}
+
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if unlockErr := unlock(); unlockErr != nil && err == nil {
+			err = unlockErr
+		}
+	}()
+
+	migrations, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(migrations) - 1; i >= 0 && len(done) < n; i-- {
+		migration := migrations[i]
+		if migration.AppliedAt == nil {
+			continue
+		}
+		if migration.DownPath == "" {
+			return done, fmt.Errorf("migration %s has no down file", migration.Version)
+		}
+
+		script, err := os.ReadFile(migration.DownPath)
+		if err != nil {
+			return done, fmt.Errorf("failed to read %s: %w", migration.DownPath, err)
+		}
+
+		err = m.db.WithTx(ctx, func(tx *sql.Tx) error {
+			if err := execScript(ctx, tx, string(script)); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, m.db.Rebind("DELETE FROM schema_migrations WHERE version = ?"), migration.Version)
+			return err
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %s_%s rollback failed: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// CreateMigration creates empty up/down files named with the current timestamp
+func CreateMigration(dir string, name string) (upPath string, downPath string, err error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	version := time.Now().UTC().Format("20060102150405")
+	fileName := version + "_" + name + ".sql"
+	if !migrationFilePattern.MatchString(fileName) {
+		return "", "", fmt.Errorf("migration name must contain only lowercase letters, digits and underscores")
+	}
+
+	upPath = filepath.Join(dir, "up", fileName)
+	downPath = filepath.Join(dir, "down", fileName)
+	for _, path := range []string{upPath, downPath} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return "", "", err
+		}
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err != nil {
+			return "", "", err
+		}
+		file.Close()
+	}
+
+	return upPath, downPath, nil
+}
+
+// lock waits until no other migrator runs and returns the function releasing the lock
+// Postgres uses a session advisory lock, released with the session if the process dies
+// SQLite uses a lock row; a row left by a crashed migrator must be deleted by hand
+func (m *Migrator) lock(ctx context.Context) (func() error, error) {
+	if m.db.Driver == DriverPostgres {
+		conn, err := m.db.Conn(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lock migrations: %w", err)
+		}
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to lock migrations: %w", err)
+		}
+		return func() error {
+			defer conn.Close()
+			if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey); err != nil {
+				return fmt.Errorf("failed to unlock migrations: %w", err)
+			}
+			return nil
+		}, nil
+	}
+
+	if _, err := m.db.ExecContext(ctx, createSchemaMigrationsLockTable); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations_lock: %w", err)
+	}
+	for {
+		_, err := m.db.ExecContext(ctx, m.db.Rebind("INSERT INTO schema_migrations_lock (id, locked_at) VALUES (1, ?)"), time.Now().UTC())
+		if err == nil {
+			break
+		}
+
+		// Only a held lock is waited for; any other error is returned
+		var lockedAt time.Time
+		if scanErr := m.db.QueryRowContext(ctx, "SELECT locked_at FROM schema_migrations_lock WHERE id = 1").Scan(&lockedAt); scanErr != nil {
+			if errors.Is(scanErr, sql.ErrNoRows) {
+				continue // Released in between
+			}
+			return nil, fmt.Errorf("failed to lock migrations: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("migrations are locked since %s; if no migration is running, delete the row from schema_migrations_lock: %w",
+				lockedAt.Format(time.RFC3339), ctx.Err())
+		case <-time.After(migrationLockPoll):
+		}
+	}
+
+	return func() error {
+		if _, err := m.db.ExecContext(context.Background(), "DELETE FROM schema_migrations_lock WHERE id = 1"); err != nil {
+			return fmt.Errorf("failed to unlock migrations: %w", err)
+		}
+		return nil
+	}, nil
+}
+
+type appliedMigration struct {
+	name      string
+	appliedAt time.Time
+}
+
+// applied returns the versions recorded in schema_migrations, creating the table if needed
+func (m *Migrator) applied(ctx context.Context) (map[string]appliedMigration, error) {
+	if _, err := m.db.ExecContext(ctx, createSchemaMigrationsTable); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	rows, err := m.db.QueryContext(ctx, "SELECT version, name, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	result := map[string]appliedMigration{}
+	for rows.Next() {
+		var version string
+		var record appliedMigration
+		if err := rows.Scan(&version, &record.name, &record.appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		result[version] = record
+	}
+	return result, rows.Err()
+}
+
+// load reads the migration files on disk
+func (m *Migrator) load() ([]*Migration, error) {
+	entries, err := os.ReadDir(filepath.Join(m.dir, "up"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var migrations []*Migration
+	seen := map[string]string{}
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		if other, ok := seen[match[1]]; ok {
+			return nil, fmt.Errorf("duplicate migration version %s (%s, %s)", match[1], other, entry.Name())
+		}
+		seen[match[1]] = entry.Name()
+
+		migration := &Migration{
+			Version: match[1],
+			Name:    match[2],
+			UpPath:  filepath.Join(m.dir, "up", entry.Name()),
+		}
+		downPath := filepath.Join(m.dir, "down", entry.Name())
+		if _, err := os.Stat(downPath); err == nil {
+			migration.DownPath = downPath
+		}
+		migrations = append(migrations, migration)
+	}
+
+	return migrations, nil
+}
+
+// execScript runs a SQL script; empty scripts are allowed
+func execScript(ctx context.Context, tx *sql.Tx, script string) error {
+	if strings.TrimSpace(script) == "" {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, script)
+	return err
+}
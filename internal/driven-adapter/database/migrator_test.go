@@ -0,0 +1,205 @@
+package database
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"kokka.com/kokka/internal/shared/config"
+)
+
+// newTestDB opens an empty SQLite database in a temporary directory
+func newTestDB(t *testing.T) *DB {
+	t.Helper()
+	db, err := Open(&config.DBConfig{Driver: DriverSQLite, Name: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// writeMigrations writes up/down files for each version into a temporary migrations directory
+func writeMigrations(t *testing.T, migrations map[string][2]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, sub := range []string{"up", "down"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for fileName, scripts := range migrations {
+		if err := os.WriteFile(filepath.Join(dir, "up", fileName), []byte(scripts[0]), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "down", fileName), []byte(scripts[1]), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func testMigrations(t *testing.T) string {
+	return writeMigrations(t, map[string][2]string{
+		"20261019090000_create_a.sql": {"CREATE TABLE a (id INTEGER PRIMARY KEY)", "DROP TABLE a"},
+		"20261019100000_create_b.sql": {"CREATE TABLE b (id INTEGER PRIMARY KEY)", "DROP TABLE b"},
+		"20261019110000_create_c.sql": {"CREATE TABLE c (id INTEGER PRIMARY KEY)", "DROP TABLE c"},
+	})
+}
+
+func versions(migrations []*Migration) []string {
+	result := make([]string, 0, len(migrations))
+	for _, migration := range migrations {
+		result = append(result, migration.Version)
+	}
+	return result
+}
+
+func tableExists(t *testing.T, db *DB, name string) bool {
+	t.Helper()
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", name).Scan(&count)
+	if err != nil {
+		t.Fatalf("sqlite_master: %v", err)
+	}
+	return count == 1
+}
+
+func TestMigratorUpDownStatus(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	migrator := NewMigrator(db, testMigrations(t))
+
+	done, err := migrator.Up(ctx, 2)
+	if err != nil {
+		t.Fatalf("Up(2): %v", err)
+	}
+	if got := versions(done); len(got) != 2 || got[0] != "20261019090000" || got[1] != "20261019100000" {
+		t.Errorf("Up(2) applied %v", got)
+	}
+
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if len(status) != 3 || status[0].AppliedAt == nil || status[1].AppliedAt == nil || status[2].AppliedAt != nil {
+		t.Errorf("Status after Up(2) = %+v", status)
+	}
+
+	done, err = migrator.Up(ctx, 0)
+	if err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if got := versions(done); len(got) != 1 || got[0] != "20261019110000" {
+		t.Errorf("Up applied %v", got)
+	}
+	for _, table := range []string{"a", "b", "c"} {
+		if !tableExists(t, db, table) {
+			t.Errorf("table %s was not created", table)
+		}
+	}
+
+	done, err = migrator.Down(ctx, 2)
+	if err != nil {
+		t.Fatalf("Down(2): %v", err)
+	}
+	if got := versions(done); len(got) != 2 || got[0] != "20261019110000" || got[1] != "20261019100000" {
+		t.Errorf("Down(2) reverted %v", got)
+	}
+	if tableExists(t, db, "b") || !tableExists(t, db, "a") {
+		t.Error("Down(2) did not drop exactly b and c")
+	}
+
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		t.Fatalf("Pending: %v", err)
+	}
+	if got := versions(pending); len(got) != 2 || got[0] != "20261019100000" {
+		t.Errorf("Pending = %v", got)
+	}
+}
+
+func TestMigratorFailedMigrationRollsBack(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	migrator := NewMigrator(db, writeMigrations(t, map[string][2]string{
+		"20261019090000_create_a.sql": {"CREATE TABLE a (id INTEGER PRIMARY KEY)", "DROP TABLE a"},
+		"20261019100000_broken.sql":   {"CREATE TABLE b (id INTEGER PRIMARY KEY); INSERT INTO missing VALUES (1)", ""},
+	}))
+
+	done, err := migrator.Up(ctx, 0)
+	if err == nil {
+		t.Fatal("Up succeeded with a broken migration")
+	}
+	if got := versions(done); len(got) != 1 || got[0] != "20261019090000" {
+		t.Errorf("Up applied %v before failing", got)
+	}
+	if tableExists(t, db, "b") {
+		t.Error("the failed migration was not rolled back")
+	}
+
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		t.Fatalf("Pending: %v", err)
+	}
+	if got := versions(pending); len(got) != 1 || got[0] != "20261019100000" {
+		t.Errorf("Pending = %v", got)
+	}
+}
+
+func TestMigratorConcurrentUp(t *testing.T) {
+	ctx := context.Background()
+	dir := testMigrations(t)
+	name := filepath.Join(t.TempDir(), "shared.db")
+
+	var wg sync.WaitGroup
+	applied := make([]int, 2)
+	errs := make([]error, 2)
+	for i := range 2 {
+		db, err := Open(&config.DBConfig{Driver: DriverSQLite, Name: name})
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			done, err := NewMigrator(db, dir).Up(ctx, 0)
+			applied[i], errs[i] = len(done), err
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("migrator %d: %v", i, err)
+		}
+	}
+	if applied[0]+applied[1] != 3 {
+		t.Errorf("migrations applied %d + %d times, want 3 in total", applied[0], applied[1])
+	}
+}
+
+func TestCreateMigration(t *testing.T) {
+	dir := t.TempDir()
+
+	upPath, downPath, err := CreateMigration(dir, "Add_Index")
+	if err != nil {
+		t.Fatalf("CreateMigration: %v", err)
+	}
+	for _, path := range []string{upPath, downPath} {
+		if !migrationFilePattern.MatchString(filepath.Base(path)) {
+			t.Errorf("%s does not match the migration file pattern", path)
+		}
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s was not created: %v", path, err)
+		}
+	}
+
+	if _, _, err := CreateMigration(dir, "add-index"); err == nil {
+		t.Error("invalid migration name was accepted")
+	}
+}
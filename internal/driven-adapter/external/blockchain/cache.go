@@ -0,0 +1,503 @@
+package blockchain
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"kokka.com/kokka/internal/shared/logger"
+	"kokka.com/kokka/internal/shared/metrics"
+)
+
+// CacheConfig configures the read cache of a client
+type CacheConfig struct {
+	MaxEntries    int           // Least recently used entries are evicted past this
+	FinalityDepth uint64        // Blocks this far below the head are treated as final
+	HeadRefresh   time.Duration // How often the head block is polled while reads come in
+}
+
+// maxWatchedAddresses caps the contracts one eth_getLogs watches for invalidating events
+const maxWatchedAddresses = 100
+
+// cacheScope decides how long an entry stays valid
+type cacheScope int
+
+const (
+	scopeForever  cacheScope = iota // Immutable results, or results at final blocks
+	scopeHead                       // Results at the head, valid until the next block
+	scopeContract                   // Contract state that only changes along with an event of the contract
+)
+
+// Cache invalidation reasons, as exported by kokka_rpc_cache_invalidations_total
+const (
+	invalidatedHead    = "head"
+	invalidatedEvent   = "event"
+	invalidatedEvicted = "evicted"
+	invalidatedPurge   = "purge"
+)
+
+// immutableSelectors are view functions whose result never changes once a contract is deployed
+var immutableSelectors = selectors("name()", "symbol()", "decimals()", "tokenA()", "tokenB()", "token0()", "token1()", "factory()")
+
+// contractSelectors are view functions whose result changes rarely, and always with an event
+// (OwnershipTransferred, or Transfer on mint and burn)
+var contractSelectors = selectors("owner()", "totalSupply()")
+
+// fetchFunc executes a JSON-RPC call against the node
+type fetchFunc func(ctx context.Context, method string, params interface{}) (*JSONRPCResponse, error)
+
+// Cache keeps results of JSON-RPC reads so repeated reads skip the node
+// Immutable results and reads at final blocks are kept until evicted, reads at the head until the next
+// block, and rarely changing contract reads until the contract emits an event
+// Only Client.Call goes through the cache; batches and writes always reach the node
+type Cache struct {
+	config CacheConfig
+
+	mutex     sync.Mutex
+	entries   map[string]*list.Element // Values are *cacheEntry
+	recent    *list.List               // Most recently used first
+	contracts map[string]int           // Entries by watched contract address
+	head      uint64
+
+	headMutex   sync.Mutex // Serializes head refreshes
+	headChecked time.Time
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// cacheEntry is a cached result
+type cacheEntry struct {
+	key      string
+	method   string
+	scope    cacheScope
+	head     uint64 // Head block the result was read at, for scopeHead
+	contract string // Lowercase contract address, for scopeContract
+	response *JSONRPCResponse
+}
+
+// CacheStats describes the content and effectiveness of a cache
+type CacheStats struct {
+	Entries   int
+	Contracts int // Contracts watched for invalidating events
+	Hits      uint64
+	Misses    uint64
+	HeadBlock uint64
+}
+
+// NewCache creates an empty cache
+func NewCache(config CacheConfig) *Cache {
+	return &Cache{
+		config:    config,
+		entries:   map[string]*list.Element{},
+		recent:    list.New(),
+		contracts: map[string]int{},
+	}
+}
+
+// Call returns a cached result of a read, or fetches and caches it
+func (c *Cache) Call(ctx context.Context, method string, params interface{}, fetch fetchFunc) (*JSONRPCResponse, error) {
+	request, ok := parseCacheRequest(method, params)
+	if !ok {
+		resp, err := fetch(ctx, method, params)
+		if err == nil && method == "eth_blockNumber" {
+			var head hexutil.Uint64
+			if resp.UnmarshalResult(&head) == nil {
+				c.advance(ctx, uint64(head), fetch)
+			}
+		}
+		return resp, err
+	}
+
+	headKnown := c.refreshHead(ctx, fetch)
+	head := c.headBlock()
+	if resp := c.get(request.key, headKnown); resp != nil {
+		c.hits.Add(1)
+		metrics.ObserveRPCCacheLookup(method, true)
+		return resp, nil
+	}
+	c.misses.Add(1)
+	metrics.ObserveRPCCacheLookup(method, false)
+
+	resp, err := fetch(ctx, method, params)
+	if err != nil || !headKnown {
+		return resp, err
+	}
+	if scope, ok := c.resultScope(request, resp.Result); ok {
+		c.put(request, scope, head, resp)
+	}
+	return resp, nil
+}
+
+// Stats returns the current content and hit counts
+func (c *Cache) Stats() CacheStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return CacheStats{
+		Entries:   len(c.entries),
+		Contracts: len(c.contracts),
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		HeadBlock: c.head,
+	}
+}
+
+// Purge drops all entries, or only those of a contract when address is set, and returns how many were dropped
+func (c *Cache) Purge(address string) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	address = strings.ToLower(address)
+	purged := c.removeWhere(func(entry *cacheEntry) bool {
+		return address == "" || entry.contract == address
+	})
+	metrics.AddRPCCacheInvalidations(invalidatedPurge, purged)
+	return purged
+}
+
+// headBlock returns the last known head block
+func (c *Cache) headBlock() uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.head
+}
+
+// get returns a copy of a valid entry, or nil
+func (c *Cache) get(key string, headKnown bool) *JSONRPCResponse {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	entry := element.Value.(*cacheEntry)
+	if entry.scope == scopeHead && (!headKnown || entry.head != c.head) {
+		return nil
+	}
+	c.recent.MoveToFront(element)
+
+	resp := *entry.response
+	return &resp
+}
+
+// put stores a result read at a head block, evicting the least recently used entries past MaxEntries
+// Results are dropped when the head moved meanwhile, as its invalidations may have missed them
+func (c *Cache) put(request *cacheRequest, scope cacheScope, head uint64, resp *JSONRPCResponse) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if head != c.head {
+		return
+	}
+
+	if element, ok := c.entries[request.key]; ok {
+		c.remove(element)
+	}
+	entry := &cacheEntry{
+		key:      request.key,
+		method:   request.method,
+		scope:    scope,
+		head:     head,
+		response: resp,
+	}
+	if scope == scopeContract {
+		entry.contract = request.contract
+		c.contracts[entry.contract]++
+	}
+	c.entries[entry.key] = c.recent.PushFront(entry)
+
+	evicted := 0
+	for c.config.MaxEntries > 0 && len(c.entries) > c.config.MaxEntries {
+		c.remove(c.recent.Back())
+		evicted++
+	}
+	metrics.AddRPCCacheInvalidations(invalidatedEvicted, evicted)
+	metrics.SetRPCCacheEntries(len(c.entries))
+}
+
+// remove drops an entry; the caller holds the mutex
+func (c *Cache) remove(element *list.Element) {
+	entry := c.recent.Remove(element).(*cacheEntry)
+	delete(c.entries, entry.key)
+	if entry.contract != "" {
+		if c.contracts[entry.contract]--; c.contracts[entry.contract] <= 0 {
+			delete(c.contracts, entry.contract)
+		}
+	}
+}
+
+// removeWhere drops the matching entries and returns how many were dropped; the caller holds the mutex
+func (c *Cache) removeWhere(match func(entry *cacheEntry) bool) int {
+	removed := 0
+	for element := c.recent.Front(); element != nil; {
+		next := element.Next()
+		if match(element.Value.(*cacheEntry)) {
+			c.remove(element)
+			removed++
+		}
+		element = next
+	}
+	metrics.SetRPCCacheEntries(len(c.entries))
+	return removed
+}
+
+// refreshHead polls the head block at most every HeadRefresh and reports whether the head is known
+func (c *Cache) refreshHead(ctx context.Context, fetch fetchFunc) bool {
+	c.headMutex.Lock()
+	defer c.headMutex.Unlock()
+
+	if !c.headChecked.IsZero() && time.Since(c.headChecked) < c.config.HeadRefresh {
+		return true
+	}
+
+	resp, err := fetch(ctx, "eth_blockNumber", []interface{}{})
+	var head hexutil.Uint64
+	if err == nil {
+		err = resp.UnmarshalResult(&head)
+	}
+	if err != nil {
+		// Serve immutable entries only until the head is known again
+		c.headChecked = time.Time{}
+		return false
+	}
+
+	c.headChecked = time.Now()
+	c.advance(ctx, uint64(head), fetch)
+	return true
+}
+
+// advance moves the head forward, dropping the entries read at the previous head and
+// those of contracts that emitted events in the new blocks
+func (c *Cache) advance(ctx context.Context, head uint64, fetch fetchFunc) {
+	c.mutex.Lock()
+	previous := c.head
+	if head <= previous {
+		c.mutex.Unlock()
+		return
+	}
+	c.head = head
+	metrics.AddRPCCacheInvalidations(invalidatedHead, c.removeWhere(func(entry *cacheEntry) bool {
+		return entry.scope == scopeHead
+	}))
+	contracts := make([]string, 0, len(c.contracts))
+	for contract := range c.contracts {
+		contracts = append(contracts, contract)
+	}
+	c.mutex.Unlock()
+
+	if len(contracts) == 0 || previous == 0 {
+		return
+	}
+
+	// Without the events of the new blocks, no contract entry can be trusted
+	emitted, err := c.emitters(ctx, previous+1, head, contracts, fetch)
+	if err != nil {
+		logger.Warn("rpc cache: dropping contract entries, failed to check events of blocks %d-%d: %v", previous+1, head, err)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	metrics.AddRPCCacheInvalidations(invalidatedEvent, c.removeWhere(func(entry *cacheEntry) bool {
+		return entry.scope == scopeContract && (err != nil || emitted[entry.contract])
+	}))
+}
+
+// emitters returns which of the contracts emitted events between two blocks
+func (c *Cache) emitters(ctx context.Context, fromBlock, toBlock uint64, contracts []string, fetch fetchFunc) (map[string]bool, error) {
+	if len(contracts) > maxWatchedAddresses {
+		return nil, fmt.Errorf("%d contracts watched, at most %d are checked", len(contracts), maxWatchedAddresses)
+	}
+	if c.config.FinalityDepth > 0 && toBlock-fromBlock >= c.config.FinalityDepth {
+		return nil, fmt.Errorf("head moved by %d blocks", toBlock-fromBlock+1)
+	}
+
+	filter := map[string]interface{}{
+		"fromBlock": hexutil.EncodeUint64(fromBlock),
+		"toBlock":   hexutil.EncodeUint64(toBlock),
+		"address":   contracts,
+	}
+	resp, err := fetch(ctx, "eth_getLogs", []interface{}{filter})
+	if err != nil {
+		return nil, err
+	}
+	logs, err := decodeLogs(resp.Result)
+	if err != nil {
+		return nil, err
+	}
+
+	emitted := map[string]bool{}
+	for _, log := range logs {
+		emitted[strings.ToLower(log.Address)] = true
+	}
+	return emitted, nil
+}
+
+// resultScope decides how long a fetched result stays valid; false when it must not be cached
+func (c *Cache) resultScope(request *cacheRequest, result json.RawMessage) (cacheScope, bool) {
+	if isNull(result) {
+		return 0, false
+	}
+
+	// Transactions and receipts are final once their block is
+	if request.method == "eth_getTransactionByHash" || request.method == "eth_getTransactionReceipt" {
+		var mined struct {
+			BlockNumber *hexutil.Uint64 `json:"blockNumber"`
+		}
+		if json.Unmarshal(result, &mined) != nil || mined.BlockNumber == nil {
+			return 0, false
+		}
+		return c.blockScope(uint64(*mined.BlockNumber)), true
+	}
+
+	if request.scope == scopeHead && request.block != nil {
+		return c.blockScope(*request.block), true
+	}
+	return request.scope, true
+}
+
+// blockScope returns scopeForever for a final block and scopeHead otherwise
+func (c *Cache) blockScope(block uint64) cacheScope {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.head >= c.config.FinalityDepth && block <= c.head-c.config.FinalityDepth {
+		return scopeForever
+	}
+	return scopeHead
+}
+
+// cacheRequest is a cacheable call
+type cacheRequest struct {
+	key      string
+	method   string
+	scope    cacheScope
+	block    *uint64 // Block number the call reads at, when given as a number
+	contract string  // Lowercase contract address, for scopeContract
+}
+
+// parseCacheRequest classifies a call; false when the call must not be cached
+func parseCacheRequest(method string, params interface{}) (*cacheRequest, bool) {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return nil, false
+	}
+	var values []interface{}
+	if !isNull(encoded) && json.Unmarshal(encoded, &values) != nil {
+		return nil, false
+	}
+
+	request := &cacheRequest{key: method + ":" + string(encoded), method: method}
+	switch method {
+	case "eth_chainId", "eth_getBlockByHash", "eth_getTransactionByHash", "eth_getTransactionReceipt":
+		request.scope = scopeForever
+		return request, true
+
+	case "eth_getBalance", "eth_getCode", "eth_getStorageAt":
+		if len(values) == 0 {
+			return nil, false
+		}
+		return request, request.atBlock(values[len(values)-1])
+
+	case "eth_getBlockByNumber":
+		if len(values) == 0 {
+			return nil, false
+		}
+		return request, request.atBlock(values[0])
+
+	case "eth_call":
+		if len(values) == 0 {
+			return nil, false
+		}
+		call, ok := values[0].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		var block interface{} = "latest"
+		if len(values) > 1 {
+			block = values[1]
+		}
+		if !request.atBlock(block) {
+			return nil, false
+		}
+
+		// Immutable and event-tracked reads do not depend on the block read at
+		to, _ := call["to"].(string)
+		selector := callSelector(call)
+		if immutableSelectors[selector] {
+			request.key = method + ":" + strings.ToLower(to) + ":" + selector
+			request.scope = scopeForever
+		} else if contractSelectors[selector] && request.block == nil && to != "" {
+			request.key = method + ":" + strings.ToLower(to) + ":" + selector
+			request.scope = scopeContract
+			request.contract = strings.ToLower(to)
+		}
+		return request, true
+	}
+	return nil, false
+}
+
+// atBlock sets the scope of a read at a block parameter; false for the pending block
+func (r *cacheRequest) atBlock(block interface{}) bool {
+	if object, ok := block.(map[string]interface{}); ok {
+		// EIP-1898 block parameter
+		if _, ok := object["blockHash"]; ok {
+			r.scope = scopeForever
+			return true
+		}
+		block = object["blockNumber"]
+	}
+
+	tag, ok := block.(string)
+	if !ok {
+		return false
+	}
+	switch tag {
+	case "latest", "safe", "finalized":
+		r.scope = scopeHead
+		return true
+	case "earliest":
+		r.scope = scopeForever
+		return true
+	case "pending":
+		return false
+	}
+
+	number, err := hexutil.DecodeUint64(tag)
+	if err != nil {
+		return false
+	}
+	r.scope = scopeHead // Narrowed down to scopeForever once the block is known to be final
+	r.block = &number
+	return true
+}
+
+// callSelector returns the lowercase 4-byte function selector of an eth_call object, or empty
+func callSelector(call map[string]interface{}) string {
+	data, _ := call["input"].(string)
+	if data == "" {
+		data, _ = call["data"].(string)
+	}
+	// Calls with arguments depend on them, only argument-less getters are matched
+	if len(data) != 10 || call["value"] != nil {
+		return ""
+	}
+	return strings.ToLower(data)
+}
+
+// selectors returns the hex-encoded selectors of function signatures
+func selectors(signatures ...string) map[string]bool {
+	result := make(map[string]bool, len(signatures))
+	for _, signature := range signatures {
+		result[hexutil.Encode(crypto.Keccak256([]byte(signature))[:4])] = true
+	}
+	return result
+}
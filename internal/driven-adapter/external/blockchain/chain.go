@@ -0,0 +1,244 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"kokka.com/kokka/internal/core/domain"
+)
+
+// rpcBlock is a block in the node's JSON encoding
+type rpcBlock struct {
+	Number        *hexutil.Uint64 `json:"number"`
+	Hash          *string         `json:"hash"`
+	ParentHash    string          `json:"parentHash"`
+	Timestamp     hexutil.Uint64  `json:"timestamp"`
+	Miner         string          `json:"miner"`
+	GasLimit      hexutil.Uint64  `json:"gasLimit"`
+	GasUsed       hexutil.Uint64  `json:"gasUsed"`
+	BaseFeePerGas *hexutil.Big    `json:"baseFeePerGas"`
+	Size          hexutil.Uint64  `json:"size"`
+	ExtraData     string          `json:"extraData"`
+	Transactions  json.RawMessage `json:"transactions"` // Hashes, or objects with full transactions
+}
+
+// rpcTransaction is a transaction in the node's JSON encoding
+type rpcTransaction struct {
+	Hash                 string          `json:"hash"`
+	Type                 hexutil.Uint64  `json:"type"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	From                 string          `json:"from"`
+	To                   *string         `json:"to"`
+	Value                *hexutil.Big    `json:"value"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Input                string          `json:"input"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+	BlockHash            *string         `json:"blockHash"`
+	BlockNumber          *hexutil.Uint64 `json:"blockNumber"`
+	TransactionIndex     *hexutil.Uint64 `json:"transactionIndex"`
+}
+
+// rpcReceipt is a transaction receipt in the node's JSON encoding
+type rpcReceipt struct {
+	TransactionHash   string         `json:"transactionHash"`
+	TransactionIndex  hexutil.Uint64 `json:"transactionIndex"`
+	BlockHash         string         `json:"blockHash"`
+	BlockNumber       hexutil.Uint64 `json:"blockNumber"`
+	From              string         `json:"from"`
+	To                *string        `json:"to"`
+	ContractAddress   *string        `json:"contractAddress"`
+	Status            hexutil.Uint64 `json:"status"`
+	Type              hexutil.Uint64 `json:"type"`
+	GasUsed           hexutil.Uint64 `json:"gasUsed"`
+	CumulativeGasUsed hexutil.Uint64 `json:"cumulativeGasUsed"`
+	EffectiveGasPrice *hexutil.Big   `json:"effectiveGasPrice"`
+	Logs              []*rpcLog      `json:"logs"`
+}
+
+// rpcLog is an event log in the node's JSON encoding
+type rpcLog struct {
+	Address          string         `json:"address"`
+	Topics           []string       `json:"topics"`
+	Data             string         `json:"data"`
+	BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+	BlockHash        string         `json:"blockHash"`
+	TransactionHash  string         `json:"transactionHash"`
+	TransactionIndex hexutil.Uint64 `json:"transactionIndex"`
+	LogIndex         hexutil.Uint64 `json:"logIndex"`
+	Removed          bool           `json:"removed"`
+}
+
+// decodeBlock decodes an eth_getBlockBy* result, nil when the node returned null
+func decodeBlock(result json.RawMessage) (*domain.Block, error) {
+	if isNull(result) {
+		return nil, nil
+	}
+
+	var raw rpcBlock
+	if err := json.Unmarshal(result, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse block: %w", err)
+	}
+
+	block := &domain.Block{
+		Number:        optionalUint64(raw.Number),
+		Hash:          optionalString(raw.Hash),
+		ParentHash:    raw.ParentHash,
+		Timestamp:     time.Unix(int64(raw.Timestamp), 0).UTC(),
+		Miner:         raw.Miner,
+		GasLimit:      uint64(raw.GasLimit),
+		GasUsed:       uint64(raw.GasUsed),
+		BaseFeePerGas: optionalBig(raw.BaseFeePerGas),
+		Size:          uint64(raw.Size),
+		ExtraData:     raw.ExtraData,
+	}
+
+	if isNull(raw.Transactions) {
+		block.TransactionHashes = []string{}
+		return block, nil
+	}
+
+	// Hashes unless the block was fetched with full transactions
+	if err := json.Unmarshal(raw.Transactions, &block.TransactionHashes); err == nil {
+		return block, nil
+	}
+	var transactions []*rpcTransaction
+	if err := json.Unmarshal(raw.Transactions, &transactions); err != nil {
+		return nil, fmt.Errorf("failed to parse block transactions: %w", err)
+	}
+	block.TransactionHashes = make([]string, len(transactions))
+	block.Transactions = make([]*domain.ChainTransaction, len(transactions))
+	for i, tx := range transactions {
+		block.TransactionHashes[i] = tx.Hash
+		block.Transactions[i] = tx.toDomain()
+	}
+	return block, nil
+}
+
+// decodeTransaction decodes an eth_getTransactionBy* result, nil when the node returned null
+func decodeTransaction(result json.RawMessage) (*domain.ChainTransaction, error) {
+	if isNull(result) {
+		return nil, nil
+	}
+
+	var raw rpcTransaction
+	if err := json.Unmarshal(result, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse transaction: %w", err)
+	}
+	return raw.toDomain(), nil
+}
+
+// decodeReceipt decodes an eth_getTransactionReceipt result, nil when the node returned null
+func decodeReceipt(result json.RawMessage) (*domain.Receipt, error) {
+	if isNull(result) {
+		return nil, nil
+	}
+
+	var raw rpcReceipt
+	if err := json.Unmarshal(result, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse receipt: %w", err)
+	}
+
+	return &domain.Receipt{
+		TransactionHash:   raw.TransactionHash,
+		TransactionIndex:  uint64(raw.TransactionIndex),
+		BlockHash:         raw.BlockHash,
+		BlockNumber:       uint64(raw.BlockNumber),
+		From:              raw.From,
+		To:                optionalString(raw.To),
+		ContractAddress:   optionalString(raw.ContractAddress),
+		Status:            uint64(raw.Status),
+		Type:              uint64(raw.Type),
+		GasUsed:           uint64(raw.GasUsed),
+		CumulativeGasUsed: uint64(raw.CumulativeGasUsed),
+		EffectiveGasPrice: optionalBig(raw.EffectiveGasPrice),
+		Logs:              logsToDomain(raw.Logs),
+	}, nil
+}
+
+// decodeLogs decodes an eth_getLogs result
+func decodeLogs(result json.RawMessage) ([]*domain.EventLog, error) {
+	var raw []*rpcLog
+	if err := json.Unmarshal(result, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse logs: %w", err)
+	}
+	return logsToDomain(raw), nil
+}
+
+// toDomain converts a transaction to its domain model
+func (t *rpcTransaction) toDomain() *domain.ChainTransaction {
+	return &domain.ChainTransaction{
+		Hash:                 t.Hash,
+		Type:                 uint64(t.Type),
+		Nonce:                uint64(t.Nonce),
+		From:                 t.From,
+		To:                   optionalString(t.To),
+		Value:                optionalBig(t.Value),
+		Gas:                  uint64(t.Gas),
+		GasPrice:             optionalBig(t.GasPrice),
+		MaxFeePerGas:         optionalBig(t.MaxFeePerGas),
+		MaxPriorityFeePerGas: optionalBig(t.MaxPriorityFeePerGas),
+		Input:                t.Input,
+		ChainID:              optionalBig(t.ChainID),
+		BlockHash:            optionalString(t.BlockHash),
+		BlockNumber:          optionalUint64(t.BlockNumber),
+		TransactionIndex:     optionalUint64(t.TransactionIndex),
+	}
+}
+
+// logsToDomain converts event logs to their domain model
+func logsToDomain(raw []*rpcLog) []*domain.EventLog {
+	logs := make([]*domain.EventLog, 0, len(raw))
+	for _, log := range raw {
+		if log == nil {
+			continue
+		}
+		logs = append(logs, &domain.EventLog{
+			Address:          log.Address,
+			Topics:           log.Topics,
+			Data:             log.Data,
+			BlockNumber:      uint64(log.BlockNumber),
+			BlockHash:        log.BlockHash,
+			TransactionHash:  log.TransactionHash,
+			TransactionIndex: uint64(log.TransactionIndex),
+			LogIndex:         uint64(log.LogIndex),
+			Removed:          log.Removed,
+		})
+	}
+	return logs
+}
+
+// isNull reports whether a JSON value is empty or null
+func isNull(value json.RawMessage) bool {
+	return len(value) == 0 || string(value) == "null"
+}
+
+// optionalUint64 converts a nullable quantity
+func optionalUint64(value *hexutil.Uint64) *uint64 {
+	if value == nil {
+		return nil
+	}
+	number := uint64(*value)
+	return &number
+}
+
+// optionalBig converts a nullable big quantity
+func optionalBig(value *hexutil.Big) *big.Int {
+	if value == nil {
+		return nil
+	}
+	return value.ToInt()
+}
+
+// optionalString maps null to an empty string
+func optionalString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
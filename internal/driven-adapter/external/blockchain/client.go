@@ -2,17 +2,30 @@ package blockchain
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sync/atomic"
+	"time"
 
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"kokka.com/kokka/internal/core/domain"
 	"kokka.com/kokka/internal/shared/http_client"
+	"kokka.com/kokka/internal/shared/logger"
+	"kokka.com/kokka/internal/shared/metrics"
+	"kokka.com/kokka/internal/shared/tracing"
 )
 
 // Client is a JSON-RPC client for blockchain interactions
 type Client struct {
 	httpClient *http_client.Client
 	config     *Config
+	retry      http_client.RetryConfig // Also paces the retries of eth_sendRawTransaction
 	requestID  int64
+	endpoint   string // Metrics label of the RPC URL
+	cache      *Cache // nil when reads are not cached
+
+	traceUnavailable atomic.Bool // Set once the node rejected debug_traceCall
 }
 
 // NewClient creates a new blockchain JSON-RPC client
@@ -22,22 +35,88 @@ func NewClient(config *Config) *Client {
 	}
 
 	// Create HTTP client with blockchain-specific configuration
+	retry := config.retryConfig()
 	httpClient := http_client.NewClient(
 		http_client.WithBaseURL(config.BaseURL),
 		http_client.WithTimeout(config.Timeout),
-		http_client.WithRetry(config.MaxRetries, config.RetryDelay, 500, 502, 503, 504),
+		http_client.WithRetry(retry),
 		http_client.WithHeader("Content-Type", "application/json"),
 	)
 
-	return &Client{
+	client := &Client{
 		httpClient: httpClient,
 		config:     config,
+		retry:      retry,
 		requestID:  0,
+		endpoint:   metrics.EndpointLabel(config.BaseURL),
+	}
+	if config.Cache != nil {
+		client.cache = NewCache(*config.Cache)
 	}
+	return client
+}
+
+// Endpoint returns the RPC URL the client sends requests to
+func (c *Client) Endpoint() string {
+	return c.config.BaseURL
+}
+
+// Cache returns the read cache, or nil when reads are not cached
+func (c *Client) Cache() *Cache {
+	return c.cache
 }
 
-// Call executes a JSON-RPC method call
+// Call executes a JSON-RPC method call, served from the read cache when possible
 func (c *Client) Call(ctx context.Context, method string, params interface{}) (*JSONRPCResponse, error) {
+	if c.cache != nil {
+		return c.cache.Call(ctx, method, params, c.invoke)
+	}
+	return c.invoke(ctx, method, params)
+}
+
+// invoke executes a JSON-RPC method call against the node
+func (c *Client) invoke(ctx context.Context, method string, params interface{}) (*JSONRPCResponse, error) {
+	ctx, span := tracing.StartKind(ctx, "rpc "+method, trace.SpanKindClient,
+		semconv.RPCSystemKey.String("jsonrpc"),
+		semconv.RPCMethod(method),
+		semconv.ServerAddress(c.endpoint),
+	)
+	defer span.End()
+	if txHash := paramTxHash(method, params); txHash != "" {
+		span.SetAttributes(tracing.AttrTxHash.String(txHash))
+		logger.GetLogger(ctx).SetTxHash(txHash)
+	}
+
+	started := time.Now()
+	resp, errorKind, err := c.call(ctx, method, params)
+	metrics.ObserveRPCCall(method, c.endpoint, time.Since(started), errorKind)
+
+	if err != nil {
+		tracing.Fail(ctx, err)
+	} else if method == "eth_sendRawTransaction" {
+		var txHash string
+		if resp.UnmarshalResult(&txHash) == nil {
+			span.SetAttributes(tracing.AttrTxHash.String(txHash))
+			logger.GetLogger(ctx).SetTxHash(txHash)
+		}
+	}
+	return resp, err
+}
+
+// paramTxHash returns the transaction hash a method is called with, if any
+func paramTxHash(method string, params interface{}) string {
+	switch method {
+	case "eth_getTransactionByHash", "eth_getTransactionReceipt":
+		if values, ok := params.([]interface{}); ok && len(values) > 0 {
+			txHash, _ := values[0].(string)
+			return txHash
+		}
+	}
+	return ""
+}
+
+// call executes a JSON-RPC method call; errorKind classifies a failure for metrics
+func (c *Client) call(ctx context.Context, method string, params interface{}) (*JSONRPCResponse, string, error) {
 	// Build JSON-RPC request
 	request := JSONRPCRequest{
 		ID:      atomic.AddInt64(&c.requestID, 1),
@@ -46,29 +125,133 @@ func (c *Client) Call(ctx context.Context, method string, params interface{}) (*
 		Params:  params,
 	}
 
+	// Sends are never repeated blindly, raw transactions are after checking the node does not have them
+	switch {
+	case method == "eth_sendRawTransaction":
+		return c.sendRawTransaction(ctx, request)
+	case nonIdempotentMethods[method]:
+		return c.post(ctx, request, http_client.WithoutRetry())
+	}
+	return c.post(ctx, request)
+}
+
+// post executes a JSON-RPC request
+func (c *Client) post(ctx context.Context, request JSONRPCRequest, opts ...http_client.RequestOption) (*JSONRPCResponse, string, error) {
 	// Execute HTTP POST request
-	resp, err := c.httpClient.Post(ctx, "", request)
+	resp, err := c.httpClient.Post(ctx, "", request, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute JSON-RPC request: %w", err)
+		return nil, "transport", domain.NewError(domain.ErrorCodeRPCUnavailable, fmt.Sprintf("failed to execute JSON-RPC request: %v", err), err)
 	}
 
 	// Check HTTP status
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("JSON-RPC request failed with status %d: %s", resp.StatusCode, resp.String())
+		return nil, "http", httpError(resp.StatusCode, resp.String())
 	}
 
 	// Parse JSON-RPC response
 	var jsonRPCResp JSONRPCResponse
 	if err := resp.JSON(&jsonRPCResp); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON-RPC response: %w", err)
+		return nil, "http", fmt.Errorf("failed to parse JSON-RPC response: %w", err)
 	}
 
 	// Check for JSON-RPC errors
 	if jsonRPCResp.IsError() {
-		return &jsonRPCResp, fmt.Errorf("JSON-RPC error %d: %s", jsonRPCResp.Error.Code, jsonRPCResp.Error.Message)
+		return &jsonRPCResp, "rpc", rpcError(jsonRPCResp.Error)
 	}
 
-	return &jsonRPCResp, nil
+	return &jsonRPCResp, "", nil
+}
+
+// BatchCall is one method call of a JSON-RPC batch
+type BatchCall struct {
+	Method string
+	Params interface{}
+}
+
+// CallBatch executes method calls as one JSON-RPC batch request
+// Responses are returned in call order; calls the node failed have their Error set instead of failing the batch
+func (c *Client) CallBatch(ctx context.Context, calls []BatchCall) ([]*JSONRPCResponse, error) {
+	ctx, span := tracing.StartKind(ctx, "rpc batch", trace.SpanKindClient,
+		semconv.RPCSystemKey.String("jsonrpc"),
+		semconv.ServerAddress(c.endpoint),
+	)
+	defer span.End()
+
+	requests := make([]JSONRPCRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = JSONRPCRequest{
+			ID:      atomic.AddInt64(&c.requestID, 1),
+			JsonRPC: "2.0",
+			Method:  call.Method,
+			Params:  call.Params,
+		}
+	}
+
+	started := time.Now()
+	responses, errorKind, err := c.callBatch(ctx, requests)
+	elapsed := time.Since(started)
+	for i, call := range calls {
+		kind := errorKind
+		if err == nil && responses[i].IsError() {
+			kind = "rpc"
+		}
+		metrics.ObserveRPCCall(call.Method, c.endpoint, elapsed, kind)
+	}
+
+	if err != nil {
+		tracing.Fail(ctx, err)
+	}
+	return responses, err
+}
+
+// callBatch posts a batch and matches the responses to the requests by ID
+// Batches with a send are not retried, as the whole batch would be repeated
+func (c *Client) callBatch(ctx context.Context, requests []JSONRPCRequest) ([]*JSONRPCResponse, string, error) {
+	var opts []http_client.RequestOption
+	for _, request := range requests {
+		if request.Method == "eth_sendRawTransaction" || nonIdempotentMethods[request.Method] {
+			opts = append(opts, http_client.WithoutRetry())
+			break
+		}
+	}
+
+	resp, err := c.httpClient.Post(ctx, "", requests, opts...)
+	if err != nil {
+		return nil, "transport", domain.NewError(domain.ErrorCodeRPCUnavailable, fmt.Sprintf("failed to execute JSON-RPC batch: %v", err), err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, "http", httpError(resp.StatusCode, resp.String())
+	}
+
+	// Nodes without batch support answer with a single error object
+	var batch []JSONRPCResponse
+	if err := resp.JSON(&batch); err != nil {
+		var single JSONRPCResponse
+		if resp.JSON(&single) == nil && single.IsError() {
+			return nil, "rpc", rpcError(single.Error)
+		}
+		return nil, "http", fmt.Errorf("failed to parse JSON-RPC batch response: %w", err)
+	}
+
+	byID := make(map[int64]*JSONRPCResponse, len(batch))
+	for i := range batch {
+		byID[batch[i].ID] = &batch[i]
+	}
+
+	responses := make([]*JSONRPCResponse, len(requests))
+	for i, request := range requests {
+		response := byID[request.ID]
+		if response == nil {
+			response = &JSONRPCResponse{
+				JsonRPC: "2.0",
+				ID:      request.ID,
+				Error:   &JSONRPCError{Code: -32603, Message: "no response to the call in the batch"},
+			}
+		}
+		responses[i] = response
+	}
+	return responses, "", nil
 }
 
 // GetBlockNumber returns the current block number
@@ -132,26 +315,60 @@ func (c *Client) GetBalance(ctx context.Context, address string, block string) (
 	return result, nil
 }
 
-// GetTransactionByHash returns transaction details by hash
-func (c *Client) GetTransactionByHash(ctx context.Context, txHash string) (*JSONRPCResponse, error) {
+// GetTransactionByHash returns a transaction by hash, or nil if the node does not know it
+func (c *Client) GetTransactionByHash(ctx context.Context, txHash string) (*domain.ChainTransaction, error) {
 	params := []interface{}{txHash}
 	resp, err := c.Call(ctx, "eth_getTransactionByHash", params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transaction: %w", err)
 	}
 
-	return resp, nil
+	return decodeTransaction(resp.Result)
+}
+
+// GetTransactionReceipt returns the receipt of a mined transaction
+// The receipt is nil while the transaction is pending or unknown
+func (c *Client) GetTransactionReceipt(ctx context.Context, txHash string) (*domain.Receipt, error) {
+	params := []interface{}{txHash}
+	resp, err := c.Call(ctx, "eth_getTransactionReceipt", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction receipt: %w", err)
+	}
+
+	return decodeReceipt(resp.Result)
 }
 
-// GetBlockByNumber returns block details by number
-func (c *Client) GetBlockByNumber(ctx context.Context, blockNumber string, fullTx bool) (*JSONRPCResponse, error) {
+// GetLogs returns the logs of the given contracts and topics in a block range
+func (c *Client) GetLogs(ctx context.Context, fromBlock string, toBlock string, addresses []string, topics []interface{}) ([]*domain.EventLog, error) {
+	filter := map[string]interface{}{
+		"fromBlock": fromBlock,
+		"toBlock":   toBlock,
+	}
+	if len(addresses) > 0 {
+		filter["address"] = addresses
+	}
+	if len(topics) > 0 {
+		filter["topics"] = topics
+	}
+
+	resp, err := c.Call(ctx, "eth_getLogs", []interface{}{filter})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logs: %w", err)
+	}
+
+	return decodeLogs(resp.Result)
+}
+
+// GetBlockByNumber returns a block by number or tag, or nil if the node does not have it
+// With fullTx the block carries its transactions, otherwise only their hashes
+func (c *Client) GetBlockByNumber(ctx context.Context, blockNumber string, fullTx bool) (*domain.Block, error) {
 	params := []interface{}{blockNumber, fullTx}
 	resp, err := c.Call(ctx, "eth_getBlockByNumber", params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get block: %w", err)
 	}
 
-	return resp, nil
+	return decodeBlock(resp.Result)
 }
 
 // CallContract calls a contract method (read-only)
@@ -180,6 +397,59 @@ func (c *Client) CallContract(ctx context.Context, to string, data string, block
 	return result, nil
 }
 
+// CallFrom executes a read-only call as if sent by from, with an optional value
+// Used to simulate a write before signing it
+func (c *Client) CallFrom(ctx context.Context, from, to, value, data, block string) (string, error) {
+	resp, err := c.Call(ctx, "eth_call", []interface{}{callObject(from, to, value, data), block})
+	if err != nil {
+		return "", fmt.Errorf("failed to call contract: %w", err)
+	}
+
+	result, err := resp.GetResultAsString()
+	if err != nil {
+		return "", fmt.Errorf("failed to parse contract call result: %w", err)
+	}
+
+	return result, nil
+}
+
+// TraceCall runs debug_traceCall with the call tracer, including emitted logs
+// Not every node exposes the debug namespace; callers should treat errors as "trace unavailable"
+func (c *Client) TraceCall(ctx context.Context, from, to, value, data, block string) (*CallFrame, error) {
+	tracerConfig := map[string]interface{}{
+		"tracer":       "callTracer",
+		"tracerConfig": map[string]interface{}{"withLog": true},
+	}
+	resp, err := c.Call(ctx, "debug_traceCall", []interface{}{callObject(from, to, value, data), block, tracerConfig})
+	if err != nil {
+		return nil, fmt.Errorf("failed to trace call: %w", err)
+	}
+
+	var frame CallFrame
+	if err := json.Unmarshal(resp.Result, &frame); err != nil {
+		return nil, fmt.Errorf("failed to parse call trace: %w", err)
+	}
+
+	return &frame, nil
+}
+
+// callObject builds the transaction object of eth_call and friends, leaving out empty fields
+func callObject(from, to, value, data string) map[string]interface{} {
+	object := map[string]interface{}{
+		"to": to,
+	}
+	if from != "" {
+		object["from"] = from
+	}
+	if value != "" && value != "0x0" && value != "0x" {
+		object["value"] = value
+	}
+	if data != "" && data != "0x" {
+		object["data"] = data
+	}
+	return object
+}
+
 // EstimateGasWithData estimates gas for a transaction with contract data
 func (c *Client) EstimateGas(ctx context.Context, from, to, value, data string) (string, error) {
 	txObject := map[string]interface{}{
@@ -1,14 +1,21 @@
 package blockchain
 
-import "time"
+import (
+	"time"
+
+	"kokka.com/kokka/internal/shared/http_client"
+)
 
 // Config holds the configuration for the blockchain client
 type Config struct {
 	BaseURL        string
 	Timeout        time.Duration
 	MaxRetries     int
-	RetryDelay     time.Duration
+	RetryDelay     time.Duration // Delay before the first retry, doubled for each further retry
+	RetryMaxDelay  time.Duration // Cap of the backoff and of a Retry-After the node asks for
+	RetryJitter    float64       // Fraction of each delay that is randomised
 	EnableLogging  bool
+	Cache          *CacheConfig // nil disables the read cache
 }
 
 // DefaultConfig returns a default configuration
@@ -17,7 +24,9 @@ func DefaultConfig() *Config {
 		BaseURL:        "https://x24.i247.com",
 		Timeout:        30 * time.Second,
 		MaxRetries:     3,
-		RetryDelay:     1 * time.Second,
+		RetryDelay:     250 * time.Millisecond,
+		RetryMaxDelay:  5 * time.Second,
+		RetryJitter:    0.5,
 		EnableLogging:  true,
 	}
 }
@@ -35,9 +44,27 @@ func (c *Config) WithTimeout(timeout time.Duration) *Config {
 }
 
 // WithRetry sets retry configuration
-func (c *Config) WithRetry(maxRetries int, retryDelay time.Duration) *Config {
+func (c *Config) WithRetry(maxRetries int, retryDelay time.Duration, retryMaxDelay time.Duration) *Config {
 	c.MaxRetries = maxRetries
 	c.RetryDelay = retryDelay
+	c.RetryMaxDelay = retryMaxDelay
+	return c
+}
+
+// retryConfig returns the retry behavior of requests to the node
+func (c *Config) retryConfig() http_client.RetryConfig {
+	return http_client.RetryConfig{
+		MaxRetries:  c.MaxRetries,
+		BaseDelay:   c.RetryDelay,
+		MaxDelay:    c.RetryMaxDelay,
+		Jitter:      c.RetryJitter,
+		RetryReason: retryReason,
+	}
+}
+
+// WithCache enables the read cache
+func (c *Config) WithCache(cache CacheConfig) *Config {
+	c.Cache = &cache
 	return c
 }
 
@@ -0,0 +1,84 @@
+package blockchain
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"kokka.com/kokka/internal/core/domain"
+)
+
+// revertPrefix starts the message of geth-compatible nodes for reverted calls
+const revertPrefix = "execution reverted"
+
+// Node error messages by domain error code, matched case-insensitively as substrings
+var rpcErrorPatterns = []struct {
+	code     domain.ErrorCode
+	patterns []string
+}{
+	{domain.ErrorCodeInsufficientAllowance, []string{"insufficient allowance", "exceeds allowance", "erc20insufficientallowance"}},
+	{domain.ErrorCodeInsufficientBalance, []string{"insufficient funds", "insufficient balance", "exceeds balance", "erc20insufficientbalance"}},
+	{domain.ErrorCodeNonceConflict, []string{"nonce too low", "nonce too high", "replacement transaction underpriced"}},
+	{domain.ErrorCodeRPCUnavailable, []string{"rate limit", "too many requests", "header not found", "service unavailable"}},
+}
+
+// rpcCodeLimitExceeded is the JSON-RPC error code of nodes and providers rejecting a call over their rate limit
+const rpcCodeLimitExceeded = -32005
+
+// rpcError turns a JSON-RPC error object into a typed domain error, or a plain error when unrecognised
+// Revert data is decoded into the reason, which is appended to the message when the node left it out
+func rpcError(rpcErr *JSONRPCError) error {
+	message := fmt.Sprintf("JSON-RPC error %d: %s", rpcErr.Code, rpcErr.Message)
+	lower := strings.ToLower(rpcErr.Message)
+
+	reason := ""
+	reverted := rpcErr.Code == 3 || strings.HasPrefix(lower, revertPrefix)
+	if reverted {
+		reason = rpcErr.Message
+		if strings.HasPrefix(lower, revertPrefix) {
+			reason = strings.TrimSpace(strings.TrimPrefix(rpcErr.Message[len(revertPrefix):], ":"))
+		}
+	}
+
+	revert := decodeRevert(revertData(rpcErr.Data))
+	if revert != nil {
+		reverted = true
+		if !strings.Contains(rpcErr.Message, revert.Reason) {
+			message += ": " + revert.Reason
+		}
+		reason = revert.Reason
+		lower += " " + strings.ToLower(revert.Error)
+	}
+
+	if rpcErr.Code == rpcCodeLimitExceeded {
+		return domain.NewError(domain.ErrorCodeRPCUnavailable, message, nil)
+	}
+
+	for _, group := range rpcErrorPatterns {
+		for _, pattern := range group.patterns {
+			if strings.Contains(lower, pattern) {
+				err := domain.NewError(group.code, message, nil)
+				err.Reason = reason
+				err.Revert = revert
+				return err
+			}
+		}
+	}
+
+	if reverted {
+		err := domain.NewError(domain.ErrorCodeExecutionReverted, message, nil)
+		err.Reason = reason
+		err.Revert = revert
+		return err
+	}
+	return fmt.Errorf("%s", message)
+}
+
+// httpError classifies a non-2xx response of the RPC endpoint
+func httpError(statusCode int, body string) error {
+	message := fmt.Sprintf("JSON-RPC request failed with status %d: %s", statusCode, body)
+	if statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError {
+		return domain.NewError(domain.ErrorCodeRPCUnavailable, message, nil)
+	}
+	return fmt.Errorf("%s", message)
+}
@@ -0,0 +1,143 @@
+package blockchain
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// hardenedOffset marks a hardened BIP-32 child index
+const hardenedOffset uint32 = 0x80000000
+
+// DepositPathPrefix is the BIP-44 prefix for Ethereum deposit addresses (m/44'/60'/0'/0)
+const DepositPathPrefix = "m/44'/60'/0'/0"
+
+// MaxDerivationIndex is the largest non-hardened child index
+const MaxDerivationIndex = hardenedOffset - 1
+
+// HDWallet derives Ethereum keys from a BIP-39 mnemonic along m/44'/60'/0'/0/{index}
+type HDWallet struct {
+	external extendedKey // Extended key at m/44'/60'/0'/0
+}
+
+// extendedKey is a BIP-32 private extended key
+type extendedKey struct {
+	key       []byte // 32-byte private key
+	chainCode []byte // 32-byte chain code
+}
+
+// NewHDWallet creates an HD wallet from a BIP-39 mnemonic and optional passphrase
+func NewHDWallet(mnemonic string, passphrase string) (*HDWallet, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errors.New("invalid mnemonic")
+	}
+
+	seed := bip39.NewSeed(mnemonic, passphrase)
+	master, err := newMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	// m/44'/60'/0'/0
+	key := master
+	for _, index := range []uint32{44 + hardenedOffset, 60 + hardenedOffset, 0 + hardenedOffset, 0} {
+		key, err = key.child(index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &HDWallet{external: key}, nil
+}
+
+// NewMnemonic generates a new 24-word BIP-39 mnemonic
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// DerivationPath returns the BIP-44 path of a deposit address index
+func DerivationPath(index uint32) string {
+	return fmt.Sprintf("%s/%d", DepositPathPrefix, index)
+}
+
+// DeriveKey returns the private key at m/44'/60'/0'/0/{index}
+func (w *HDWallet) DeriveKey(index uint32) (*ecdsa.PrivateKey, error) {
+	if index > MaxDerivationIndex {
+		return nil, fmt.Errorf("derivation index %d out of range", index)
+	}
+
+	child, err := w.external.child(index)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.ToECDSA(child.key)
+}
+
+// DeriveAddress returns the address at m/44'/60'/0'/0/{index}
+func (w *HDWallet) DeriveAddress(index uint32) (common.Address, error) {
+	key, err := w.DeriveKey(index)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(key.PublicKey), nil
+}
+
+// newMasterKey derives the BIP-32 master key from a seed
+func newMasterKey(seed []byte) (extendedKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key := extendedKey{key: sum[:32], chainCode: sum[32:]}
+	k := new(big.Int).SetBytes(key.key)
+	if k.Sign() == 0 || k.Cmp(crypto.S256().Params().N) >= 0 {
+		return extendedKey{}, errors.New("invalid master key")
+	}
+	return key, nil
+}
+
+// child derives a private child key (BIP-32 CKDpriv)
+func (k extendedKey) child(index uint32) (extendedKey, error) {
+	var data []byte
+	if index >= hardenedOffset {
+		data = append([]byte{0x00}, k.key...)
+	} else {
+		privateKey, err := crypto.ToECDSA(k.key)
+		if err != nil {
+			return extendedKey{}, err
+		}
+		data = crypto.CompressPubkey(&privateKey.PublicKey)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	// Child key is (IL + parent key) mod N; IL >= N or a zero key means the index is unusable
+	curveN := crypto.S256().Params().N
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(curveN) >= 0 {
+		return extendedKey{}, fmt.Errorf("invalid child key at index %d", index)
+	}
+	childKey := il.Add(il, new(big.Int).SetBytes(k.key))
+	childKey.Mod(childKey, curveN)
+	if childKey.Sign() == 0 {
+		return extendedKey{}, fmt.Errorf("invalid child key at index %d", index)
+	}
+
+	return extendedKey{key: common.LeftPadBytes(childKey.Bytes(), 32), chainCode: sum[32:]}, nil
+}
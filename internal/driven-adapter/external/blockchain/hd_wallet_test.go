@@ -0,0 +1,56 @@
+package blockchain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testMnemonic is the BIP-39 test mnemonic used by most wallets to check derivation
+var testMnemonic = strings.Repeat("abandon ", 11) + "about"
+
+func TestHDWalletDeriveAddress(t *testing.T) {
+	wallet, err := NewHDWallet(testMnemonic, "")
+	if err != nil {
+		t.Fatalf("NewHDWallet: %v", err)
+	}
+
+	// m/44'/60'/0'/0/i, the addresses other BIP-44 wallets derive from the same mnemonic
+	want := []string{
+		"0x9858EfFD232B4033E47d90003D41EC34EcaEda94",
+		"0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0",
+		"0xb6716976A3ebe8D39aCEB04372f22Ff8e6802D7A",
+	}
+	for index, address := range want {
+		got, err := wallet.DeriveAddress(uint32(index))
+		if err != nil {
+			t.Fatalf("DeriveAddress(%d): %v", index, err)
+		}
+		if got.Hex() != address {
+			t.Errorf("DeriveAddress(%d) = %s, want %s", index, got.Hex(), address)
+		}
+
+		key, err := wallet.DeriveKey(uint32(index))
+		if err != nil {
+			t.Fatalf("DeriveKey(%d): %v", index, err)
+		}
+		if crypto.PubkeyToAddress(key.PublicKey).Hex() != address {
+			t.Errorf("DeriveKey(%d) does not match %s", index, address)
+		}
+	}
+}
+
+func TestDerivationPath(t *testing.T) {
+	if got := DerivationPath(7); got != "m/44'/60'/0'/0/7" {
+		t.Errorf("DerivationPath(7) = %s", got)
+	}
+}
+
+func TestNewHDWalletInvalidMnemonic(t *testing.T) {
+	for _, mnemonic := range []string{"", "abandon abandon abandon", strings.Repeat("abandon ", 12)} {
+		if _, err := NewHDWallet(mnemonic, ""); err == nil {
+			t.Errorf("NewHDWallet(%q) succeeded", mnemonic)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// KeystoreSigner signs with a go-ethereum keystore JSON file
+// The key is decrypted for each signing operation and wiped afterwards
+type KeystoreSigner struct {
+	keyJSON    []byte
+	passphrase string
+	address    common.Address
+}
+
+// NewKeystoreSigner loads the keystore file name from dir and checks the passphrase unlocks it
+// The file is read through an os.Root, so neither "..", an absolute name nor a symlink can leave dir
+func NewKeystoreSigner(dir string, name string, passphrase string) (*KeystoreSigner, error) {
+	if !filepath.IsLocal(name) {
+		return nil, fmt.Errorf("keystore file %s is not inside the keystore directory", name)
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open keystore directory: %w", err)
+	}
+	defer root.Close()
+
+	keyJSON, err := root.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore file: %w", err)
+	}
+
+	var header struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(keyJSON, &header); err != nil {
+		return nil, fmt.Errorf("failed to parse keystore file: %w", err)
+	}
+
+	signer := &KeystoreSigner{
+		keyJSON:    keyJSON,
+		passphrase: passphrase,
+		address:    common.HexToAddress(header.Address),
+	}
+
+	// Verify the passphrase (and the address header) up front
+	key, err := signer.decrypt()
+	if err != nil {
+		return nil, err
+	}
+	signer.address = key.Address
+	wipeKey(key)
+
+	return signer, nil
+}
+
+// Address returns the signer's address
+func (s *KeystoreSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx signs a transaction with EIP-155 replay protection
+func (s *KeystoreSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	key, err := s.decrypt()
+	if err != nil {
+		return nil, err
+	}
+	defer wipeKey(key)
+
+	return types.SignTx(tx, types.NewEIP155Signer(chainID), key.PrivateKey)
+}
+
+// SignTypedData signs EIP-712 typed data
+func (s *KeystoreSigner) SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash typed data: %w", err)
+	}
+
+	key, err := s.decrypt()
+	if err != nil {
+		return nil, err
+	}
+	defer wipeKey(key)
+
+	return signHash(hash, key.PrivateKey)
+}
+
+// SignMessage signs an EIP-191 personal message
+func (s *KeystoreSigner) SignMessage(ctx context.Context, message []byte) ([]byte, error) {
+	key, err := s.decrypt()
+	if err != nil {
+		return nil, err
+	}
+	defer wipeKey(key)
+
+	hash := MessageHash(message)
+	return signHash(hash[:], key.PrivateKey)
+}
+
+// decrypt unlocks the keystore key
+func (s *KeystoreSigner) decrypt() (*keystore.Key, error) {
+	key, err := keystore.DecryptKey(s.keyJSON, s.passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore: %w", err)
+	}
+	return key, nil
+}
+
+// wipeKey zeroes the private key scalar
+func wipeKey(key *keystore.Key) {
+	if key == nil || key.PrivateKey == nil {
+		return
+	}
+	b := key.PrivateKey.D.Bits()
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,69 @@
+package blockchain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// LocalSigner signs with a private key held in process memory
+type LocalSigner struct {
+	privateKey *ecdsa.PrivateKey
+	address    common.Address
+}
+
+// NewLocalSigner creates a signer from a hex-encoded private key
+func NewLocalSigner(privateKeyHex string) (*LocalSigner, error) {
+	// Parse private key (0x prefix is optional)
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	return &LocalSigner{
+		privateKey: privateKey,
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+	}, nil
+}
+
+// Address returns the signer's address
+func (s *LocalSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx signs a transaction with EIP-155 replay protection
+func (s *LocalSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.NewEIP155Signer(chainID), s.privateKey)
+}
+
+// SignTypedData signs EIP-712 typed data
+func (s *LocalSigner) SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash typed data: %w", err)
+	}
+	return signHash(hash, s.privateKey)
+}
+
+// SignMessage signs an EIP-191 personal message
+func (s *LocalSigner) SignMessage(ctx context.Context, message []byte) ([]byte, error) {
+	hash := MessageHash(message)
+	return signHash(hash[:], s.privateKey)
+}
+
+// signHash signs a 32-byte hash and returns the signature with V in {27, 28}
+func signHash(hash []byte, privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	signature, err := crypto.Sign(hash, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
@@ -0,0 +1,271 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Remote signer wire format, shared with the kokka-signer daemon
+// ------------------------------------------------------------
+
+// RemoteAccountsResponse lists the accounts a remote signer holds
+type RemoteAccountsResponse struct {
+	Accounts []string `json:"accounts"`
+}
+
+// RemoteSignTxRequest asks the remote signer to sign a transaction
+type RemoteSignTxRequest struct {
+	Address string `json:"address"`
+	ChainID string `json:"chain_id"` // Decimal chain ID
+	Tx      string `json:"tx"`       // Hex-encoded unsigned transaction (binary encoding)
+}
+
+// RemoteSignTxResponse carries the signed transaction
+type RemoteSignTxResponse struct {
+	SignedTx string `json:"signed_tx"` // Hex-encoded signed transaction
+}
+
+// RemoteSignTypedDataRequest asks the remote signer to sign EIP-712 typed data
+type RemoteSignTypedDataRequest struct {
+	Address   string             `json:"address"`
+	TypedData apitypes.TypedData `json:"typed_data"`
+}
+
+// RemoteSignMessageRequest asks the remote signer to sign an EIP-191 personal message
+type RemoteSignMessageRequest struct {
+	Address string `json:"address"`
+	Message string `json:"message"` // Hex-encoded message bytes
+}
+
+// RemoteSignatureResponse carries a signature
+type RemoteSignatureResponse struct {
+	Signature string `json:"signature"` // Hex-encoded 65-byte signature
+}
+
+// RemoteErrorResponse is returned by the remote signer on failure
+type RemoteErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// RemoteSigner delegates signing to a kokka-signer process over HTTP or a Unix socket
+// It talks net/http directly so auth headers and payloads never go through the logging interceptors
+type RemoteSigner struct {
+	baseURL    string
+	authToken  string
+	address    common.Address
+	httpClient *http.Client
+}
+
+// NewRemoteSigner creates a signer for an account held by a remote signer
+// endpoint is either "unix:///path/to/socket" or an http(s) URL
+func NewRemoteSigner(endpoint string, authToken string, address string) (*RemoteSigner, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid signer address: %s", address)
+	}
+
+	baseURL, httpClient, err := newRemoteHTTPClient(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RemoteSigner{
+		baseURL:    baseURL,
+		authToken:  authToken,
+		address:    common.HexToAddress(address),
+		httpClient: httpClient,
+	}, nil
+}
+
+// Address returns the signer's address
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx asks the remote signer to sign a transaction
+func (s *RemoteSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	rawTx, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction: %w", err)
+	}
+
+	req := RemoteSignTxRequest{
+		Address: s.address.Hex(),
+		ChainID: chainID.String(),
+		Tx:      hexutil.Encode(rawTx),
+	}
+	var resp RemoteSignTxResponse
+	if err := s.post(ctx, "/v1/sign-tx", req, &resp); err != nil {
+		return nil, err
+	}
+
+	signedBytes, err := hexutil.Decode(resp.SignedTx)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer returned invalid transaction: %w", err)
+	}
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(signedBytes); err != nil {
+		return nil, fmt.Errorf("remote signer returned invalid transaction: %w", err)
+	}
+
+	// Make sure the remote signer signed what we asked for, with the expected key
+	if !sameTransaction(tx, signedTx) {
+		return nil, fmt.Errorf("remote signer returned a different transaction")
+	}
+	from, err := types.Sender(types.NewEIP155Signer(chainID), signedTx)
+	if err != nil || from != s.address {
+		return nil, fmt.Errorf("remote signer signed with an unexpected key")
+	}
+
+	return signedTx, nil
+}
+
+// SignTypedData asks the remote signer to sign EIP-712 typed data
+func (s *RemoteSigner) SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	req := RemoteSignTypedDataRequest{
+		Address:   s.address.Hex(),
+		TypedData: typedData,
+	}
+	var resp RemoteSignatureResponse
+	if err := s.post(ctx, "/v1/sign-typed-data", req, &resp); err != nil {
+		return nil, err
+	}
+
+	signature, err := hexutil.Decode(resp.Signature)
+	if err != nil || len(signature) != 65 {
+		return nil, fmt.Errorf("remote signer returned an invalid signature")
+	}
+
+	// Make sure the remote signer signed this typed data with the expected key
+	if signer, err := RecoverTypedDataSigner(typedData, signature); err != nil || signer != s.address {
+		return nil, fmt.Errorf("remote signer signed with an unexpected key")
+	}
+	return signature, nil
+}
+
+// SignMessage asks the remote signer to sign an EIP-191 personal message
+func (s *RemoteSigner) SignMessage(ctx context.Context, message []byte) ([]byte, error) {
+	req := RemoteSignMessageRequest{
+		Address: s.address.Hex(),
+		Message: hexutil.Encode(message),
+	}
+	var resp RemoteSignatureResponse
+	if err := s.post(ctx, "/v1/sign-message", req, &resp); err != nil {
+		return nil, err
+	}
+
+	signature, err := hexutil.Decode(resp.Signature)
+	if err != nil || len(signature) != 65 {
+		return nil, fmt.Errorf("remote signer returned an invalid signature")
+	}
+
+	// Make sure the remote signer signed with the expected key
+	if signer, err := RecoverMessageSigner(message, signature); err != nil || signer != s.address {
+		return nil, fmt.Errorf("remote signer signed with an unexpected key")
+	}
+	return signature, nil
+}
+
+// Accounts lists the addresses held by the remote signer
+func (s *RemoteSigner) Accounts(ctx context.Context) ([]string, error) {
+	var resp RemoteAccountsResponse
+	if err := s.do(ctx, http.MethodGet, "/v1/accounts", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Accounts, nil
+}
+
+// post sends a JSON request to the remote signer
+func (s *RemoteSigner) post(ctx context.Context, path string, body any, out any) error {
+	return s.do(ctx, http.MethodPost, path, body, out)
+}
+
+// do executes a request against the remote signer and decodes the response
+func (s *RemoteSigner) do(ctx context.Context, method string, path string, body any, out any) error {
+	var bodyReader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode remote signer request: %w", err)
+		}
+		bodyReader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, bodyReader)
+	if err != nil {
+		return fmt.Errorf("failed to create remote signer request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.authToken)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("remote signer request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read remote signer response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var errResp RemoteErrorResponse
+		if json.Unmarshal(respBody, &errResp) == nil && errResp.Error != "" {
+			return fmt.Errorf("remote signer refused: %s", errResp.Error)
+		}
+		return fmt.Errorf("remote signer returned status %d", resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to parse remote signer response: %w", err)
+	}
+	return nil
+}
+
+// sameTransaction reports whether two transactions carry the same payload (ignoring signatures)
+func sameTransaction(a, b *types.Transaction) bool {
+	if a.Nonce() != b.Nonce() || a.Gas() != b.Gas() || a.GasPrice().Cmp(b.GasPrice()) != 0 {
+		return false
+	}
+	if a.Value().Cmp(b.Value()) != 0 || !bytes.Equal(a.Data(), b.Data()) {
+		return false
+	}
+	if a.To() == nil || b.To() == nil {
+		return a.To() == nil && b.To() == nil
+	}
+	return *a.To() == *b.To()
+}
+
+// newRemoteHTTPClient builds an HTTP client for a unix:// or http(s):// endpoint
+func newRemoteHTTPClient(endpoint string) (string, *http.Client, error) {
+	if socketPath, ok := strings.CutPrefix(endpoint, "unix://"); ok {
+		dialer := &net.Dialer{Timeout: 5 * time.Second}
+		transport := &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return dialer.DialContext(ctx, "unix", socketPath)
+			},
+		}
+		return "http://kokka-signer", &http.Client{Transport: transport, Timeout: 30 * time.Second}, nil
+	}
+
+	if strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://") {
+		return strings.TrimSuffix(endpoint, "/"), &http.Client{Timeout: 30 * time.Second}, nil
+	}
+
+	return "", nil, fmt.Errorf("unsupported remote signer endpoint: %s", endpoint)
+}
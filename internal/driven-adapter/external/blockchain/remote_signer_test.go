@@ -0,0 +1,94 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const (
+	testRemoteKey = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	testOtherKey  = "8da4ef21b864d2cc526dbdb2a120bd2874c36c9d0a1fb7f8c63d7f7a8b41de8f"
+)
+
+func testTypedData(contents string) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {{Name: "name", Type: "string"}, {Name: "chainId", Type: "uint256"}},
+			"Mail":         {{Name: "contents", Type: "string"}},
+		},
+		PrimaryType: "Mail",
+		Domain:      apitypes.TypedDataDomain{Name: "kokka", ChainId: math.NewHexOrDecimal256(31337)},
+		Message:     apitypes.TypedDataMessage{"contents": contents},
+	}
+}
+
+// newTestRemoteSigner serves /v1/sign-typed-data by signing sign(request) with key
+func newTestRemoteSigner(t *testing.T, key string, sign func(apitypes.TypedData) apitypes.TypedData) *RemoteSigner {
+	t.Helper()
+	local, err := NewLocalSigner(key)
+	if err != nil {
+		t.Fatalf("NewLocalSigner: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req RemoteSignTypedDataRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		signature, err := local.SignTypedData(r.Context(), sign(req.TypedData))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(RemoteSignatureResponse{Signature: hexutil.Encode(signature)})
+	}))
+	t.Cleanup(server.Close)
+
+	expected, err := NewLocalSigner(testRemoteKey)
+	if err != nil {
+		t.Fatalf("NewLocalSigner: %v", err)
+	}
+	signer, err := NewRemoteSigner(server.URL, "token", expected.Address().Hex())
+	if err != nil {
+		t.Fatalf("NewRemoteSigner: %v", err)
+	}
+	return signer
+}
+
+func TestRemoteSignTypedData(t *testing.T) {
+	same := func(typedData apitypes.TypedData) apitypes.TypedData { return typedData }
+
+	signer := newTestRemoteSigner(t, testRemoteKey, same)
+	signature, err := signer.SignTypedData(context.Background(), testTypedData("hello"))
+	if err != nil {
+		t.Fatalf("SignTypedData: %v", err)
+	}
+	if recovered, err := RecoverTypedDataSigner(testTypedData("hello"), signature); err != nil || recovered != signer.Address() {
+		t.Errorf("signature recovers to %s (%v), want %s", recovered.Hex(), err, signer.Address().Hex())
+	}
+}
+
+func TestRemoteSignTypedDataRejectsOtherKey(t *testing.T) {
+	same := func(typedData apitypes.TypedData) apitypes.TypedData { return typedData }
+
+	signer := newTestRemoteSigner(t, testOtherKey, same)
+	if _, err := signer.SignTypedData(context.Background(), testTypedData("hello")); err == nil {
+		t.Error("signature of another key was accepted")
+	}
+}
+
+func TestRemoteSignTypedDataRejectsOtherData(t *testing.T) {
+	other := func(apitypes.TypedData) apitypes.TypedData { return testTypedData("something else") }
+
+	signer := newTestRemoteSigner(t, testRemoteKey, other)
+	if _, err := signer.SignTypedData(context.Background(), testTypedData("hello")); err == nil {
+		t.Error("signature of other typed data was accepted")
+	}
+}
@@ -0,0 +1,148 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/shared/http_client"
+	"kokka.com/kokka/internal/shared/logger"
+	"kokka.com/kokka/internal/shared/metrics"
+)
+
+// nonIdempotentMethods change state each time they succeed, so a failed call is never repeated blindly
+// eth_sendRawTransaction is retried separately, once the node is known not to have the transaction
+var nonIdempotentMethods = map[string]bool{
+	"eth_sendTransaction":      true,
+	"personal_sendTransaction": true,
+}
+
+// knownTransactionPatterns are node errors for a transaction that is already in the pool or mined
+var knownTransactionPatterns = []string{"already known", "known transaction", "already imported"}
+
+// retryReason classifies a response of the node for retries; empty when it must not be retried
+// Besides network errors, rate limiting and gateway errors, JSON-RPC errors of an overloaded or
+// lagging node are retried: code -32005 (limit exceeded), "header not found" and rate-limit messages
+func retryReason(resp *http_client.Response, err error) string {
+	if err != nil {
+		return http_client.NetworkRetryReason(err)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Sprintf("HTTP %d", resp.StatusCode)
+	}
+	if !resp.IsSuccess() || !bytes.Contains(resp.Body, []byte(`"error"`)) {
+		return ""
+	}
+
+	// A single response, or the responses of a batch
+	var responses []JSONRPCResponse
+	if err := resp.JSON(&responses); err != nil {
+		var single JSONRPCResponse
+		if resp.JSON(&single) != nil {
+			return ""
+		}
+		responses = []JSONRPCResponse{single}
+	}
+	for _, response := range responses {
+		if response.IsError() && retryableRPCError(response.Error) {
+			return fmt.Sprintf("JSON-RPC error %d: %s", response.Error.Code, response.Error.Message)
+		}
+	}
+	return ""
+}
+
+// retryableRPCError reports whether a JSON-RPC error is transient
+func retryableRPCError(rpcErr *JSONRPCError) bool {
+	return domain.ErrorCodeOf(rpcError(rpcErr)) == domain.ErrorCodeRPCUnavailable
+}
+
+// sendRawTransaction submits a signed transaction
+// A failed attempt may still have reached the node, so before each retry the transaction hash is
+// looked up and the send only repeated while the node does not have the transaction
+func (c *Client) sendRawTransaction(ctx context.Context, request JSONRPCRequest) (*JSONRPCResponse, string, error) {
+	txHash := rawTransactionHash(request.Params)
+
+	for attempt := 1; ; attempt++ {
+		resp, errorKind, err := c.post(ctx, request, http_client.WithoutRetry())
+		if err != nil && attempt > 1 && txHash != "" && knownTransaction(err) {
+			return hashResponse(request.ID, txHash), "", nil
+		}
+		if err == nil || txHash == "" || attempt > c.retry.MaxRetries || domain.ErrorCodeOf(err) != domain.ErrorCodeRPCUnavailable {
+			return resp, errorKind, err
+		}
+
+		delay := c.retry.Backoff(attempt)
+		if endpoint, err := url.Parse(c.config.BaseURL); err == nil {
+			metrics.IncRetry(endpoint.Host)
+		}
+		logger.GetLogger(ctx).Warnf("[RPC] eth_sendRawTransaction %s failed (%v), checking the node before retry %d of %d in %s",
+			txHash, err, attempt, c.retry.MaxRetries, delay)
+		if http_client.Wait(ctx, delay) != nil {
+			return resp, errorKind, err
+		}
+
+		exists, checkErr := c.transactionExists(ctx, txHash)
+		if checkErr != nil {
+			// Unknown whether the node has it; resending could only fail or duplicate the broadcast
+			return resp, errorKind, err
+		}
+		if exists {
+			return hashResponse(request.ID, txHash), "", nil
+		}
+		request.ID = atomic.AddInt64(&c.requestID, 1)
+	}
+}
+
+// transactionExists reports whether the node knows a transaction, pending or mined
+func (c *Client) transactionExists(ctx context.Context, txHash string) (bool, error) {
+	resp, _, err := c.post(ctx, JSONRPCRequest{
+		ID:      atomic.AddInt64(&c.requestID, 1),
+		JsonRPC: "2.0",
+		Method:  "eth_getTransactionByHash",
+		Params:  []interface{}{txHash},
+	})
+	if err != nil {
+		return false, err
+	}
+	return !isNull(resp.Result), nil
+}
+
+// rawTransactionHash returns the hash of the signed transaction eth_sendRawTransaction is called with, or empty
+func rawTransactionHash(params interface{}) string {
+	values, ok := params.([]interface{})
+	if !ok || len(values) == 0 {
+		return ""
+	}
+	signedTx, _ := values[0].(string)
+	raw, err := hexutil.Decode(signedTx)
+	if err != nil || len(raw) == 0 {
+		return ""
+	}
+	// The hash of typed transactions covers the type byte, so it is the hash of the raw encoding either way
+	return crypto.Keccak256Hash(raw).Hex()
+}
+
+// knownTransaction reports whether the node rejected a send because it already has the transaction
+func knownTransaction(err error) bool {
+	message := strings.ToLower(err.Error())
+	for _, pattern := range knownTransactionPatterns {
+		if strings.Contains(message, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// hashResponse is the response of a send the node already had
+func hashResponse(id int64, txHash string) *JSONRPCResponse {
+	result, _ := json.Marshal(txHash)
+	return &JSONRPCResponse{JsonRPC: "2.0", ID: id, Result: result}
+}
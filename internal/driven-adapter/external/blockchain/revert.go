@@ -0,0 +1,165 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/driven-adapter/external/blockchain/gen/erc20"
+	"kokka.com/kokka/internal/driven-adapter/external/blockchain/gen/swap"
+)
+
+// Selectors of the built-in Solidity errors
+var (
+	errorStringSelector = []byte{0x08, 0xc3, 0x79, 0xa0} // Error(string), from require/revert with a message
+	panicSelector       = []byte{0x4e, 0x48, 0x7b, 0x71} // Panic(uint256), from assert, overflow, division by zero...
+)
+
+// panicReasons describes the Solidity panic codes
+var panicReasons = map[uint64]string{
+	0x00: "generic compiler panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to an uninitialised function",
+}
+
+// contractABIs returns the parsed ABIs of the contracts kokka talks to
+var contractABIs = sync.OnceValue(func() []abi.ABI {
+	var parsedABIs []abi.ABI
+	for _, metaABI := range []string{erc20.ERC20MetaData.ABI, swap.SwapMetaData.ABI} {
+		parsed, err := abi.JSON(strings.NewReader(metaABI))
+		if err != nil {
+			continue
+		}
+		parsedABIs = append(parsedABIs, parsed)
+	}
+	return parsedABIs
+})
+
+// contractErrors returns the custom errors of the contract ABIs kokka talks to, by selector
+var contractErrors = sync.OnceValue(func() map[[4]byte]abi.Error {
+	errs := map[[4]byte]abi.Error{}
+	for _, parsed := range contractABIs() {
+		for _, abiErr := range parsed.Errors {
+			errs[[4]byte(abiErr.ID[:4])] = abiErr
+		}
+	}
+	return errs
+})
+
+// revertData extracts the revert bytes from the data field of a JSON-RPC error
+// Nodes send a hex string; some wrap it in an object with a data member
+func revertData(raw json.RawMessage) []byte {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var hexData string
+	if err := json.Unmarshal(raw, &hexData); err != nil {
+		var wrapped struct {
+			Data string `json:"data"`
+		}
+		if err := json.Unmarshal(raw, &wrapped); err != nil {
+			return nil
+		}
+		hexData = wrapped.Data
+	}
+
+	data, err := hexutil.Decode(hexData)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// decodeRevert decodes Error(string), Panic(uint256) and the custom errors of the loaded ABIs
+// It returns nil when the data is empty or not in a known format
+func decodeRevert(data []byte) *domain.Revert {
+	if len(data) < 4 {
+		return nil
+	}
+
+	selector, args := data[:4], data[4:]
+	revert := &domain.Revert{
+		Selector: hexutil.Encode(selector),
+		Data:     hexutil.Encode(data),
+	}
+
+	switch {
+	case bytes.Equal(selector, errorStringSelector):
+		reason, err := abi.UnpackRevert(data)
+		if err != nil {
+			return nil
+		}
+		revert.Error = "Error"
+		revert.Reason = reason
+		return revert
+
+	case bytes.Equal(selector, panicSelector):
+		if len(args) != 32 {
+			return nil
+		}
+		code := new(big.Int).SetBytes(args)
+		description := "unknown panic"
+		if known, ok := panicReasons[code.Uint64()]; ok && code.IsUint64() {
+			description = known
+		}
+		revert.Error = "Panic"
+		revert.Reason = fmt.Sprintf("panic: %s (0x%x)", description, code)
+		revert.Args = map[string]any{"code": code.String()}
+		return revert
+	}
+
+	abiErr, ok := contractErrors()[[4]byte(selector)]
+	if !ok {
+		return nil
+	}
+	values, err := abiErr.Inputs.Unpack(args)
+	if err != nil {
+		return nil
+	}
+
+	revert.Error = abiErr.Name
+	revert.Args = make(map[string]any, len(values))
+	parts := make([]string, 0, len(values))
+	for i, value := range values {
+		name := abiErr.Inputs[i].Name
+		if name == "" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		formatted := formatABIValue(value)
+		revert.Args[name] = formatted
+		parts = append(parts, fmt.Sprintf("%s=%v", name, formatted))
+	}
+	revert.Reason = fmt.Sprintf("%s(%s)", abiErr.Name, strings.Join(parts, ", "))
+	return revert
+}
+
+// formatABIValue renders a decoded ABI value for JSON: addresses and bytes as hex, integers as decimal strings
+func formatABIValue(value any) any {
+	switch v := value.(type) {
+	case common.Address:
+		return v.Hex()
+	case *big.Int:
+		return v.String()
+	case []byte:
+		return hexutil.Encode(v)
+	case [32]byte:
+		return hexutil.Encode(v[:])
+	default:
+		return v
+	}
+}
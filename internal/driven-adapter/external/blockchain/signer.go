@@ -2,56 +2,165 @@ package blockchain
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"kokka.com/kokka/internal/shared/metrics"
 )
 
-// TransactionSigner handles transaction signing operations
+// Signer signs transactions and typed data on behalf of a single account
+// Implementations: LocalSigner (in-memory key), KeystoreSigner (keystore JSON file)
+// and RemoteSigner (separate kokka-signer process)
+type Signer interface {
+	Address() common.Address
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error)
+	SignMessage(ctx context.Context, message []byte) ([]byte, error)
+}
+
+// TransactionSigner builds transactions, has them signed by a Signer and sends them
 type TransactionSigner struct {
-	privateKey *ecdsa.PrivateKey
-	client     *Client
+	signer Signer
+	client *Client
 }
 
-// NewTransactionSigner creates a new transaction signer
+// NewTransactionSigner creates a new transaction signer backed by an in-memory private key
 func NewTransactionSigner(privateKeyHex string, client *Client) (*TransactionSigner, error) {
-	// Remove 0x prefix if present
-	if len(privateKeyHex) > 2 && privateKeyHex[:2] == "0x" {
-		privateKeyHex = privateKeyHex[2:]
+	signer, err := NewLocalSigner(privateKeyHex)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTransactionSignerWithSigner(signer, client), nil
+}
+
+// NewTransactionSignerWithSigner creates a new transaction signer backed by any Signer
+func NewTransactionSignerWithSigner(signer Signer, client *Client) *TransactionSigner {
+	return &TransactionSigner{
+		signer: signer,
+		client: client,
 	}
+}
+
+// GetAddress returns the Ethereum address of the signing account
+func (s *TransactionSigner) GetAddress() string {
+	return s.signer.Address().Hex()
+}
 
-	// Parse private key
-	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+// Signer returns the underlying signer
+func (s *TransactionSigner) Signer() Signer {
+	return s.signer
+}
+
+// SignMessage signs an EIP-191 personal message and returns the 65-byte signature
+func (s *TransactionSigner) SignMessage(ctx context.Context, message []byte) ([]byte, error) {
+	signature, err := s.signer.SignMessage(ctx, message)
 	if err != nil {
-		return nil, fmt.Errorf("invalid private key: %w", err)
+		metrics.IncSigningFailure(signerKind(s.signer))
+		return nil, fmt.Errorf("failed to sign message: %w", err)
 	}
+	return signature, nil
+}
 
-	return &TransactionSigner{
-		privateKey: privateKey,
-		client:     client,
+// SignTypedData signs EIP-712 typed data and returns the 65-byte signature
+func (s *TransactionSigner) SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	signature, err := s.signer.SignTypedData(ctx, typedData)
+	if err != nil {
+		metrics.IncSigningFailure(signerKind(s.signer))
+		return nil, fmt.Errorf("failed to sign typed data: %w", err)
+	}
+	return signature, nil
+}
+
+// SentTransaction describes a signed transaction that was handed to the network
+type SentTransaction struct {
+	TxHash string
+	From   string
+	Nonce  uint64
+}
+
+// SignAndSend signs a transaction and sends it to the blockchain
+// If signing succeeded but sending failed, the signed transaction is returned along with the error
+func (s *TransactionSigner) SignAndSend(ctx context.Context, req *SignTransactionRequest) (*SentTransaction, error) {
+	signed, err := s.Sign(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return s.Send(ctx, signed)
+}
+
+// SignedTransaction is a signed transaction that has not been sent yet
+type SignedTransaction struct {
+	Raw     string             // 0x-prefixed encoding accepted by eth_sendRawTransaction
+	Tx      *types.Transaction // Signed transaction
+	From    string
+	ChainID *big.Int
+}
+
+// Sign resolves the nonce, gas and chain ID of a transaction and signs it without sending it
+// The signed transaction can be broadcast later, from this or any other machine
+func (s *TransactionSigner) Sign(ctx context.Context, req *SignTransactionRequest) (*SignedTransaction, error) {
+	// Resolve nonce, gas and chain ID
+	tx, chainID, err := s.client.buildTransaction(ctx, s.GetAddress(), req, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign transaction
+	signedTx, err := s.signer.SignTx(ctx, tx, chainID)
+	if err != nil {
+		metrics.IncSigningFailure(signerKind(s.signer))
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	// Encode signed transaction to raw hex
+	rawTxBytes, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction: %w", err)
+	}
+
+	return &SignedTransaction{
+		Raw:     "0x" + common.Bytes2Hex(rawTxBytes),
+		Tx:      signedTx,
+		From:    s.GetAddress(),
+		ChainID: chainID,
 	}, nil
 }
 
-// GetAddress returns the Ethereum address associated with the private key
-func (s *TransactionSigner) GetAddress() string {
-	publicKey := s.privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return ""
+// Send broadcasts a transaction signed by Sign
+// If sending failed, the transaction is returned along with the error, as it may still have reached the network
+func (s *TransactionSigner) Send(ctx context.Context, signed *SignedTransaction) (*SentTransaction, error) {
+	sent := &SentTransaction{
+		TxHash: signed.Tx.Hash().Hex(),
+		From:   signed.From,
+		Nonce:  signed.Tx.Nonce(),
+	}
+
+	txHash, err := s.client.SendRawTransaction(ctx, signed.Raw)
+	if err != nil {
+		return sent, fmt.Errorf("failed to send transaction: %w", err)
 	}
-	return crypto.PubkeyToAddress(*publicKeyECDSA).Hex()
+	sent.TxHash = txHash
+
+	return sent, nil
+}
+
+// BuildTransaction resolves the nonce, gas limit, gas price and chain ID of an unsigned legacy transaction sent by from
+// Missing fields of the request are filled from the network as for SignAndSend
+func (c *Client) BuildTransaction(ctx context.Context, from string, req *SignTransactionRequest) (*types.Transaction, *big.Int, error) {
+	return c.buildTransaction(ctx, from, req, true)
 }
 
-// SignAndSendTransaction signs a transaction and sends it to the blockchain
-func (s *TransactionSigner) SignAndSendTransaction(ctx context.Context, req *SignTransactionRequest) (string, error) {
+// buildTransaction resolves the nonce, gas limit, gas price and chain ID of an unsigned legacy transaction
+// Without estimateGas, a contract call with no gas limit in the request gets a gas limit of 0
+func (c *Client) buildTransaction(ctx context.Context, from string, req *SignTransactionRequest, estimateGas bool) (*types.Transaction, *big.Int, error) {
 	// Get chain ID
-	chainIDHex, err := s.client.GetChainID(ctx)
+	chainIDHex, err := c.GetChainID(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to get chain ID: %w", err)
+		return nil, nil, fmt.Errorf("failed to get chain ID: %w", err)
 	}
 	chainID := new(big.Int)
 	chainID.SetString(chainIDHex[2:], 16) // Remove 0x and parse as hex
@@ -82,9 +191,9 @@ func (s *TransactionSigner) SignAndSendTransaction(ctx context.Context, req *Sig
 		nonce = nonceBig.Uint64()
 	} else {
 		// Get nonce from blockchain
-		nonceHex, err := s.client.GetTransactionCount(ctx, s.GetAddress(), "pending")
+		nonceHex, err := c.GetTransactionCount(ctx, from, "pending")
 		if err != nil {
-			return "", fmt.Errorf("failed to get nonce: %w", err)
+			return nil, nil, fmt.Errorf("failed to get nonce: %w", err)
 		}
 		nonceStr := nonceHex
 		if len(nonceStr) > 2 && nonceStr[:2] == "0x" {
@@ -105,11 +214,13 @@ func (s *TransactionSigner) SignAndSendTransaction(ctx context.Context, req *Sig
 		gasLimitBig := new(big.Int)
 		gasLimitBig.SetString(gasLimitStr, 16)
 		gasLimit = gasLimitBig.Uint64()
+	} else if req.Data != "" && !estimateGas {
+		gasLimit = 0
 	} else if req.Data != "" {
 		// If data is present, estimate gas
-		estimatedGasHex, err := s.client.EstimateGas(ctx, s.GetAddress(), req.To, req.Value, req.Data)
+		estimatedGasHex, err := c.EstimateGas(ctx, from, req.To, req.Value, req.Data)
 		if err != nil {
-			return "", fmt.Errorf("failed to estimate gas: %w", err)
+			return nil, nil, fmt.Errorf("failed to estimate gas: %w", err)
 		}
 		gasLimitStr := estimatedGasHex
 		if len(gasLimitStr) > 2 && gasLimitStr[:2] == "0x" {
@@ -131,9 +242,9 @@ func (s *TransactionSigner) SignAndSendTransaction(ctx context.Context, req *Sig
 		gasPrice.SetString(gasPriceStr, 16)
 	} else {
 		// Get gas price from blockchain
-		gasPriceHex, err := s.client.GetGasPrice(ctx)
+		gasPriceHex, err := c.GetGasPrice(ctx)
 		if err != nil {
-			return "", fmt.Errorf("failed to get gas price: %w", err)
+			return nil, nil, fmt.Errorf("failed to get gas price: %w", err)
 		}
 		gasPrice = new(big.Int)
 		gasPriceStr := gasPriceHex
@@ -150,28 +261,7 @@ func (s *TransactionSigner) SignAndSendTransaction(ctx context.Context, req *Sig
 	}
 
 	// Create transaction
-	tx := types.NewTransaction(nonce, toAddress, value, gasLimit, gasPrice, data)
-
-	// Sign transaction
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), s.privateKey)
-	if err != nil {
-		return "", fmt.Errorf("failed to sign transaction: %w", err)
-	}
-
-	// Encode signed transaction to raw hex
-	rawTxBytes, err := signedTx.MarshalBinary()
-	if err != nil {
-		return "", fmt.Errorf("failed to encode transaction: %w", err)
-	}
-	rawTxHex := "0x" + common.Bytes2Hex(rawTxBytes)
-
-	// Send transaction
-	txHash, err := s.client.SendRawTransaction(ctx, rawTxHex)
-	if err != nil {
-		return "", fmt.Errorf("failed to send transaction: %w", err)
-	}
-
-	return txHash, nil
+	return types.NewTransaction(nonce, toAddress, value, gasLimit, gasPrice, data), chainID, nil
 }
 
 // SignTransactionRequest represents the parameters needed to sign a transaction
@@ -183,3 +273,17 @@ type SignTransactionRequest struct {
 	GasPrice string `json:"gas_price"` // Optional: gas price (hex string)
 	Nonce    string `json:"nonce"`     // Optional: transaction nonce (hex string)
 }
+
+// signerKind names a signer implementation for metrics
+func signerKind(signer Signer) string {
+	switch signer.(type) {
+	case *LocalSigner:
+		return "local"
+	case *KeystoreSigner:
+		return "keystore"
+	case *RemoteSigner:
+		return "remote"
+	default:
+		return "other"
+	}
+}
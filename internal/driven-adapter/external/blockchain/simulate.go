@@ -0,0 +1,250 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/shared/logger"
+)
+
+// SimulatedTransaction is the outcome of a dry run: the unsigned transaction and what executing it would do
+type SimulatedTransaction struct {
+	From         string
+	Tx           *types.Transaction // Unsigned transaction that would be signed and sent
+	ChainID      *big.Int
+	EstimatedFee *big.Int // Gas limit * gas price, in wei
+
+	Success      bool
+	ReturnData   string           // Raw return data of the call
+	Outputs      map[string]any   // Decoded return values, when the contract method is known
+	Logs         []*SimulatedLog  // Emitted events; nil when the node cannot trace calls
+	Error        string           // Why the transaction would fail
+	ErrorCode    domain.ErrorCode // Code of the failure
+	RevertReason string
+	Revert       *domain.Revert
+}
+
+// SimulatedLog is an event emitted by a simulated call
+// Event and Args are set when the event belongs to a known contract ABI
+type SimulatedLog struct {
+	Address string
+	Topics  []string
+	Data    string
+	Event   string
+	Args    map[string]any
+}
+
+// CallFrame is a frame of the debug_traceCall call tracer
+type CallFrame struct {
+	Type  string       `json:"type"`
+	From  string       `json:"from"`
+	To    string       `json:"to"`
+	Error string       `json:"error,omitempty"`
+	Calls []*CallFrame `json:"calls,omitempty"`
+	Logs  []*CallLog   `json:"logs,omitempty"`
+}
+
+// CallLog is a log recorded by the call tracer
+type CallLog struct {
+	Address string   `json:"address"`
+	Topics  []string `json:"topics"`
+	Data    string   `json:"data"`
+}
+
+// Simulate runs a transaction without signing it: it resolves the nonce, gas and fee as SignAndSend does,
+// executes it with eth_call from the signer address and, where the node supports it, traces the emitted events
+// A reverting transaction is a successful simulation with Success false; only RPC failures return an error
+func (s *TransactionSigner) Simulate(ctx context.Context, req *SignTransactionRequest) (*SimulatedTransaction, error) {
+	from := s.GetAddress()
+	sim := &SimulatedTransaction{From: from, Success: true}
+
+	// Execute the call as the signer
+	returnData, err := s.client.CallFrom(ctx, from, req.To, req.Value, req.Data, "latest")
+	if err != nil && !sim.fail(err) {
+		return nil, err
+	}
+	sim.ReturnData = returnData
+
+	// Resolve nonce and gas; a failing call cannot be estimated, so its gas limit stays unresolved
+	tx, chainID, err := s.client.buildTransaction(ctx, from, req, sim.Success)
+	if err != nil {
+		if !sim.fail(err) {
+			return nil, err
+		}
+		if tx, chainID, err = s.client.buildTransaction(ctx, from, req, false); err != nil {
+			return nil, err
+		}
+	}
+	sim.Tx = tx
+	sim.ChainID = chainID
+	sim.EstimatedFee = new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas()), tx.GasPrice())
+
+	if sim.Success {
+		sim.Logs = s.client.simulatedLogs(ctx, from, req)
+	}
+
+	return sim, nil
+}
+
+// fail records err as the outcome when it means the transaction would fail, and reports whether it did
+func (sim *SimulatedTransaction) fail(err error) bool {
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) {
+		return false
+	}
+	switch domainErr.Code {
+	case domain.ErrorCodeExecutionReverted, domain.ErrorCodeInsufficientBalance, domain.ErrorCodeInsufficientAllowance:
+	default:
+		return false
+	}
+
+	sim.Success = false
+	sim.Error = domainErr.Message
+	sim.ErrorCode = domainErr.Code
+	sim.RevertReason = domainErr.Reason
+	sim.Revert = domainErr.Revert
+	return true
+}
+
+// DecodeOutputs decodes the return data of a simulated call to method
+func (sim *SimulatedTransaction) DecodeOutputs(contractABI abi.ABI, method string) {
+	if !sim.Success || sim.ReturnData == "" {
+		return
+	}
+	abiMethod, ok := contractABI.Methods[method]
+	if !ok || len(abiMethod.Outputs) == 0 {
+		return
+	}
+	data, err := hexutil.Decode(sim.ReturnData)
+	if err != nil {
+		return
+	}
+	values, err := abiMethod.Outputs.Unpack(data)
+	if err != nil {
+		return
+	}
+
+	sim.Outputs = make(map[string]any, len(values))
+	for i, value := range values {
+		name := abiMethod.Outputs[i].Name
+		if name == "" {
+			name = fmt.Sprintf("output%d", i)
+		}
+		sim.Outputs[name] = formatABIValue(value)
+	}
+}
+
+// simulatedLogs traces the call and returns its events, or nil when tracing is unavailable
+// A node that rejects debug_traceCall is not asked again
+func (c *Client) simulatedLogs(ctx context.Context, from string, req *SignTransactionRequest) []*SimulatedLog {
+	if c.traceUnavailable.Load() {
+		return nil
+	}
+
+	frame, err := c.TraceCall(ctx, from, req.To, req.Value, req.Data, "latest")
+	if err != nil {
+		if domain.ErrorCodeOf(err) != domain.ErrorCodeRPCUnavailable {
+			c.traceUnavailable.Store(true)
+			logger.Info("blockchain: debug_traceCall unavailable, dry runs will not report events: %v", err)
+		}
+		return nil
+	}
+
+	logs := []*SimulatedLog{}
+	collectLogs(frame, &logs)
+	return logs
+}
+
+// collectLogs appends the logs of a frame and its sub-calls in execution order
+// Logs of reverted frames are discarded, as they would be on chain
+func collectLogs(frame *CallFrame, logs *[]*SimulatedLog) {
+	if frame == nil || frame.Error != "" {
+		return
+	}
+	for _, callLog := range frame.Logs {
+		*logs = append(*logs, decodeLog(callLog))
+	}
+	for _, call := range frame.Calls {
+		collectLogs(call, logs)
+	}
+}
+
+// decodeLog decodes a log against the events of the loaded contract ABIs
+func decodeLog(callLog *CallLog) *SimulatedLog {
+	simulated := &SimulatedLog{
+		Address: callLog.Address,
+		Topics:  callLog.Topics,
+		Data:    callLog.Data,
+	}
+	if len(callLog.Topics) == 0 {
+		return simulated
+	}
+
+	topics := make([]common.Hash, len(callLog.Topics))
+	for i, topic := range callLog.Topics {
+		topics[i] = common.HexToHash(topic)
+	}
+
+	for _, parsed := range contractABIs() {
+		event, err := parsed.EventByID(topics[0])
+		if err != nil {
+			continue
+		}
+
+		args := map[string]any{}
+		if err := abi.ParseTopicsIntoMap(args, indexedArguments(event.Inputs), topics[1:]); err != nil {
+			return simulated
+		}
+		if err := event.Inputs.UnpackIntoMap(args, common.FromHex(callLog.Data)); err != nil {
+			return simulated
+		}
+		for name, value := range args {
+			args[name] = formatABIValue(value)
+		}
+
+		simulated.Event = event.Name
+		simulated.Args = args
+		return simulated
+	}
+
+	return simulated
+}
+
+// indexedArguments returns the indexed arguments of an event
+func indexedArguments(arguments abi.Arguments) abi.Arguments {
+	var indexed abi.Arguments
+	for _, argument := range arguments {
+		if argument.Indexed {
+			indexed = append(indexed, argument)
+		}
+	}
+	return indexed
+}
+
+// UnsignedPayload returns the RLP-encoded EIP-155 signing payload of a legacy transaction and its hash,
+// the form external signers and hardware wallets sign
+func UnsignedPayload(tx *types.Transaction, chainID *big.Int) ([]byte, common.Hash, error) {
+	payload, err := rlp.EncodeToBytes([]interface{}{
+		tx.Nonce(),
+		tx.GasPrice(),
+		tx.Gas(),
+		tx.To(),
+		tx.Value(),
+		tx.Data(),
+		chainID,
+		uint(0),
+		uint(0),
+	})
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("failed to encode unsigned transaction: %w", err)
+	}
+	return payload, types.NewEIP155Signer(chainID).Hash(tx), nil
+}
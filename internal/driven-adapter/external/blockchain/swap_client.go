@@ -40,15 +40,15 @@ func NewSwapClient(client *Client, signer *TransactionSigner) (*SwapClient, erro
 }
 
 // SwapAforB executes a swap from token A to token B
-func (s *SwapClient) SwapAforB(ctx context.Context, contractAddress string, amountIn *big.Int) (string, error) {
+func (s *SwapClient) SwapAforB(ctx context.Context, contractAddress string, amountIn *big.Int) (*SentTransaction, error) {
 	if s.signer == nil {
-		return "", fmt.Errorf("signer is required for swap operations")
+		return nil, fmt.Errorf("signer is required for swap operations")
 	}
 
 	// Encode the swapAforB function call
 	data, err := s.abi.Pack("swapAforB", amountIn)
 	if err != nil {
-		return "", fmt.Errorf("failed to encode swapAforB call: %w", err)
+		return nil, fmt.Errorf("failed to encode swapAforB call: %w", err)
 	}
 
 	// Prepare transaction request
@@ -58,24 +58,24 @@ func (s *SwapClient) SwapAforB(ctx context.Context, contractAddress string, amou
 	}
 
 	// Sign and send the transaction
-	txHash, err := s.signer.SignAndSendTransaction(ctx, txReq)
+	sent, err := s.signer.SignAndSend(ctx, txReq)
 	if err != nil {
-		return "", fmt.Errorf("failed to send swapAforB transaction: %w", err)
+		return sent, fmt.Errorf("failed to send swapAforB transaction: %w", err)
 	}
 
-	return txHash, nil
+	return sent, nil
 }
 
 // SwapBforA executes a swap from token B to token A
-func (s *SwapClient) SwapBforA(ctx context.Context, contractAddress string, amountIn *big.Int) (string, error) {
+func (s *SwapClient) SwapBforA(ctx context.Context, contractAddress string, amountIn *big.Int) (*SentTransaction, error) {
 	if s.signer == nil {
-		return "", fmt.Errorf("signer is required for swap operations")
+		return nil, fmt.Errorf("signer is required for swap operations")
 	}
 
 	// Encode the swapBforA function call
 	data, err := s.abi.Pack("swapBforA", amountIn)
 	if err != nil {
-		return "", fmt.Errorf("failed to encode swapBforA call: %w", err)
+		return nil, fmt.Errorf("failed to encode swapBforA call: %w", err)
 	}
 
 	// Prepare transaction request
@@ -85,12 +85,45 @@ func (s *SwapClient) SwapBforA(ctx context.Context, contractAddress string, amou
 	}
 
 	// Sign and send the transaction
-	txHash, err := s.signer.SignAndSendTransaction(ctx, txReq)
+	sent, err := s.signer.SignAndSend(ctx, txReq)
 	if err != nil {
-		return "", fmt.Errorf("failed to send swapBforA transaction: %w", err)
+		return sent, fmt.Errorf("failed to send swapBforA transaction: %w", err)
 	}
 
-	return txHash, nil
+	return sent, nil
+}
+
+// SimulateSwapAforB dry-runs a swap from token A to token B without signing it
+func (s *SwapClient) SimulateSwapAforB(ctx context.Context, contractAddress string, amountIn *big.Int) (*SimulatedTransaction, error) {
+	return s.simulate(ctx, contractAddress, "swapAforB", amountIn)
+}
+
+// SimulateSwapBforA dry-runs a swap from token B to token A without signing it
+func (s *SwapClient) SimulateSwapBforA(ctx context.Context, contractAddress string, amountIn *big.Int) (*SimulatedTransaction, error) {
+	return s.simulate(ctx, contractAddress, "swapBforA", amountIn)
+}
+
+// simulate encodes a contract write, dry-runs it from the signer and decodes its return values
+func (s *SwapClient) simulate(ctx context.Context, contractAddress string, method string, args ...interface{}) (*SimulatedTransaction, error) {
+	if s.signer == nil {
+		return nil, fmt.Errorf("signer is required for swap operations")
+	}
+
+	data, err := s.abi.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s call: %w", method, err)
+	}
+
+	sim, err := s.signer.Simulate(ctx, &SignTransactionRequest{
+		To:   contractAddress,
+		Data: hexutil.Encode(data),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate %s transaction: %w", method, err)
+	}
+	sim.DecodeOutputs(s.abi, method)
+
+	return sim, nil
 }
 
 // GetAmountOutAforB returns the expected output amount for swapping A to B
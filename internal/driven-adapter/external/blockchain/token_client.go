@@ -40,11 +40,11 @@ func NewTokenClient(client *Client, signer *TransactionSigner) (*TokenClient, er
 }
 
 // Mint mints new tokens to a specified address
-func (v *TokenClient) Mint(ctx context.Context, contractAddress string, to string, amount *big.Int) (string, error) {
+func (v *TokenClient) Mint(ctx context.Context, contractAddress string, to string, amount *big.Int) (*SentTransaction, error) {
 	// Encode the mint function call
 	data, err := v.abi.Pack("mint", common.HexToAddress(to), amount)
 	if err != nil {
-		return "", fmt.Errorf("failed to encode mint call: %w", err)
+		return nil, fmt.Errorf("failed to encode mint call: %w", err)
 	}
 
 	// Prepare transaction request
@@ -54,20 +54,20 @@ func (v *TokenClient) Mint(ctx context.Context, contractAddress string, to strin
 	}
 
 	// Sign and send the transaction
-	txHash, err := v.signer.SignAndSendTransaction(ctx, txReq)
+	sent, err := v.signer.SignAndSend(ctx, txReq)
 	if err != nil {
-		return "", fmt.Errorf("failed to send mint transaction: %w", err)
+		return sent, fmt.Errorf("failed to send mint transaction: %w", err)
 	}
 
-	return txHash, nil
+	return sent, nil
 }
 
 // Burn burns tokens from the caller's account
-func (v *TokenClient) Burn(ctx context.Context, contractAddress string, amount *big.Int) (string, error) {
+func (v *TokenClient) Burn(ctx context.Context, contractAddress string, amount *big.Int) (*SentTransaction, error) {
 	// Encode the burn function call
 	data, err := v.abi.Pack("burn", amount)
 	if err != nil {
-		return "", fmt.Errorf("failed to encode burn call: %w", err)
+		return nil, fmt.Errorf("failed to encode burn call: %w", err)
 	}
 
 	// Prepare transaction request
@@ -77,20 +77,20 @@ func (v *TokenClient) Burn(ctx context.Context, contractAddress string, amount *
 	}
 
 	// Sign and send the transaction
-	txHash, err := v.signer.SignAndSendTransaction(ctx, txReq)
+	sent, err := v.signer.SignAndSend(ctx, txReq)
 	if err != nil {
-		return "", fmt.Errorf("failed to send burn transaction: %w", err)
+		return sent, fmt.Errorf("failed to send burn transaction: %w", err)
 	}
 
-	return txHash, nil
+	return sent, nil
 }
 
 // Transfer transfers tokens to a specified address
-func (v *TokenClient) Transfer(ctx context.Context, contractAddress string, to string, amount *big.Int) (string, error) {
+func (v *TokenClient) Transfer(ctx context.Context, contractAddress string, to string, amount *big.Int) (*SentTransaction, error) {
 	// Encode the transfer function call
 	data, err := v.abi.Pack("transfer", common.HexToAddress(to), amount)
 	if err != nil {
-		return "", fmt.Errorf("failed to encode transfer call: %w", err)
+		return nil, fmt.Errorf("failed to encode transfer call: %w", err)
 	}
 
 	// Prepare transaction request
@@ -100,12 +100,50 @@ func (v *TokenClient) Transfer(ctx context.Context, contractAddress string, to s
 	}
 
 	// Sign and send the transaction
-	txHash, err := v.signer.SignAndSendTransaction(ctx, txReq)
+	sent, err := v.signer.SignAndSend(ctx, txReq)
 	if err != nil {
-		return "", fmt.Errorf("failed to send transfer transaction: %w", err)
+		return sent, fmt.Errorf("failed to send transfer transaction: %w", err)
 	}
 
-	return txHash, nil
+	return sent, nil
+}
+
+// SimulateMint dry-runs a mint without signing it
+func (v *TokenClient) SimulateMint(ctx context.Context, contractAddress string, to string, amount *big.Int) (*SimulatedTransaction, error) {
+	return v.simulate(ctx, contractAddress, "mint", common.HexToAddress(to), amount)
+}
+
+// SimulateBurn dry-runs a burn without signing it
+func (v *TokenClient) SimulateBurn(ctx context.Context, contractAddress string, amount *big.Int) (*SimulatedTransaction, error) {
+	return v.simulate(ctx, contractAddress, "burn", amount)
+}
+
+// SimulateTransfer dry-runs a transfer without signing it
+func (v *TokenClient) SimulateTransfer(ctx context.Context, contractAddress string, to string, amount *big.Int) (*SimulatedTransaction, error) {
+	return v.simulate(ctx, contractAddress, "transfer", common.HexToAddress(to), amount)
+}
+
+// simulate encodes a contract write, dry-runs it from the signer and decodes its return values
+func (v *TokenClient) simulate(ctx context.Context, contractAddress string, method string, args ...interface{}) (*SimulatedTransaction, error) {
+	if v.signer == nil {
+		return nil, fmt.Errorf("signer is required to simulate %s", method)
+	}
+
+	data, err := v.abi.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s call: %w", method, err)
+	}
+
+	sim, err := v.signer.Simulate(ctx, &SignTransactionRequest{
+		To:   contractAddress,
+		Data: hexutil.Encode(data),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate %s transaction: %w", method, err)
+	}
+	sim.DecodeOutputs(v.abi, method)
+
+	return sim, nil
 }
 
 // BalanceOf returns the token balance of an address
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/driven-adapter/database"
+)
+
+const idempotencyColumns = "client_id, idempotency_key, fingerprint, status, response_code, response_body, created_at, updated_at, expires_at"
+
+// IdempotencyRepository persists Idempotency-Key records
+type IdempotencyRepository struct {
+	db *database.DB
+}
+
+// NewIdempotencyRepository creates a new idempotency repository
+func NewIdempotencyRepository(db *database.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// Reserve stores the record unless a live record already holds its key, in which case that record is returned
+func (r *IdempotencyRepository) Reserve(ctx context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error) {
+	now := time.Now().UTC()
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = now
+	}
+	record.UpdatedAt = record.CreatedAt
+
+	var existing *domain.IdempotencyRecord
+	err := r.db.WithTx(ctx, func(sqlTx *sql.Tx) error {
+		// An expired record no longer holds its key
+		_, err := sqlTx.ExecContext(ctx, r.db.Rebind(
+			"DELETE FROM idempotency_keys WHERE client_id = ? AND idempotency_key = ? AND expires_at < ?"),
+			record.ClientID, record.Key, now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to delete expired idempotency key: %w", err)
+		}
+
+		result, err := sqlTx.ExecContext(ctx, r.db.Rebind(
+			"INSERT INTO idempotency_keys ("+idempotencyColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) "+
+				"ON CONFLICT (client_id, idempotency_key) DO NOTHING"),
+			record.ClientID, record.Key, record.Fingerprint, record.Status,
+			nullInt(record.ResponseCode), nullString(string(record.ResponseBody)),
+			record.CreatedAt, record.UpdatedAt, record.ExpiresAt.UTC(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert idempotency key: %w", err)
+		}
+		if affected, err := result.RowsAffected(); err != nil || affected > 0 {
+			return err
+		}
+
+		row := sqlTx.QueryRowContext(ctx, r.db.Rebind(
+			"SELECT "+idempotencyColumns+" FROM idempotency_keys WHERE client_id = ? AND idempotency_key = ?"),
+			record.ClientID, record.Key,
+		)
+		existing, err = scanIdempotencyRecord(row)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// Complete stores the response of the request holding the key
+func (r *IdempotencyRepository) Complete(ctx context.Context, clientID string, key string, responseCode int, responseBody []byte) error {
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(
+		"UPDATE idempotency_keys SET status = ?, response_code = ?, response_body = ?, updated_at = ? WHERE client_id = ? AND idempotency_key = ?"),
+		domain.IdempotencyStatusCompleted, responseCode, string(responseBody), time.Now().UTC(), clientID, key,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Release removes a key so the request can be retried
+func (r *IdempotencyRepository) Release(ctx context.Context, clientID string, key string) error {
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(
+		"DELETE FROM idempotency_keys WHERE client_id = ? AND idempotency_key = ?"), clientID, key)
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired removes records that expired before the given time
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(
+		"DELETE FROM idempotency_keys WHERE expires_at < ?"), before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// scanIdempotencyRecord scans a row selected with idempotencyColumns
+func scanIdempotencyRecord(row scanner) (*domain.IdempotencyRecord, error) {
+	var record domain.IdempotencyRecord
+	var responseCode sql.NullInt64
+	var responseBody sql.NullString
+
+	err := row.Scan(&record.ClientID, &record.Key, &record.Fingerprint, &record.Status, &responseCode, &responseBody,
+		&record.CreatedAt, &record.UpdatedAt, &record.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("idempotency key not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan idempotency key: %w", err)
+	}
+
+	record.ResponseCode = int(responseCode.Int64)
+	if responseBody.Valid {
+		record.ResponseBody = []byte(responseBody.String)
+	}
+	return &record, nil
+}
+
+// nullInt maps zero to NULL
+func nullInt(value int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(value), Valid: value != 0}
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/driven-adapter/database"
+)
+
+const jobRunColumns = "id, job, trigger_type, status, owner, detail, error, started_at, finished_at"
+
+// JobRepository persists background job state and run history
+type JobRepository struct {
+	db *database.DB
+}
+
+// NewJobRepository creates a new job repository
+func NewJobRepository(db *database.DB) *JobRepository {
+	return &JobRepository{db: db}
+}
+
+// AcquireLock takes the job lock until the given time, if no other run holds it
+func (r *JobRepository) AcquireLock(ctx context.Context, name string, owner string, until time.Time) (bool, error) {
+	now := time.Now().UTC()
+	if err := r.ensureState(ctx, name, now); err != nil {
+		return false, err
+	}
+
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(
+		"UPDATE job_states SET locked_by = ?, locked_until = ?, updated_at = ? WHERE name = ? AND (locked_until IS NULL OR locked_until < ?)"),
+		owner, until.UTC(), now, name, now,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire job lock: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire job lock: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// ReleaseLock releases the job lock if the owner still holds it
+func (r *JobRepository) ReleaseLock(ctx context.Context, name string, owner string) error {
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(
+		"UPDATE job_states SET locked_by = NULL, locked_until = NULL, updated_at = ? WHERE name = ? AND locked_by = ?"),
+		time.Now().UTC(), name, owner,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to release job lock: %w", err)
+	}
+	return nil
+}
+
+// SetPaused pauses or resumes scheduled runs of a job
+func (r *JobRepository) SetPaused(ctx context.Context, name string, paused bool) error {
+	now := time.Now().UTC()
+	if err := r.ensureState(ctx, name, now); err != nil {
+		return err
+	}
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(
+		"UPDATE job_states SET paused = ?, updated_at = ? WHERE name = ?"), paused, now, name)
+	if err != nil {
+		return fmt.Errorf("failed to update job state: %w", err)
+	}
+	return nil
+}
+
+// GetState returns the state of a job, a job without stored state is unpaused and unlocked
+func (r *JobRepository) GetState(ctx context.Context, name string) (*domain.JobState, error) {
+	state := domain.JobState{Name: name}
+	var lockedBy sql.NullString
+	var lockedUntil sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, r.db.Rebind(
+		"SELECT paused, locked_by, locked_until, updated_at FROM job_states WHERE name = ?"), name,
+	).Scan(&state.Paused, &lockedBy, &lockedUntil, &state.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return &state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job state: %w", err)
+	}
+
+	state.LockedBy = lockedBy.String
+	state.LockedUntil = lockedUntil.Time
+	return &state, nil
+}
+
+// CreateRun records a job run that has started
+func (r *JobRepository) CreateRun(ctx context.Context, run *domain.JobRun) error {
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(
+		"INSERT INTO job_runs ("+jobRunColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"),
+		run.ID, run.Job, run.Trigger, run.Status, run.Owner, nullString(run.Detail), nullString(run.Error),
+		run.StartedAt.UTC(), nullTime(run.FinishedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert job run: %w", err)
+	}
+	return nil
+}
+
+// FinishRun records the outcome of a job run
+func (r *JobRepository) FinishRun(ctx context.Context, run *domain.JobRun) error {
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(
+		"UPDATE job_runs SET status = ?, detail = ?, error = ?, finished_at = ? WHERE id = ?"),
+		run.Status, nullString(run.Detail), nullString(run.Error), nullTime(run.FinishedAt), run.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update job run: %w", err)
+	}
+	return nil
+}
+
+// ListRuns returns the latest runs, of one job or of all jobs when job is empty
+func (r *JobRepository) ListRuns(ctx context.Context, job string, limit int) ([]*domain.JobRun, error) {
+	query := "SELECT " + jobRunColumns + " FROM job_runs"
+	var args []any
+	if job != "" {
+		query += " WHERE job = ?"
+		args = append(args, job)
+	}
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	query += " ORDER BY started_at DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job runs: %w", err)
+	}
+	defer rows.Close()
+
+	result := []*domain.JobRun{}
+	for rows.Next() {
+		var run domain.JobRun
+		var detail, runError sql.NullString
+		var finishedAt sql.NullTime
+		if err := rows.Scan(&run.ID, &run.Job, &run.Trigger, &run.Status, &run.Owner, &detail, &runError,
+			&run.StartedAt, &finishedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan job run: %w", err)
+		}
+		run.Detail = detail.String
+		run.Error = runError.String
+		if finishedAt.Valid {
+			run.FinishedAt = &finishedAt.Time
+		}
+		result = append(result, &run)
+	}
+	return result, rows.Err()
+}
+
+// DeleteRunsBefore removes run history started before the given time
+func (r *JobRepository) DeleteRunsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(
+		"DELETE FROM job_runs WHERE started_at < ?"), before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete job runs: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// ensureState creates the state row of a job if it does not exist yet
+func (r *JobRepository) ensureState(ctx context.Context, name string, now time.Time) error {
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(
+		"INSERT INTO job_states (name, paused, updated_at) VALUES (?, ?, ?) ON CONFLICT (name) DO NOTHING"),
+		name, false, now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create job state: %w", err)
+	}
+	return nil
+}
+
+// nullTime maps a nil pointer to NULL
+func nullTime(value *time.Time) sql.NullTime {
+	if value == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: value.UTC(), Valid: true}
+}
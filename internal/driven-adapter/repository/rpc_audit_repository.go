@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/driven-adapter/database"
+)
+
+const rpcAuditColumns = "id, client_id, request_id, method, batch, outcome, error, params_bytes, duration_ms, created_at"
+
+// RPCAuditRepository persists the audit trail of JSON-RPC proxy calls
+type RPCAuditRepository struct {
+	db *database.DB
+}
+
+// NewRPCAuditRepository creates a new RPC audit repository
+func NewRPCAuditRepository(db *database.DB) *RPCAuditRepository {
+	return &RPCAuditRepository{db: db}
+}
+
+// Insert records the calls of one proxy request
+func (r *RPCAuditRepository) Insert(ctx context.Context, entries []*domain.RPCAuditEntry) error {
+	return r.db.WithTx(ctx, func(sqlTx *sql.Tx) error {
+		for _, entry := range entries {
+			_, err := sqlTx.ExecContext(ctx, r.db.Rebind(
+				"INSERT INTO rpc_audit ("+rpcAuditColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"),
+				entry.ID, entry.ClientID, nullString(entry.RequestID), entry.Method, entry.Batch, entry.Outcome,
+				nullString(entry.Error), entry.ParamsBytes, entry.DurationMs, entry.CreatedAt.UTC(),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to insert rpc audit entry: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// List returns audit entries, newest first
+func (r *RPCAuditRepository) List(ctx context.Context, filter *domain.RPCAuditFilter) ([]*domain.RPCAuditEntry, error) {
+	query := "SELECT " + rpcAuditColumns + " FROM rpc_audit"
+	var where []string
+	var args []any
+	limit := defaultListLimit
+
+	if filter != nil {
+		if filter.ClientID != "" {
+			where = append(where, "client_id = ?")
+			args = append(args, filter.ClientID)
+		}
+		if filter.Method != "" {
+			where = append(where, "method = ?")
+			args = append(args, filter.Method)
+		}
+		if filter.Outcome != "" {
+			where = append(where, "outcome = ?")
+			args = append(args, filter.Outcome)
+		}
+		if filter.Limit > 0 {
+			limit = filter.Limit
+		}
+	}
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY created_at DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rpc audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	result := []*domain.RPCAuditEntry{}
+	for rows.Next() {
+		var entry domain.RPCAuditEntry
+		var requestID, entryError sql.NullString
+		if err := rows.Scan(&entry.ID, &entry.ClientID, &requestID, &entry.Method, &entry.Batch, &entry.Outcome,
+			&entryError, &entry.ParamsBytes, &entry.DurationMs, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan rpc audit entry: %w", err)
+		}
+		entry.RequestID = requestID.String
+		entry.Error = entryError.String
+		result = append(result, &entry)
+	}
+	return result, rows.Err()
+}
+
+// DeleteBefore removes audit entries recorded before the given time
+func (r *RPCAuditRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(
+		"DELETE FROM rpc_audit WHERE created_at < ?"), before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete rpc audit entries: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/driven-adapter/database"
+)
+
+// TokenTransferRepository persists indexed token transfers
+type TokenTransferRepository struct {
+	db *database.DB
+}
+
+// NewTokenTransferRepository creates a new token transfer repository
+func NewTokenTransferRepository(db *database.DB) *TokenTransferRepository {
+	return &TokenTransferRepository{db: db}
+}
+
+// SaveBatch stores the transfers and moves the cursor to blockNumber in one transaction
+// Transfers already indexed are skipped, so re-processing a block range is safe
+func (r *TokenTransferRepository) SaveBatch(ctx context.Context, transfers []*domain.TokenTransfer, cursor string, blockNumber uint64) error {
+	now := time.Now().UTC()
+
+	return r.db.WithTx(ctx, func(sqlTx *sql.Tx) error {
+		for _, transfer := range transfers {
+			if transfer.CreatedAt.IsZero() {
+				transfer.CreatedAt = now
+			}
+			_, err := sqlTx.ExecContext(ctx, r.db.Rebind(
+				"INSERT INTO token_transfers (id, token, from_address, to_address, amount, block_number, tx_hash, log_index, created_at) "+
+					"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT (id) DO NOTHING"),
+				transfer.ID, transfer.Token, transfer.From, transfer.To, transfer.Amount,
+				int64(transfer.BlockNumber), transfer.TxHash, int64(transfer.LogIndex), transfer.CreatedAt,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to insert token transfer: %w", err)
+			}
+		}
+
+		_, err := sqlTx.ExecContext(ctx, r.db.Rebind(
+			"INSERT INTO indexer_cursors (name, block_number, updated_at) VALUES (?, ?, ?) "+
+				"ON CONFLICT (name) DO UPDATE SET block_number = excluded.block_number, updated_at = excluded.updated_at"),
+			cursor, int64(blockNumber), now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update indexer cursor: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetCursor returns the last block processed by an indexer, ok is false before its first batch
+func (r *TokenTransferRepository) GetCursor(ctx context.Context, cursor string) (uint64, bool, error) {
+	var blockNumber int64
+	err := r.db.QueryRowContext(ctx, r.db.Rebind(
+		"SELECT block_number FROM indexer_cursors WHERE name = ?"), cursor).Scan(&blockNumber)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get indexer cursor: %w", err)
+	}
+	return uint64(blockNumber), true, nil
+}
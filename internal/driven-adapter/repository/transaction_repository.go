@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/driven-adapter/database"
+	"kokka.com/kokka/internal/shared/utils"
+)
+
+// ErrTransactionNotFound is returned when a journal entry does not exist
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+// defaultListLimit caps journal listings when no limit is given
+const defaultListLimit = 100
+
+const transactionColumns = "id, operation, status, requested_by, wallet_id, payload, signer_address, nonce, tx_hash, error, created_at, updated_at"
+
+// TransactionRepository persists the transaction journal
+type TransactionRepository struct {
+	db *database.DB
+}
+
+// NewTransactionRepository creates a new transaction repository
+func NewTransactionRepository(db *database.DB) *TransactionRepository {
+	return &TransactionRepository{db: db}
+}
+
+// Create inserts a journal entry and its initial status event
+func (r *TransactionRepository) Create(ctx context.Context, tx *domain.Transaction) error {
+	now := time.Now().UTC()
+	if tx.CreatedAt.IsZero() {
+		tx.CreatedAt = now
+	}
+	tx.UpdatedAt = tx.CreatedAt
+
+	return r.db.WithTx(ctx, func(sqlTx *sql.Tx) error {
+		_, err := sqlTx.ExecContext(ctx, r.db.Rebind(
+			"INSERT INTO transactions ("+transactionColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"),
+			tx.ID, tx.Operation, tx.Status, tx.RequestedBy, nullString(tx.WalletID), tx.Payload,
+			nullString(tx.SignerAddress), nullUint64(tx.Nonce), nullString(tx.TxHash), nullString(tx.Error),
+			tx.CreatedAt, tx.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert transaction: %w", err)
+		}
+
+		return r.insertEvent(ctx, sqlTx, tx.ID, tx.Status, "", tx.CreatedAt)
+	})
+}
+
+// Transition updates the status (and any newly known fields) of a journal entry and records the event
+// The entry must be in a status that may move to update.Status, or domain.ErrInvalidTransition is returned
+func (r *TransactionRepository) Transition(ctx context.Context, id string, update *domain.TransactionUpdate) error {
+	now := time.Now().UTC()
+	before := domain.TransactionStatusesBefore(update.Status)
+	if len(before) == 0 {
+		return fmt.Errorf("%w: no status moves to %s", domain.ErrInvalidTransition, update.Status)
+	}
+
+	sets := []string{"status = ?", "updated_at = ?"}
+	args := []any{update.Status, now}
+	if update.SignerAddress != "" {
+		sets = append(sets, "signer_address = ?")
+		args = append(args, update.SignerAddress)
+	}
+	if update.Nonce != nil {
+		sets = append(sets, "nonce = ?")
+		args = append(args, int64(*update.Nonce))
+	}
+	if update.TxHash != "" {
+		sets = append(sets, "tx_hash = ?")
+		args = append(args, update.TxHash)
+	}
+	if update.Error != "" {
+		sets = append(sets, "error = ?")
+		args = append(args, update.Error)
+	}
+	args = append(args, id)
+	for _, status := range before {
+		args = append(args, status)
+	}
+
+	return r.db.WithTx(ctx, func(sqlTx *sql.Tx) error {
+		// The status guard makes concurrent transitions of the same entry safe: only the first one moves it
+		result, err := sqlTx.ExecContext(ctx, r.db.Rebind(
+			"UPDATE transactions SET "+strings.Join(sets, ", ")+" WHERE id = ? AND status IN (?"+
+				strings.Repeat(", ?", len(before)-1)+")"), args...)
+		if err != nil {
+			return fmt.Errorf("failed to update transaction: %w", err)
+		}
+		if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+			var current string
+			err := sqlTx.QueryRowContext(ctx, r.db.Rebind("SELECT status FROM transactions WHERE id = ?"), id).Scan(&current)
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrTransactionNotFound
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read transaction status: %w", err)
+			}
+			return fmt.Errorf("%w: %s to %s", domain.ErrInvalidTransition, current, update.Status)
+		}
+
+		detail := update.Detail
+		if detail == "" {
+			detail = update.Error
+		}
+		return r.insertEvent(ctx, sqlTx, id, update.Status, detail, now)
+	})
+}
+
+// GetByID returns the journal entry with the given ID
+func (r *TransactionRepository) GetByID(ctx context.Context, id string) (*domain.Transaction, error) {
+	row := r.db.QueryRowContext(ctx, r.db.Rebind("SELECT "+transactionColumns+" FROM transactions WHERE id = ?"), id)
+	return scanTransaction(row)
+}
+
+// GetByTxHash returns the journal entry for an on-chain transaction hash
+func (r *TransactionRepository) GetByTxHash(ctx context.Context, txHash string) (*domain.Transaction, error) {
+	row := r.db.QueryRowContext(ctx, r.db.Rebind("SELECT "+transactionColumns+" FROM transactions WHERE tx_hash = ?"), txHash)
+	return scanTransaction(row)
+}
+
+// List returns journal entries, newest first
+func (r *TransactionRepository) List(ctx context.Context, filter *domain.TransactionFilter) ([]*domain.Transaction, error) {
+	query := "SELECT " + transactionColumns + " FROM transactions"
+	var where []string
+	var args []any
+	limit := defaultListLimit
+
+	if filter != nil {
+		if filter.Status != "" {
+			where = append(where, "status = ?")
+			args = append(args, filter.Status)
+		}
+		if filter.Operation != "" {
+			where = append(where, "operation = ?")
+			args = append(args, filter.Operation)
+		}
+		if filter.RequestedBy != "" {
+			where = append(where, "requested_by = ?")
+			args = append(args, filter.RequestedBy)
+		}
+		if !filter.UpdatedBefore.IsZero() {
+			where = append(where, "updated_at < ?")
+			args = append(args, filter.UpdatedBefore.UTC())
+		}
+		if filter.Limit > 0 {
+			limit = filter.Limit
+		}
+	}
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY created_at DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+	defer rows.Close()
+
+	result := []*domain.Transaction{}
+	for rows.Next() {
+		tx, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, tx)
+	}
+	return result, rows.Err()
+}
+
+// ListEvents returns the status transitions of a journal entry, oldest first
+func (r *TransactionRepository) ListEvents(ctx context.Context, id string) ([]*domain.TransactionEvent, error) {
+	rows, err := r.db.QueryContext(ctx, r.db.Rebind(
+		"SELECT id, transaction_id, status, detail, created_at FROM transaction_events WHERE transaction_id = ? ORDER BY created_at ASC"), id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transaction events: %w", err)
+	}
+	defer rows.Close()
+
+	result := []*domain.TransactionEvent{}
+	for rows.Next() {
+		var event domain.TransactionEvent
+		var detail sql.NullString
+		if err := rows.Scan(&event.ID, &event.TransactionID, &event.Status, &detail, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan transaction event: %w", err)
+		}
+		event.Detail = detail.String
+		result = append(result, &event)
+	}
+	return result, rows.Err()
+}
+
+// insertEvent records a status transition
+func (r *TransactionRepository) insertEvent(ctx context.Context, sqlTx *sql.Tx, transactionID string, status string, detail string, at time.Time) error {
+	eventID, err := utils.NewID("evt_")
+	if err != nil {
+		return err
+	}
+
+	_, err = sqlTx.ExecContext(ctx, r.db.Rebind(
+		"INSERT INTO transaction_events (id, transaction_id, status, detail, created_at) VALUES (?, ?, ?, ?, ?)"),
+		eventID, transactionID, status, nullString(detail), at,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert transaction event: %w", err)
+	}
+	return nil
+}
+
+// scanner is implemented by *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTransaction scans a row selected with transactionColumns
+func scanTransaction(row scanner) (*domain.Transaction, error) {
+	var tx domain.Transaction
+	var walletID, signerAddress, txHash, txError sql.NullString
+	var nonce sql.NullInt64
+
+	err := row.Scan(&tx.ID, &tx.Operation, &tx.Status, &tx.RequestedBy, &walletID, &tx.Payload,
+		&signerAddress, &nonce, &txHash, &txError, &tx.CreatedAt, &tx.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTransactionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan transaction: %w", err)
+	}
+
+	tx.WalletID = walletID.String
+	tx.SignerAddress = signerAddress.String
+	tx.TxHash = txHash.String
+	tx.Error = txError.String
+	if nonce.Valid {
+		value := uint64(nonce.Int64)
+		tx.Nonce = &value
+	}
+	return &tx, nil
+}
+
+// nullString maps an empty string to NULL
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// nullUint64 maps a nil pointer to NULL
+func nullUint64(value *uint64) sql.NullInt64 {
+	if value == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*value), Valid: true}
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/driven-adapter/database"
+	"kokka.com/kokka/internal/shared/config"
+)
+
+// newTestDB opens a SQLite database in a temporary directory with every migration applied
+func newTestDB(t *testing.T) *database.DB {
+	t.Helper()
+	db, err := database.Open(&config.DBConfig{Driver: database.DriverSQLite, Name: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if _, err := database.NewMigrator(db, filepath.Join("..", "..", "..", "migrations")).Up(context.Background(), 0); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	return db
+}
+
+func createTestTransaction(t *testing.T, repo *TransactionRepository, id string, createdAt time.Time) *domain.Transaction {
+	t.Helper()
+	tx := &domain.Transaction{
+		ID:          id,
+		Operation:   domain.OperationTransfer,
+		Status:      domain.TransactionStatusPending,
+		RequestedBy: "alice",
+		Payload:     `{"amount":"1"}`,
+		CreatedAt:   createdAt,
+	}
+	if err := repo.Create(context.Background(), tx); err != nil {
+		t.Fatalf("Create %s: %v", id, err)
+	}
+	return tx
+}
+
+func TestTransactionCreate(t *testing.T) {
+	ctx := context.Background()
+	repo := NewTransactionRepository(newTestDB(t))
+
+	createTestTransaction(t, repo, "txn_1", time.Now().UTC())
+
+	tx, err := repo.GetByID(ctx, "txn_1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if tx.Status != domain.TransactionStatusPending || tx.RequestedBy != "alice" || tx.Payload != `{"amount":"1"}` {
+		t.Errorf("GetByID = %+v", tx)
+	}
+	if tx.Nonce != nil || tx.TxHash != "" || tx.WalletID != "" {
+		t.Errorf("unset fields were not NULL: %+v", tx)
+	}
+
+	events, err := repo.ListEvents(ctx, "txn_1")
+	if err != nil {
+		t.Fatalf("ListEvents: %v", err)
+	}
+	if len(events) != 1 || events[0].Status != domain.TransactionStatusPending {
+		t.Errorf("events = %+v, want the pending event", events)
+	}
+
+	if _, err := repo.GetByID(ctx, "txn_missing"); !errors.Is(err, ErrTransactionNotFound) {
+		t.Errorf("GetByID of a missing entry: %v", err)
+	}
+}
+
+func TestTransactionTransition(t *testing.T) {
+	ctx := context.Background()
+	repo := NewTransactionRepository(newTestDB(t))
+	createTestTransaction(t, repo, "txn_1", time.Now().UTC())
+
+	nonce := uint64(7)
+	err := repo.Transition(ctx, "txn_1", &domain.TransactionUpdate{
+		Status:        domain.TransactionStatusSubmitted,
+		SignerAddress: "0x9858EfFD232B4033E47d90003D41EC34EcaEda94",
+		Nonce:         &nonce,
+		TxHash:        "0xabc",
+	})
+	if err != nil {
+		t.Fatalf("pending -> submitted: %v", err)
+	}
+	err = repo.Transition(ctx, "txn_1", &domain.TransactionUpdate{Status: domain.TransactionStatusConfirmed, Detail: "mined in block 16"})
+	if err != nil {
+		t.Fatalf("submitted -> confirmed: %v", err)
+	}
+
+	tx, err := repo.GetByTxHash(ctx, "0xabc")
+	if err != nil {
+		t.Fatalf("GetByTxHash: %v", err)
+	}
+	if tx.Status != domain.TransactionStatusConfirmed || tx.Nonce == nil || *tx.Nonce != 7 || tx.SignerAddress == "" {
+		t.Errorf("entry after transitions = %+v", tx)
+	}
+
+	events, err := repo.ListEvents(ctx, "txn_1")
+	if err != nil {
+		t.Fatalf("ListEvents: %v", err)
+	}
+	if len(events) != 3 || events[2].Status != domain.TransactionStatusConfirmed || events[2].Detail != "mined in block 16" {
+		t.Errorf("events = %+v", events)
+	}
+}
+
+func TestTransactionTransitionRefused(t *testing.T) {
+	ctx := context.Background()
+	repo := NewTransactionRepository(newTestDB(t))
+	createTestTransaction(t, repo, "txn_pending", time.Now().UTC())
+	createTestTransaction(t, repo, "txn_failed", time.Now().UTC())
+	if err := repo.Transition(ctx, "txn_failed", &domain.TransactionUpdate{Status: domain.TransactionStatusFailed, Error: "boom"}); err != nil {
+		t.Fatalf("pending -> failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		id     string
+		status string
+	}{
+		{"pending to confirmed", "txn_pending", domain.TransactionStatusConfirmed},
+		{"pending to pending", "txn_pending", domain.TransactionStatusPending},
+		{"failed to submitted", "txn_failed", domain.TransactionStatusSubmitted},
+		{"failed to failed", "txn_failed", domain.TransactionStatusFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, err := repo.GetByID(ctx, tt.id)
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+
+			err = repo.Transition(ctx, tt.id, &domain.TransactionUpdate{Status: tt.status, TxHash: "0xdef"})
+			if !errors.Is(err, domain.ErrInvalidTransition) {
+				t.Fatalf("Transition error = %v, want ErrInvalidTransition", err)
+			}
+
+			after, err := repo.GetByID(ctx, tt.id)
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+			if after.Status != before.Status || after.TxHash != before.TxHash {
+				t.Errorf("refused transition changed the entry: %+v", after)
+			}
+		})
+	}
+
+	err := repo.Transition(ctx, "txn_missing", &domain.TransactionUpdate{Status: domain.TransactionStatusFailed})
+	if !errors.Is(err, ErrTransactionNotFound) {
+		t.Errorf("Transition of a missing entry: %v", err)
+	}
+}
+
+func TestTransactionList(t *testing.T) {
+	ctx := context.Background()
+	repo := NewTransactionRepository(newTestDB(t))
+	now := time.Now().UTC()
+	createTestTransaction(t, repo, "txn_old", now.Add(-2*time.Hour))
+	createTestTransaction(t, repo, "txn_mid", now.Add(-time.Hour))
+	createTestTransaction(t, repo, "txn_new", now)
+	if err := repo.Transition(ctx, "txn_new", &domain.TransactionUpdate{Status: domain.TransactionStatusSubmitted, TxHash: "0x1"}); err != nil {
+		t.Fatalf("Transition: %v", err)
+	}
+
+	ids := func(filter *domain.TransactionFilter) []string {
+		t.Helper()
+		transactions, err := repo.List(ctx, filter)
+		if err != nil {
+			t.Fatalf("List(%+v): %v", filter, err)
+		}
+		result := []string{}
+		for _, tx := range transactions {
+			result = append(result, tx.ID)
+		}
+		return result
+	}
+	equal := func(got []string, want ...string) bool {
+		if len(got) != len(want) {
+			return false
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	if got := ids(nil); !equal(got, "txn_new", "txn_mid", "txn_old") {
+		t.Errorf("List() = %v, want newest first", got)
+	}
+	if got := ids(&domain.TransactionFilter{Status: domain.TransactionStatusPending}); !equal(got, "txn_mid", "txn_old") {
+		t.Errorf("List(pending) = %v", got)
+	}
+	if got := ids(&domain.TransactionFilter{UpdatedBefore: now.Add(-90 * time.Minute)}); !equal(got, "txn_old") {
+		t.Errorf("List(UpdatedBefore) = %v", got)
+	}
+	if got := ids(&domain.TransactionFilter{Status: domain.TransactionStatusPending, UpdatedBefore: now.Add(-30 * time.Minute)}); !equal(got, "txn_mid", "txn_old") {
+		t.Errorf("List(pending, UpdatedBefore) = %v", got)
+	}
+	if got := ids(&domain.TransactionFilter{Status: domain.TransactionStatusSubmitted, UpdatedBefore: now.Add(-time.Minute)}); !equal(got) {
+		t.Errorf("List(submitted, UpdatedBefore) = %v, want the just-updated entry excluded", got)
+	}
+	if got := ids(&domain.TransactionFilter{Limit: 1}); !equal(got, "txn_new") {
+		t.Errorf("List(limit 1) = %v", got)
+	}
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/driven-adapter/database"
+)
+
+const walletColumns = "id, address, label, kind, encrypted_key, keystore_path, derivation_index, derivation_path, created_by, created_at"
+
+// WalletRepository persists custodial wallets, so every instance and the sweeper share them
+// Wallet keys are stored already encrypted, the table holds no plaintext secrets
+type WalletRepository struct {
+	db *database.DB
+}
+
+// NewWalletRepository creates a new wallet repository
+func NewWalletRepository(db *database.DB) *WalletRepository {
+	return &WalletRepository{db: db}
+}
+
+// Create adds a new wallet; the ID, address and derivation index must be unused
+func (r *WalletRepository) Create(ctx context.Context, wallet *domain.Wallet) error {
+	var derivationIndex sql.NullInt64
+	if wallet.DerivationIndex != nil {
+		derivationIndex = sql.NullInt64{Int64: int64(*wallet.DerivationIndex), Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(
+		"INSERT INTO wallets ("+walletColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"),
+		wallet.ID, wallet.Address, nullString(wallet.Label), wallet.Kind, nullString(wallet.EncryptedKey),
+		nullString(wallet.KeystorePath), derivationIndex, nullString(wallet.DerivationPath),
+		nullString(wallet.CreatedBy), wallet.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert wallet: %w", err)
+	}
+	return nil
+}
+
+// GetByID returns the wallet with the given ID
+func (r *WalletRepository) GetByID(ctx context.Context, id string) (*domain.Wallet, error) {
+	row := r.db.QueryRowContext(ctx, r.db.Rebind("SELECT "+walletColumns+" FROM wallets WHERE id = ?"), id)
+	return scanWallet(row)
+}
+
+// GetByAddress returns the wallet for the given address (case-insensitive)
+func (r *WalletRepository) GetByAddress(ctx context.Context, address string) (*domain.Wallet, error) {
+	row := r.db.QueryRowContext(ctx, r.db.Rebind(
+		"SELECT "+walletColumns+" FROM wallets WHERE LOWER(address) = ?"), strings.ToLower(address))
+	return scanWallet(row)
+}
+
+// GetByDerivationIndex returns the derived wallet at the given HD index
+func (r *WalletRepository) GetByDerivationIndex(ctx context.Context, index uint32) (*domain.Wallet, error) {
+	row := r.db.QueryRowContext(ctx, r.db.Rebind(
+		"SELECT "+walletColumns+" FROM wallets WHERE derivation_index = ?"), int64(index))
+	return scanWallet(row)
+}
+
+// List returns all wallets, oldest first
+func (r *WalletRepository) List(ctx context.Context) ([]*domain.Wallet, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+walletColumns+" FROM wallets ORDER BY created_at, id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wallets: %w", err)
+	}
+	defer rows.Close()
+
+	var wallets []*domain.Wallet
+	for rows.Next() {
+		wallet, err := scanWallet(rows)
+		if err != nil {
+			return nil, err
+		}
+		wallets = append(wallets, wallet)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list wallets: %w", err)
+	}
+	return wallets, nil
+}
+
+// scanWallet scans a row selected with walletColumns
+func scanWallet(row scanner) (*domain.Wallet, error) {
+	var wallet domain.Wallet
+	var label, encryptedKey, keystorePath, derivationPath, createdBy sql.NullString
+	var derivationIndex sql.NullInt64
+
+	err := row.Scan(&wallet.ID, &wallet.Address, &label, &wallet.Kind, &encryptedKey, &keystorePath,
+		&derivationIndex, &derivationPath, &createdBy, &wallet.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrWalletNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan wallet: %w", err)
+	}
+
+	wallet.Label = label.String
+	wallet.EncryptedKey = encryptedKey.String
+	wallet.KeystorePath = keystorePath.String
+	wallet.DerivationPath = derivationPath.String
+	wallet.CreatedBy = createdBy.String
+	if derivationIndex.Valid {
+		index := uint32(derivationIndex.Int64)
+		wallet.DerivationIndex = &index
+	}
+	return &wallet, nil
+}
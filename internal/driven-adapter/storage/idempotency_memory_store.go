@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"kokka.com/kokka/internal/core/domain"
+)
+
+// IdempotencyMemoryStore keeps Idempotency-Key records in memory
+// Used when no database is configured; records do not survive a restart
+type IdempotencyMemoryStore struct {
+	mutex   sync.Mutex
+	records map[string]*domain.IdempotencyRecord
+}
+
+// NewIdempotencyMemoryStore creates an empty in-memory idempotency store
+func NewIdempotencyMemoryStore() *IdempotencyMemoryStore {
+	return &IdempotencyMemoryStore{records: make(map[string]*domain.IdempotencyRecord)}
+}
+
+// Reserve stores the record unless a live record already holds its key, in which case a copy of that record is returned
+func (s *IdempotencyMemoryStore) Reserve(ctx context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now().UTC()
+	id := idempotencyStoreKey(record.ClientID, record.Key)
+	if existing, ok := s.records[id]; ok && !existing.ExpiresAt.Before(now) {
+		found := *existing
+		return &found, nil
+	}
+
+	stored := *record
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = now
+	}
+	stored.UpdatedAt = stored.CreatedAt
+	s.records[id] = &stored
+	return nil, nil
+}
+
+// Complete stores the response of the request holding the key
+func (s *IdempotencyMemoryStore) Complete(ctx context.Context, clientID string, key string, responseCode int, responseBody []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if record, ok := s.records[idempotencyStoreKey(clientID, key)]; ok {
+		record.Status = domain.IdempotencyStatusCompleted
+		record.ResponseCode = responseCode
+		record.ResponseBody = append([]byte(nil), responseBody...)
+		record.UpdatedAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// Release removes a key so the request can be retried
+func (s *IdempotencyMemoryStore) Release(ctx context.Context, clientID string, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.records, idempotencyStoreKey(clientID, key))
+	return nil
+}
+
+// DeleteExpired removes records that expired before the given time
+func (s *IdempotencyMemoryStore) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var deleted int64
+	for id, record := range s.records {
+		if record.ExpiresAt.Before(before) {
+			delete(s.records, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// idempotencyStoreKey scopes a key to its client
+func idempotencyStoreKey(clientID string, key string) string {
+	return clientID + "\x00" + key
+}
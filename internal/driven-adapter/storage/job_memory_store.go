@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"kokka.com/kokka/internal/core/domain"
+)
+
+// maxMemoryJobRuns caps the run history kept in memory
+const maxMemoryJobRuns = 1000
+
+// JobMemoryStore keeps background job state and run history in memory
+// Used when no database is configured; locks only cover this server instance
+type JobMemoryStore struct {
+	mutex  sync.Mutex
+	states map[string]*domain.JobState
+	runs   []*domain.JobRun // Oldest first
+}
+
+// NewJobMemoryStore creates an empty in-memory job store
+func NewJobMemoryStore() *JobMemoryStore {
+	return &JobMemoryStore{states: make(map[string]*domain.JobState)}
+}
+
+// AcquireLock takes the job lock until the given time, if no other run holds it
+func (s *JobMemoryStore) AcquireLock(ctx context.Context, name string, owner string, until time.Time) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now().UTC()
+	state := s.state(name)
+	if state.LockedUntil.After(now) {
+		return false, nil
+	}
+
+	state.LockedBy = owner
+	state.LockedUntil = until.UTC()
+	state.UpdatedAt = now
+	return true, nil
+}
+
+// ReleaseLock releases the job lock if the owner still holds it
+func (s *JobMemoryStore) ReleaseLock(ctx context.Context, name string, owner string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	state := s.state(name)
+	if state.LockedBy == owner {
+		state.LockedBy = ""
+		state.LockedUntil = time.Time{}
+		state.UpdatedAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// SetPaused pauses or resumes scheduled runs of a job
+func (s *JobMemoryStore) SetPaused(ctx context.Context, name string, paused bool) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	state := s.state(name)
+	state.Paused = paused
+	state.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// GetState returns a copy of the state of a job
+func (s *JobMemoryStore) GetState(ctx context.Context, name string) (*domain.JobState, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	state := *s.state(name)
+	return &state, nil
+}
+
+// CreateRun records a job run that has started
+func (s *JobMemoryStore) CreateRun(ctx context.Context, run *domain.JobRun) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stored := *run
+	s.runs = append(s.runs, &stored)
+	if len(s.runs) > maxMemoryJobRuns {
+		s.runs = s.runs[len(s.runs)-maxMemoryJobRuns:]
+	}
+	return nil
+}
+
+// FinishRun records the outcome of a job run
+func (s *JobMemoryStore) FinishRun(ctx context.Context, run *domain.JobRun) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i := len(s.runs) - 1; i >= 0; i-- {
+		if s.runs[i].ID == run.ID {
+			stored := *run
+			s.runs[i] = &stored
+			break
+		}
+	}
+	return nil
+}
+
+// ListRuns returns the latest runs, of one job or of all jobs when job is empty
+func (s *JobMemoryStore) ListRuns(ctx context.Context, job string, limit int) ([]*domain.JobRun, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	result := []*domain.JobRun{}
+	for i := len(s.runs) - 1; i >= 0 && (limit <= 0 || len(result) < limit); i-- {
+		if job == "" || s.runs[i].Job == job {
+			run := *s.runs[i]
+			result = append(result, &run)
+		}
+	}
+	return result, nil
+}
+
+// DeleteRunsBefore removes run history started before the given time
+func (s *JobMemoryStore) DeleteRunsBefore(ctx context.Context, before time.Time) (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	kept := s.runs[:0]
+	for _, run := range s.runs {
+		if !run.StartedAt.Before(before) {
+			kept = append(kept, run)
+		}
+	}
+	deleted := int64(len(s.runs) - len(kept))
+	s.runs = kept
+	return deleted, nil
+}
+
+// state returns the state of a job, creating it if needed; the caller must hold the mutex
+func (s *JobMemoryStore) state(name string) *domain.JobState {
+	state, ok := s.states[name]
+	if !ok {
+		state = &domain.JobState{Name: name}
+		s.states[name] = state
+	}
+	return state
+}
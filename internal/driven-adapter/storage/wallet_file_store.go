@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"kokka.com/kokka/internal/core/domain"
+)
+
+// WalletFileStore persists wallets as a JSON file, for single-instance deployments without a database
+// Wallet keys are stored already encrypted, the file itself holds no plaintext secrets
+type WalletFileStore struct {
+	path    string
+	mutex   sync.RWMutex
+	wallets []*domain.Wallet
+}
+
+// NewWalletFileStore opens (or creates) a wallet store at the given path
+func NewWalletFileStore(path string) (*WalletFileStore, error) {
+	store := &WalletFileStore{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read wallet store: %w", err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &store.wallets); err != nil {
+			return nil, fmt.Errorf("failed to parse wallet store: %w", err)
+		}
+	}
+
+	return store, nil
+}
+
+// Create adds a new wallet
+func (s *WalletFileStore) Create(ctx context.Context, wallet *domain.Wallet) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, w := range s.wallets {
+		if w.ID == wallet.ID {
+			return fmt.Errorf("wallet %s already exists", wallet.ID)
+		}
+		if strings.EqualFold(w.Address, wallet.Address) {
+			return fmt.Errorf("wallet for address %s already exists", wallet.Address)
+		}
+		if w.DerivationIndex != nil && wallet.DerivationIndex != nil && *w.DerivationIndex == *wallet.DerivationIndex {
+			return fmt.Errorf("wallet for derivation index %d already exists", *wallet.DerivationIndex)
+		}
+	}
+
+	s.wallets = append(s.wallets, wallet)
+	if err := s.flush(); err != nil {
+		s.wallets = s.wallets[:len(s.wallets)-1]
+		return err
+	}
+
+	return nil
+}
+
+// GetByID returns the wallet with the given ID
+func (s *WalletFileStore) GetByID(ctx context.Context, id string) (*domain.Wallet, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, w := range s.wallets {
+		if w.ID == id {
+			return w, nil
+		}
+	}
+	return nil, domain.ErrWalletNotFound
+}
+
+// GetByAddress returns the wallet for the given address
+func (s *WalletFileStore) GetByAddress(ctx context.Context, address string) (*domain.Wallet, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, w := range s.wallets {
+		if strings.EqualFold(w.Address, address) {
+			return w, nil
+		}
+	}
+	return nil, domain.ErrWalletNotFound
+}
+
+// GetByDerivationIndex returns the derived wallet at the given HD index
+func (s *WalletFileStore) GetByDerivationIndex(ctx context.Context, index uint32) (*domain.Wallet, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, w := range s.wallets {
+		if w.DerivationIndex != nil && *w.DerivationIndex == index {
+			return w, nil
+		}
+	}
+	return nil, domain.ErrWalletNotFound
+}
+
+// List returns all wallets
+func (s *WalletFileStore) List(ctx context.Context) ([]*domain.Wallet, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	result := make([]*domain.Wallet, len(s.wallets))
+	copy(result, s.wallets)
+	return result, nil
+}
+
+// flush writes the store to disk atomically (write temp file, then rename)
+func (s *WalletFileStore) flush() error {
+	data, err := json.MarshalIndent(s.wallets, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode wallet store: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create wallet store directory: %w", err)
+	}
+
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write wallet store: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return fmt.Errorf("failed to replace wallet store: %w", err)
+	}
+
+	return nil
+}
@@ -1,13 +1,14 @@
 package controller
 
 import (
+	"bytes"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 
 	"kokka.com/kokka/internal/applications/dtos"
 	diSvc "kokka.com/kokka/internal/core/di/services"
+	"kokka.com/kokka/internal/core/domain"
 	"kokka.com/kokka/internal/shared/constant/status"
 	"kokka.com/kokka/internal/shared/response"
 )
@@ -30,7 +31,7 @@ func (c *BlockchainController) GetBlockNumber(w http.ResponseWriter, r *http.Req
 
 	result, err := c.blockchainService.GetBlockNumber(ctx)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.INTERNAL)
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
 		return
 	}
 
@@ -43,7 +44,7 @@ func (c *BlockchainController) GetGasPrice(w http.ResponseWriter, r *http.Reques
 
 	result, err := c.blockchainService.GetGasPrice(ctx)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.INTERNAL)
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
 		return
 	}
 
@@ -56,7 +57,7 @@ func (c *BlockchainController) GetChainID(w http.ResponseWriter, r *http.Request
 
 	result, err := c.blockchainService.GetChainID(ctx)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.INTERNAL)
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
 		return
 	}
 
@@ -69,14 +70,14 @@ func (c *BlockchainController) GetBalance(w http.ResponseWriter, r *http.Request
 
 	var req dtos.GetBalanceRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteJson(w, r.Context(), nil, fmt.Errorf("invalid parameters"), status.FAIL)
+		response.WriteJson(w, r.Context(), nil, errInvalidParameters, status.FAIL)
 		return
 	}
 
 	// Call service
 	result, err := c.blockchainService.GetBalance(ctx, &req)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.INTERNAL)
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
 		return
 	}
 
@@ -89,14 +90,14 @@ func (c *BlockchainController) GetBlock(w http.ResponseWriter, r *http.Request)
 
 	var req dtos.GetBlockRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteJson(w, r.Context(), nil, fmt.Errorf("invalid parameters"), status.FAIL)
+		response.WriteJson(w, r.Context(), nil, errInvalidParameters, status.FAIL)
 		return
 	}
 
 	// Call service
 	result, err := c.blockchainService.GetBlock(ctx, &req)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.INTERNAL)
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
 		return
 	}
 
@@ -109,14 +110,14 @@ func (c *BlockchainController) GetTransaction(w http.ResponseWriter, r *http.Req
 
 	var req dtos.GetTransactionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteJson(w, r.Context(), nil, fmt.Errorf("invalid parameters"), status.FAIL)
+		response.WriteJson(w, r.Context(), nil, errInvalidParameters, status.FAIL)
 		return
 	}
 
 	// Call service
 	result, err := c.blockchainService.GetTransaction(ctx, &req)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.INTERNAL)
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
 		return
 	}
 
@@ -129,14 +130,14 @@ func (c *BlockchainController) CallContract(w http.ResponseWriter, r *http.Reque
 
 	var req dtos.CallContractRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteJson(w, r.Context(), nil, fmt.Errorf("invalid parameters"), status.FAIL)
+		response.WriteJson(w, r.Context(), nil, errInvalidParameters, status.FAIL)
 		return
 	}
 
 	// Call service
 	result, err := c.blockchainService.CallContract(ctx, &req)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.INTERNAL)
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
 		return
 	}
 
@@ -149,14 +150,14 @@ func (c *BlockchainController) EstimateGas(w http.ResponseWriter, r *http.Reques
 
 	var req dtos.EstimateGasRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteJson(w, r.Context(), nil, fmt.Errorf("invalid parameters"), status.FAIL)
+		response.WriteJson(w, r.Context(), nil, errInvalidParameters, status.FAIL)
 		return
 	}
 
 	// Call service
 	result, err := c.blockchainService.EstimateGas(ctx, &req)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.INTERNAL)
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
 		return
 	}
 
@@ -169,14 +170,14 @@ func (c *BlockchainController) SendRawTransaction(w http.ResponseWriter, r *http
 
 	var req dtos.SendRawTransactionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteJson(w, r.Context(), nil, fmt.Errorf("invalid parameters"), status.FAIL)
+		response.WriteJson(w, r.Context(), nil, errInvalidParameters, status.FAIL)
 		return
 	}
 
 	// Call service
 	result, err := c.blockchainService.SendRawTransaction(ctx, &req)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.INTERNAL)
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
 		return
 	}
 
@@ -189,42 +190,160 @@ func (c *BlockchainController) SignAndSendTransaction(w http.ResponseWriter, r *
 
 	var req dtos.SignAndSendTransactionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteJson(w, r.Context(), nil, fmt.Errorf("invalid parameters"), status.FAIL)
+		response.WriteJson(w, r.Context(), nil, errInvalidParameters, status.FAIL)
 		return
 	}
 
 	// Call service
 	result, err := c.blockchainService.SignAndSendTransaction(ctx, &req)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.INTERNAL)
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
 		return
 	}
 
 	response.WriteJson(w, ctx, result, nil, status.OK)
 }
 
-// GenericRPCCall handles POST /blockchain/rpc
+// SignTransaction handles POST /blockchain/sign
+func (c *BlockchainController) SignTransaction(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req dtos.SignTransactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, r.Context(), nil, errInvalidParameters, status.FAIL)
+		return
+	}
+
+	// Call service
+	result, err := c.blockchainService.SignTransaction(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
+
+// BuildTransaction handles POST /blockchain/build
+func (c *BlockchainController) BuildTransaction(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req dtos.BuildTransactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, r.Context(), nil, errInvalidParameters, status.FAIL)
+		return
+	}
+
+	// Call service
+	result, err := c.blockchainService.BuildTransaction(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
+
+// SignMessage handles POST /blockchain/sign-message
+func (c *BlockchainController) SignMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req dtos.SignMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, r.Context(), nil, errInvalidParameters, status.FAIL)
+		return
+	}
+
+	// Call service
+	result, err := c.blockchainService.SignMessage(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
+
+// SignTypedData handles POST /blockchain/sign-typed-data
+func (c *BlockchainController) SignTypedData(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req dtos.SignTypedDataRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, r.Context(), nil, errInvalidParameters, status.FAIL)
+		return
+	}
+
+	// Call service
+	result, err := c.blockchainService.SignTypedData(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
+
+// VerifySignature handles POST /blockchain/verify-signature
+func (c *BlockchainController) VerifySignature(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req dtos.VerifySignatureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, r.Context(), nil, errInvalidParameters, status.FAIL)
+		return
+	}
+
+	// Call service
+	result, err := c.blockchainService.VerifySignature(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
+
+// GenericRPCCall handles POST /blockchain/rpc with a single call or a batch
 func (c *BlockchainController) GenericRPCCall(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// Parse request body
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.FAIL)
+		response.WriteJson(w, ctx, nil, domain.AsValidationError(err), status.FAIL)
 		return
 	}
 	defer r.Body.Close()
 
+	// A JSON array is a batch
+	if trimmed := bytes.TrimLeft(body, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '[' {
+		var reqs []*dtos.GenericRPCRequest
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			response.WriteJson(w, ctx, nil, domain.AsValidationError(err), status.FAIL)
+			return
+		}
+
+		result, err := c.blockchainService.GenericRPCBatch(ctx, reqs)
+		if err != nil {
+			response.WriteJson(w, ctx, nil, err, errorStatus(err))
+			return
+		}
+
+		response.WriteJson(w, ctx, result, nil, status.OK)
+		return
+	}
+
 	var req dtos.GenericRPCRequest
 	if err := json.Unmarshal(body, &req); err != nil {
-		response.WriteJson(w, ctx, nil, err, status.FAIL)
+		response.WriteJson(w, ctx, nil, domain.AsValidationError(err), status.FAIL)
 		return
 	}
 
 	// Call service
 	result, err := c.blockchainService.GenericRPCCall(ctx, &req)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.INTERNAL)
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
 		return
 	}
 
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"kokka.com/kokka/internal/applications/dtos"
+	diSvc "kokka.com/kokka/internal/core/di/services"
+	"kokka.com/kokka/internal/shared/constant/status"
+	"kokka.com/kokka/internal/shared/response"
+)
+
+type HealthController struct {
+	healthService diSvc.IHealthService
+}
+
+func NewHealthController(healthService diSvc.IHealthService) *HealthController {
+	return &HealthController{
+		healthService: healthService,
+	}
+}
+
+// HandleHealthz handles GET /healthz
+func (c *HealthController) HandleHealthz(w http.ResponseWriter, r *http.Request) {
+	result, err := c.healthService.Liveness(r.Context())
+	if err != nil {
+		writeProbe(w, http.StatusServiceUnavailable, map[string]string{"status": "failed", "error": err.Error()})
+		return
+	}
+	writeProbe(w, http.StatusOK, result)
+}
+
+// HandleReadyz handles GET /readyz
+// Load balancers and deploy scripts read the HTTP status, so this answers 503 unless ready
+func (c *HealthController) HandleReadyz(w http.ResponseWriter, r *http.Request) {
+	result, err := c.healthService.Readiness(r.Context())
+	if err != nil {
+		writeProbe(w, http.StatusServiceUnavailable, map[string]string{"status": dtos.ReadinessNotReady, "error": err.Error()})
+		return
+	}
+
+	code := http.StatusOK
+	if result.Status != dtos.ReadinessReady {
+		code = http.StatusServiceUnavailable
+	}
+	writeProbe(w, code, result)
+}
+
+// HandleDiagnostics handles GET /diagnostics
+func (c *HealthController) HandleDiagnostics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.healthService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("health service is not configured"), status.INTERNAL)
+		return
+	}
+
+	result, err := c.healthService.Diagnostics(ctx)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
+
+// writeProbe writes a probe result with a real HTTP status, unlike response.WriteJson
+func writeProbe(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"kokka.com/kokka/internal/applications/policy"
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/shared/constant/status"
+)
+
+// errInvalidParameters is returned when the request body cannot be decoded
+var errInvalidParameters = domain.NewValidationError("invalid parameters")
+
+// errorStatus maps a service error to the kstatus returned to the client
+// The kstatus doubles as the HTTP status when RESPONSE_HTTP_STATUS is enabled
+func errorStatus(err error) status.Code {
+	switch domain.ErrorCodeOf(err) {
+	case domain.ErrorCodeValidation:
+		return status.FAIL
+	case domain.ErrorCodeUnauthorized:
+		return status.UNAUTHORIZED
+	case domain.ErrorCodeMethodNotAllowed:
+		return status.FORBIDDEN
+	case domain.ErrorCodeNonceConflict:
+		return status.CONFLICT
+	case domain.ErrorCodeInsufficientBalance, domain.ErrorCodeInsufficientAllowance, domain.ErrorCodeExecutionReverted:
+		return status.UNPROCESSABLE
+	case domain.ErrorCodeQuotaExceeded:
+		return status.TOO_MANY_REQUESTS
+	case domain.ErrorCodeRPCUnavailable:
+		return status.UNAVAILABLE
+	}
+	if policy.IsUnauthorized(err) {
+		return status.UNAUTHORIZED
+	}
+	return status.INTERNAL
+}
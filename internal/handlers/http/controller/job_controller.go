@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"kokka.com/kokka/internal/applications/dtos"
+	diSvc "kokka.com/kokka/internal/core/di/services"
+	"kokka.com/kokka/internal/shared/constant/status"
+	"kokka.com/kokka/internal/shared/response"
+)
+
+type JobController struct {
+	jobService diSvc.IJobService
+}
+
+func NewJobController(jobService diSvc.IJobService) *JobController {
+	return &JobController{
+		jobService: jobService,
+	}
+}
+
+// HandleListJobs handles GET /admin/jobs
+func (c *JobController) HandleListJobs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.jobService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("job service is not configured"), status.INTERNAL)
+		return
+	}
+
+	result, err := c.jobService.ListJobs(ctx)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
+
+// HandleListJobRuns handles GET /admin/jobs/runs?name=&limit=
+func (c *JobController) HandleListJobRuns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.jobService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("job service is not configured"), status.INTERNAL)
+		return
+	}
+
+	query := r.URL.Query()
+	req := dtos.ListJobRunsRequest{
+		Name: query.Get("name"),
+	}
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
+			return
+		}
+		req.Limit = value
+	}
+
+	result, err := c.jobService.ListJobRuns(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
+
+// HandleTriggerJob handles POST /admin/jobs/trigger
+func (c *JobController) HandleTriggerJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.jobService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("job service is not configured"), status.INTERNAL)
+		return
+	}
+
+	var req dtos.JobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
+		return
+	}
+
+	result, err := c.jobService.TriggerJob(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.CREATED)
+}
+
+// HandlePauseJob handles POST /admin/jobs/pause
+func (c *JobController) HandlePauseJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.jobService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("job service is not configured"), status.INTERNAL)
+		return
+	}
+
+	var req dtos.JobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
+		return
+	}
+
+	result, err := c.jobService.PauseJob(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
+
+// HandleResumeJob handles POST /admin/jobs/resume
+func (c *JobController) HandleResumeJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.jobService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("job service is not configured"), status.INTERNAL)
+		return
+	}
+
+	var req dtos.JobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
+		return
+	}
+
+	result, err := c.jobService.ResumeJob(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
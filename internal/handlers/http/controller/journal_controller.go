@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"kokka.com/kokka/internal/applications/dtos"
+	diSvc "kokka.com/kokka/internal/core/di/services"
+	"kokka.com/kokka/internal/shared/constant/status"
+	"kokka.com/kokka/internal/shared/response"
+)
+
+type JournalController struct {
+	journalService diSvc.IJournalService
+}
+
+func NewJournalController(journalService diSvc.IJournalService) *JournalController {
+	return &JournalController{
+		journalService: journalService,
+	}
+}
+
+// HandleListTransactions handles GET /journal/list?status=&operation=&requested_by=&limit=
+func (c *JournalController) HandleListTransactions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.journalService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("journal service is not configured"), status.INTERNAL)
+		return
+	}
+
+	query := r.URL.Query()
+	req := dtos.ListJournalTransactionsRequest{
+		Status:      query.Get("status"),
+		Operation:   query.Get("operation"),
+		RequestedBy: query.Get("requested_by"),
+	}
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
+			return
+		}
+		req.Limit = value
+	}
+
+	result, err := c.journalService.ListTransactions(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
+
+// HandleGetTransaction handles POST /journal/info
+func (c *JournalController) HandleGetTransaction(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.journalService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("journal service is not configured"), status.INTERNAL)
+		return
+	}
+
+	var req dtos.GetJournalTransactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
+		return
+	}
+
+	result, err := c.journalService.GetTransaction(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
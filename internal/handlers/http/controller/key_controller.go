@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"kokka.com/kokka/internal/applications/dtos"
+	diSvc "kokka.com/kokka/internal/core/di/services"
+	"kokka.com/kokka/internal/shared/constant/status"
+	"kokka.com/kokka/internal/shared/response"
+)
+
+type KeyController struct {
+	keyService diSvc.IKeyService
+}
+
+func NewKeyController(keyService diSvc.IKeyService) *KeyController {
+	return &KeyController{
+		keyService: keyService,
+	}
+}
+
+// HandleGetKeyringInfo handles GET /admin/keys
+func (c *KeyController) HandleGetKeyringInfo(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.keyService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("key service is not configured"), status.INTERNAL)
+		return
+	}
+
+	result, err := c.keyService.GetKeyringInfo(ctx)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
+
+// HandleRewrapKeys handles POST /admin/keys/rewrap
+func (c *KeyController) HandleRewrapKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.keyService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("key service is not configured"), status.INTERNAL)
+		return
+	}
+
+	var req dtos.RewrapKeysRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
+		return
+	}
+
+	result, err := c.keyService.RewrapKeys(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
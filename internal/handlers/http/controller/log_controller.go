@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"kokka.com/kokka/internal/applications/dtos"
+	diSvc "kokka.com/kokka/internal/core/di/services"
+	"kokka.com/kokka/internal/shared/constant/status"
+	"kokka.com/kokka/internal/shared/response"
+)
+
+type LogController struct {
+	logService diSvc.ILogService
+}
+
+func NewLogController(logService diSvc.ILogService) *LogController {
+	return &LogController{
+		logService: logService,
+	}
+}
+
+// HandleGetLogLevel handles GET /admin/log-level
+func (c *LogController) HandleGetLogLevel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.logService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("log service is not configured"), status.INTERNAL)
+		return
+	}
+
+	result, err := c.logService.GetLogLevel(ctx)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
+
+// HandleSetLogLevel handles POST /admin/log-level
+func (c *LogController) HandleSetLogLevel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.logService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("log service is not configured"), status.INTERNAL)
+		return
+	}
+
+	var req dtos.SetLogLevelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
+		return
+	}
+
+	result, err := c.logService.SetLogLevel(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
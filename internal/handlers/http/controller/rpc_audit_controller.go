@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"kokka.com/kokka/internal/applications/dtos"
+	diSvc "kokka.com/kokka/internal/core/di/services"
+	"kokka.com/kokka/internal/shared/constant/status"
+	"kokka.com/kokka/internal/shared/response"
+)
+
+type RPCAuditController struct {
+	rpcAuditService diSvc.IRPCAuditService
+}
+
+func NewRPCAuditController(rpcAuditService diSvc.IRPCAuditService) *RPCAuditController {
+	return &RPCAuditController{
+		rpcAuditService: rpcAuditService,
+	}
+}
+
+// HandleListEntries handles GET /admin/rpc-audit?client_id=&method=&outcome=&limit=
+func (c *RPCAuditController) HandleListEntries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.rpcAuditService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("rpc audit service is not configured"), status.INTERNAL)
+		return
+	}
+
+	query := r.URL.Query()
+	req := dtos.ListRPCAuditRequest{
+		ClientID: query.Get("client_id"),
+		Method:   query.Get("method"),
+		Outcome:  query.Get("outcome"),
+	}
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
+			return
+		}
+		req.Limit = value
+	}
+
+	result, err := c.rpcAuditService.ListEntries(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
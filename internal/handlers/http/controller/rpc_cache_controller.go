@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"kokka.com/kokka/internal/applications/dtos"
+	diSvc "kokka.com/kokka/internal/core/di/services"
+	"kokka.com/kokka/internal/shared/constant/status"
+	"kokka.com/kokka/internal/shared/response"
+)
+
+type RPCCacheController struct {
+	rpcCacheService diSvc.IRPCCacheService
+}
+
+func NewRPCCacheController(rpcCacheService diSvc.IRPCCacheService) *RPCCacheController {
+	return &RPCCacheController{
+		rpcCacheService: rpcCacheService,
+	}
+}
+
+// HandleGetStats handles GET /admin/rpc-cache
+func (c *RPCCacheController) HandleGetStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.rpcCacheService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("rpc cache service is not configured"), status.INTERNAL)
+		return
+	}
+
+	result, err := c.rpcCacheService.GetStats(ctx)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
+
+// HandlePurge handles POST /admin/rpc-cache/purge
+func (c *RPCCacheController) HandlePurge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.rpcCacheService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("rpc cache service is not configured"), status.INTERNAL)
+		return
+	}
+
+	var req dtos.PurgeRPCCacheRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
+		return
+	}
+
+	result, err := c.rpcCacheService.Purge(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
@@ -32,13 +32,13 @@ func (c *SwapController) HandleSwap(w http.ResponseWriter, r *http.Request) {
 
 	var req dtos.SwapTokenRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteJson(w, ctx, nil, fmt.Errorf("invalid parameters"), status.FAIL)
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
 		return
 	}
 
 	result, err := c.swapService.Swap(ctx, &req)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.INTERNAL)
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
 		return
 	}
 
@@ -56,13 +56,13 @@ func (c *SwapController) HandleGetSwapQuote(w http.ResponseWriter, r *http.Reque
 
 	var req dtos.GetSwapQuoteRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteJson(w, ctx, nil, fmt.Errorf("invalid parameters"), status.FAIL)
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
 		return
 	}
 
 	result, err := c.swapService.GetQuote(ctx, &req)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.INTERNAL)
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
 		return
 	}
 
@@ -80,13 +80,13 @@ func (c *SwapController) HandleGetSwapInfo(w http.ResponseWriter, r *http.Reques
 
 	var req dtos.GetSwapInfoRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteJson(w, ctx, nil, fmt.Errorf("invalid parameters"), status.FAIL)
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
 		return
 	}
 
 	result, err := c.swapService.GetSwapInfo(ctx, &req)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.INTERNAL)
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
 		return
 	}
 
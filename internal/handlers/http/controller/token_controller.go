@@ -32,13 +32,13 @@ func (c *TokenController) HandleMintToken(w http.ResponseWriter, r *http.Request
 
 	var req dtos.MintTokenRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteJson(w, ctx, nil, fmt.Errorf("invalid parameters"), status.FAIL)
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
 		return
 	}
 
 	result, err := c.tokenService.Mint(ctx, &req)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.INTERNAL)
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
 		return
 	}
 
@@ -56,13 +56,13 @@ func (c *TokenController) HandleBurnToken(w http.ResponseWriter, r *http.Request
 
 	var req dtos.BurnTokenRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteJson(w, ctx, nil, fmt.Errorf("invalid parameters"), status.FAIL)
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
 		return
 	}
 
 	result, err := c.tokenService.Burn(ctx, &req)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.INTERNAL)
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
 		return
 	}
 
@@ -80,13 +80,13 @@ func (c *TokenController) HandleGetTokenBalance(w http.ResponseWriter, r *http.R
 
 	var req dtos.GetTokenBalanceRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteJson(w, ctx, nil, fmt.Errorf("invalid parameters"), status.FAIL)
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
 		return
 	}
 
 	result, err := c.tokenService.GetBalance(ctx, &req)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.INTERNAL)
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
 		return
 	}
 
@@ -116,13 +116,13 @@ func (c *TokenController) HandleTransferToken(w http.ResponseWriter, r *http.Req
 
 	var req dtos.TransferTokenRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteJson(w, ctx, nil, fmt.Errorf("invalid parameters"), status.FAIL)
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
 		return
 	}
 
 	result, err := c.tokenService.Transfer(ctx, &req)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.INTERNAL)
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
 		return
 	}
 
@@ -144,13 +144,13 @@ func (c *TokenController) HandleGetContractAddressInfo(w http.ResponseWriter, r
 	}
 	var req dtos.GetAddressInfoRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteJson(w, ctx, nil, fmt.Errorf("invalid parameters"), status.FAIL)
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
 		return
 	}
 
 	result, err := c.tokenService.GetAddressInfo(ctx, &req)
 	if err != nil {
-		response.WriteJson(w, ctx, nil, err, status.INTERNAL)
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
 		return
 	}
 
@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"kokka.com/kokka/internal/applications/dtos"
+	diSvc "kokka.com/kokka/internal/core/di/services"
+	"kokka.com/kokka/internal/shared/constant/status"
+	"kokka.com/kokka/internal/shared/response"
+)
+
+type WalletController struct {
+	walletService diSvc.IWalletService
+}
+
+func NewWalletController(walletService diSvc.IWalletService) *WalletController {
+	return &WalletController{
+		walletService: walletService,
+	}
+}
+
+// HandleCreateWallet handles POST /wallet/create
+func (c *WalletController) HandleCreateWallet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.walletService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("wallet service is not configured"), status.INTERNAL)
+		return
+	}
+
+	var req dtos.CreateWalletRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
+		return
+	}
+
+	result, err := c.walletService.CreateWallet(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.CREATED)
+}
+
+// HandleImportWallet handles POST /wallet/import
+func (c *WalletController) HandleImportWallet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.walletService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("wallet service is not configured"), status.INTERNAL)
+		return
+	}
+
+	var req dtos.ImportWalletRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
+		return
+	}
+
+	result, err := c.walletService.ImportWallet(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.CREATED)
+}
+
+// HandleRegisterWallet handles POST /wallet/register
+func (c *WalletController) HandleRegisterWallet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.walletService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("wallet service is not configured"), status.INTERNAL)
+		return
+	}
+
+	var req dtos.RegisterWalletRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
+		return
+	}
+
+	result, err := c.walletService.RegisterWallet(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.CREATED)
+}
+
+// HandleDeriveWallet handles POST /wallet/derive
+func (c *WalletController) HandleDeriveWallet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.walletService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("wallet service is not configured"), status.INTERNAL)
+		return
+	}
+
+	var req dtos.DeriveWalletRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
+		return
+	}
+
+	result, err := c.walletService.DeriveWallet(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
+
+// HandleResolveDerivedAddress handles GET /wallet/derive/address?address=0x...
+func (c *WalletController) HandleResolveDerivedAddress(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.walletService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("wallet service is not configured"), status.INTERNAL)
+		return
+	}
+
+	req := dtos.ResolveDerivedAddressRequest{Address: r.URL.Query().Get("address")}
+
+	result, err := c.walletService.ResolveDerivedAddress(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
+
+// HandleGetWallet handles POST /wallet/info
+func (c *WalletController) HandleGetWallet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.walletService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("wallet service is not configured"), status.INTERNAL)
+		return
+	}
+
+	var req dtos.GetWalletRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteJson(w, ctx, nil, errInvalidParameters, status.FAIL)
+		return
+	}
+
+	result, err := c.walletService.GetWallet(ctx, &req)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
+
+// HandleListWallets handles GET /wallet/list
+func (c *WalletController) HandleListWallets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if c.walletService == nil {
+		response.WriteJson(w, ctx, nil, fmt.Errorf("wallet service is not configured"), status.INTERNAL)
+		return
+	}
+
+	result, err := c.walletService.ListWallets(ctx)
+	if err != nil {
+		response.WriteJson(w, ctx, nil, err, errorStatus(err))
+		return
+	}
+
+	response.WriteJson(w, ctx, result, nil, status.OK)
+}
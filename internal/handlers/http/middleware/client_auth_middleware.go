@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"kokka.com/kokka/internal/applications/policy"
+	"kokka.com/kokka/internal/shared/constant/status"
+	"kokka.com/kokka/internal/shared/logger"
+	"kokka.com/kokka/internal/shared/response"
+)
+
+// APIKeyHeader is the header API clients use to identify themselves
+const APIKeyHeader = "X-API-Key"
+
+// policyOnlyPaths manage keys, custodial wallets, the journal and the server itself; without a policy nobody
+// can be granted them, so they are refused
+var policyOnlyPaths = []string{"/admin", "/wallet", "/journal", "/diagnostics", "/metrics"}
+
+// ClientAuthMiddleware identifies the API client from its key and checks it may call the route
+// Resource-level checks (tokens, pools, amounts) are enforced by the service layer
+// A nil policy allows every route except policyOnlyPaths
+func ClientAuthMiddleware(p *policy.Policy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			if p == nil {
+				for _, path := range policyOnlyPaths {
+					if r.URL.Path == path || strings.HasPrefix(r.URL.Path, path+"/") {
+						err := policy.Unauthorized(fmt.Sprintf("%s %s requires an access policy (POLICY_FILE)", r.Method, r.URL.Path))
+						if log := logger.GetLogger(ctx); log != nil {
+							log.Warnf("clientAuthMiddleware: %v", err)
+						}
+						response.WriteJson(w, ctx, nil, err, status.UNAUTHORIZED)
+						return
+					}
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			identity, err := p.Identify(r.Header.Get(APIKeyHeader))
+			if err != nil {
+				response.WriteJson(w, ctx, nil, err, status.UNAUTHORIZED)
+				return
+			}
+			identity.Route = fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+			if log := logger.GetLogger(ctx); log != nil {
+				log.SetClient(identity.ClientID)
+			}
+
+			if err := p.AuthorizeRoute(identity, identity.Route); err != nil {
+				if log := logger.GetLogger(ctx); log != nil {
+					log.Warnf("clientAuthMiddleware: %v", err)
+				}
+				response.WriteJson(w, ctx, nil, err, status.UNAUTHORIZED)
+				return
+			}
+
+			ctx = policy.WithIdentity(ctx, identity)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"kokka.com/kokka/internal/applications/policy"
+)
+
+func TestClientAuthWithoutPolicy(t *testing.T) {
+	handler := ClientAuthMiddleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	tests := []struct {
+		route   string
+		allowed bool
+	}{
+		{"GET /blockchain/block-number", true},
+		{"POST /token/transfer", true},
+		{"GET /healthz", true},
+		{"GET /administrator", true},
+		{"GET /admin/keys", false},
+		{"POST /admin/jobs/trigger", false},
+		{"POST /wallet/create", false},
+		{"POST /wallet/derive", false},
+		{"GET /journal/list", false},
+		{"POST /journal/info", false},
+		{"GET /diagnostics", false},
+		{"GET /metrics", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.route, func(t *testing.T) {
+			method, path, _ := strings.Cut(tt.route, " ")
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+			if allowed := recorder.Code == http.StatusTeapot; allowed != tt.allowed {
+				t.Errorf("allowed = %v, want %v (status %d)", allowed, tt.allowed, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestClientAuthWithPolicy(t *testing.T) {
+	p, err := policy.New(&policy.Config{
+		AnonymousRoles: []string{"public"},
+		Roles: map[string]policy.RoleConfig{
+			"public": {Routes: []string{"GET /healthz"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("policy.New: %v", err)
+	}
+	handler := ClientAuthMiddleware(p)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if policy.GetIdentity(r.Context()) == nil {
+			t.Error("identity is not set")
+		}
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if recorder.Code != http.StatusTeapot {
+		t.Errorf("GET /healthz: status %d", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/admin/keys", nil))
+	if recorder.Code == http.StatusTeapot {
+		t.Error("GET /admin/keys was allowed")
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"kokka.com/kokka/internal/shared/constant/status"
+	"kokka.com/kokka/internal/shared/lifecycle"
+	"kokka.com/kokka/internal/shared/response"
+)
+
+// DrainMiddleware tracks write requests so shutdown can wait for them, and refuses new ones once draining
+// A refused request was not started, so the client can safely retry it on another instance
+func DrainMiddleware(drainer *lifecycle.Drainer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !drainer.Acquire() {
+				w.Header().Set("Retry-After", "1")
+				response.WriteJson(w, r.Context(), nil, errors.New("server is shutting down"), status.UNAVAILABLE)
+				return
+			}
+			defer drainer.Release()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	diSvc "kokka.com/kokka/internal/core/di/services"
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/shared/constant/status"
+	"kokka.com/kokka/internal/shared/logger"
+	"kokka.com/kokka/internal/shared/response"
+)
+
+// IdempotencyKeyHeader is the header clients use to make a write safe to retry
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader marks a response replayed from an earlier request
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// IdempotencyMiddleware replays the stored response for a repeated Idempotency-Key instead of running the handler again
+// Requests are matched by key per client and by a fingerprint of method, path and body
+// Only responses of successful or signing requests are stored, see keepResponse
+// Requests without the header and dry runs are passed through unchanged
+func IdempotencyMiddleware(svc diSvc.IIdempotencyService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || svc == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				response.WriteJson(w, ctx, nil, errors.New("invalid parameters"), status.FAIL)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			// Dry runs have no side effects, so they neither reserve nor replay the key
+			if isDryRun(body) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			record, err := svc.Begin(ctx, key, requestFingerprint(r, body))
+			if err != nil {
+				if log := logger.GetLogger(ctx); log != nil {
+					log.Warnf("idempotencyMiddleware: %v", err)
+				}
+				code := status.INTERNAL
+				if errors.Is(err, domain.ErrIdempotencyKeyReused) || errors.Is(err, domain.ErrIdempotencyKeyInProgress) {
+					code = status.CONFLICT
+				}
+				response.WriteJson(w, ctx, nil, err, code)
+				return
+			}
+
+			// Replay the stored response without running the handler
+			if record != nil {
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
+				w.Header().Set("X-Content-Type-Options", "nosniff")
+				w.Header().Set(IdempotentReplayedHeader, "true")
+				w.WriteHeader(record.ResponseCode)
+				_, _ = w.Write(record.ResponseBody)
+				return
+			}
+
+			// Free the key if the handler panics so the client can retry
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					svc.Release(ctx, key)
+					panic(recovered)
+				}
+			}()
+
+			wrapper := &responseWriterWrapper{ResponseWriter: w, body: bytes.NewBuffer(nil)}
+			next.ServeHTTP(wrapper, r)
+
+			code := wrapper.statusCode
+			if code == 0 {
+				code = http.StatusOK
+			}
+			if keepResponse(ctx) {
+				svc.Complete(ctx, key, code, wrapper.body.Bytes())
+			} else {
+				svc.Release(ctx, key)
+			}
+
+			w.WriteHeader(code)
+			_, _ = w.Write(wrapper.body.Bytes())
+		})
+	}
+}
+
+// keepResponse reports whether the response must be replayed on retry
+// Failures that signed nothing, retryable or not, release the key so the request can be retried;
+// once a transaction was signed the response is kept even if sending failed, as the transaction
+// may have reached the network and a retry would sign a second one
+func keepResponse(ctx context.Context) bool {
+	outcome := response.GetOutcome(ctx)
+	if outcome == nil {
+		return true // Without an outcome it is unknown whether anything was signed
+	}
+	return outcome.TxHash != "" || outcome.KStatus < status.FAIL
+}
+
+// requestFingerprint hashes what identifies a request, so a reused key with another request is detected
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// isDryRun reports whether a write request body asks for a dry run
+func isDryRun(body []byte) bool {
+	var req struct {
+		DryRun bool `json:"dry_run"`
+	}
+	return json.Unmarshal(body, &req) == nil && req.DryRun
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"kokka.com/kokka/internal/core/domain"
+	"kokka.com/kokka/internal/shared/constant/status"
+	"kokka.com/kokka/internal/shared/response"
+)
+
+// fakeIdempotencyService records how the key was settled
+type fakeIdempotencyService struct {
+	completed bool
+	released  bool
+}
+
+func (s *fakeIdempotencyService) Begin(ctx context.Context, key string, fingerprint string) (*domain.IdempotencyRecord, error) {
+	return nil, nil
+}
+
+func (s *fakeIdempotencyService) Complete(ctx context.Context, key string, responseCode int, responseBody []byte) {
+	s.completed = true
+}
+
+func (s *fakeIdempotencyService) Release(ctx context.Context, key string) {
+	s.released = true
+}
+
+func (s *fakeIdempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func TestIdempotencySettlesKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		err    error
+		txHash string
+		keep   bool
+	}{
+		{"success", status.OK, nil, "0x01", true},
+		{"validation error", status.FAIL, errors.New("invalid amount"), "", false},
+		{"retryable error before signing", status.UNAVAILABLE, domain.NewError(domain.ErrorCodeRPCUnavailable, "node down", nil), "", false},
+		{"send failed after signing", status.UNAVAILABLE, domain.NewError(domain.ErrorCodeRPCUnavailable, "node down", nil), "0x01", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &fakeIdempotencyService{}
+			handler := IdempotencyMiddleware(svc)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.txHash != "" {
+					response.RecordTxHash(r.Context(), tt.txHash)
+				}
+				response.WriteJson(w, r.Context(), nil, tt.err, tt.status)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/token/transfer", strings.NewReader(`{"amount":"1"}`))
+			req.Header.Set(IdempotencyKeyHeader, "key-1")
+			req = req.WithContext(response.WithOutcome(req.Context(), &response.Outcome{}))
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if svc.completed != tt.keep || svc.released == tt.keep {
+				t.Errorf("completed = %v, released = %v, want the response kept = %v", svc.completed, svc.released, tt.keep)
+			}
+		})
+	}
+}
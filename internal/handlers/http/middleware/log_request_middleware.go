@@ -8,16 +8,14 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
-	"sync"
 
 	"kokka.com/kokka/internal/shared/logger"
+	"kokka.com/kokka/internal/shared/redact"
 )
 
 type requestLoggerMiddleware struct {
 	hiddenFieldsRegex *regexp.Regexp
 	logHeaders        bool
-	mutex             sync.Mutex
-	reqID             int64
 }
 
 func LogRequestMiddleware(next http.Handler) http.Handler {
@@ -45,9 +43,7 @@ func newRequestLoggerMiddleware() *requestLoggerMiddleware {
 
 func (m *requestLoggerMiddleware) Handle(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		var (
-			reqID int64 = m.nextRequestID()
-		)
+		reqID := logger.RequestID(r.Context())
 
 		log := logger.GetLogger(r.Context())
 
@@ -59,24 +55,26 @@ func (m *requestLoggerMiddleware) Handle(next http.Handler) http.Handler {
 
 		log.InfofWithBgColor(logger.BgYellow, "IN <%v> %v %v", reqID, r.Method, r.URL.Path)
 
+		redactor := redact.Default()
+
 		if r.Method == http.MethodGet && strings.TrimSpace(r.URL.RawQuery) != "" {
-			log.Infof("IN QUERY PARAMS %v", r.URL.RawQuery)
+			log.Infof("IN QUERY PARAMS %v", redactor.Text(r.URL.RawQuery))
 		}
 
 		if isJSON {
-			truncatedBodyBytes := m.truncateSensitiveFields(rawBody.Bytes())
+			truncatedBodyBytes := m.truncateSensitiveFields(redactor.Body(rawBody.Bytes()))
 			log.Infof("IN REQUEST BODY %v", string(truncatedBodyBytes))
 		} else {
 			mapBody := m.decodeBodyToMap(rawBody.Bytes())
 			if len(mapBody) > 0 {
-				log.Infof("IN REQUEST BODY %v", mapBody)
+				log.Infof("IN REQUEST BODY %v", redactor.Map(mapBody))
 			}
 		}
 
 		if m.logHeaders {
 			for name, values := range r.Header {
 				for _, value := range values {
-					log.Infof("IN HEADER %v: %v", name, value)
+					log.Infof("IN HEADER %v: %v", name, redactor.Header(name, value))
 				}
 			}
 		}
@@ -84,7 +82,7 @@ func (m *requestLoggerMiddleware) Handle(next http.Handler) http.Handler {
 		wrapper := m.newResponseWrapper(w)
 		next.ServeHTTP(wrapper, r)
 
-		log.InfofWithBgColor(logger.BgYellow, "OUT <%v> %v %v \n%s", reqID, r.Method, r.URL.Path, wrapper.body.String())
+		log.InfofWithBgColor(logger.BgYellow, "OUT <%v> %v %v \n%s", reqID, r.Method, r.URL.Path, redactor.Body(wrapper.body.Bytes()))
 
 		m.flushResponse(w, wrapper)
 	})
@@ -97,15 +95,6 @@ func (m *requestLoggerMiddleware) newResponseWrapper(w http.ResponseWriter) *res
 	}
 }
 
-func (m *requestLoggerMiddleware) nextRequestID() int64 {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
-
-	next := m.reqID
-	m.reqID++
-	return next
-}
-
 func (m *requestLoggerMiddleware) readRequestBody(r *http.Request) (*bytes.Buffer, bool, error) {
 	rawBody := new(bytes.Buffer)
 	if _, err := rawBody.ReadFrom(r.Body); err != nil {
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"kokka.com/kokka/internal/shared/config"
+	"kokka.com/kokka/internal/shared/logger"
+)
+
+const (
+	testPrivateKey = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	testCiphertext = "U2FsdGVkX1+XkQ7cJv5b0lE3yZ9qv2Hk7Vt4xGm8pB0sN6wRfYc1DaA3uLzTeI9o"
+	testRawTx      = "0xf86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"
+	testAPIKey     = "client-api-key-value"
+	testPassphrase = "decryption-passphrase-value"
+)
+
+// TestLogFileHasNoSecrets writes requests carrying secrets through the LOG_FILE_PATH output
+// and checks the file contains none of them
+func TestLogFileHasNoSecrets(t *testing.T) {
+	for _, format := range []string{string(logger.FormatText), string(logger.FormatJSON)} {
+		t.Run(format, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "logs", "server.log")
+			if err := logger.Setup(&config.LogConfig{File: path, Format: format, Level: "debug", MaxSizeMB: 10}); err != nil {
+				t.Fatalf("Setup: %v", err)
+			}
+			t.Cleanup(func() { _ = logger.Close() })
+
+			handler := LoggerMiddleware(LogRequestMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				logger.GetLogger(r.Context()).Infof("decrypted %s with key %s", testCiphertext, testPrivateKey)
+				logger.Warn("fallback key %s,%s", testPrivateKey, testPrivateKey)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = io.WriteString(w, `{"status":"OK","data":{"encrypted_private_key":"`+testCiphertext+`"}}`)
+			})))
+
+			requests := []*http.Request{
+				httptest.NewRequest(http.MethodPost, "/wallet/import",
+					strings.NewReader(`{"private_key":"`+testPrivateKey+`","encrypted_private_key":"`+testCiphertext+`"}`)),
+				httptest.NewRequest(http.MethodPost, "/rpc",
+					strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction","params":["`+testRawTx+`"]}`)),
+				httptest.NewRequest(http.MethodPost, "/rpc",
+					strings.NewReader(`[{"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction","params":["`+testRawTx+`"]}]`)),
+			}
+			for _, req := range requests {
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("Authorization", "Bearer "+testAPIKey)
+				req.Header.Set("X-Api-Key", testAPIKey)
+				req.Header.Set("X-Decryption-Key", testPassphrase)
+				handler.ServeHTTP(httptest.NewRecorder(), req)
+			}
+
+			if err := logger.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read log file: %v", err)
+			}
+			if !strings.Contains(string(content), "/wallet/import") {
+				t.Fatalf("log file has no request lines:\n%s", content)
+			}
+			for _, secret := range []string{testPrivateKey, testCiphertext, testRawTx, testAPIKey, testPassphrase} {
+				if strings.Contains(string(content), secret) {
+					t.Errorf("log file contains %q:\n%s", secret, content)
+				}
+			}
+		})
+	}
+}
@@ -9,11 +9,19 @@ import (
 // LoggerMiddleware adds structured logging context to requests
 // It extracts session information and adds it to the logger context
 // for better observability and debugging
-func LoggerMiddleware(outFilePath string) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx := logger.WithLogger(r.Context(), logger.NewRequestScopedLogger(r, outFilePath))
-			next.ServeHTTP(w, r.WithContext(ctx))
-		})
-	}
+// Each request gets an X-Request-ID, accepted from the caller when valid, which is
+// logged on every line and returned in the response
+func LoggerMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(logger.RequestIDHeader)
+		if !logger.ValidRequestID(requestID) {
+			requestID = logger.NewRequestID()
+		}
+		w.Header().Set(logger.RequestIDHeader, requestID)
+		r = r.WithContext(logger.WithRequestID(r.Context(), requestID))
+
+		log := logger.NewRequestScopedLogger(r)
+		ctx := logger.WithLogger(r.Context(), log)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
 }
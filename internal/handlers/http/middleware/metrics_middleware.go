@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"kokka.com/kokka/internal/shared/metrics"
+	"kokka.com/kokka/internal/shared/response"
+)
+
+// MetricsMiddleware records the count and latency of every request by route and kstatus
+// Register it first so requests rejected by later middleware are counted too
+func MetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started := time.Now()
+		outcome := &response.Outcome{}
+
+		next.ServeHTTP(w, r.WithContext(response.WithOutcome(r.Context(), outcome)))
+
+		metrics.ObserveHTTPRequest(outcome.Route, int(outcome.KStatus), time.Since(started))
+	})
+}
+
+// RouteMiddleware reports the route pattern matched by the router to MetricsMiddleware
+// Register it last: the router sets the pattern on the request it is handed
+func RouteMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+
+		if outcome := response.GetOutcome(r.Context()); outcome != nil {
+			outcome.Route = r.Pattern
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"kokka.com/kokka/internal/shared/response"
+	"kokka.com/kokka/internal/shared/tracing"
+)
+
+// untracedPaths are polled by probes and scrapers and would only add noise
+var untracedPaths = []string{"/healthz", "/readyz", "/metrics"}
+
+// TracingMiddleware opens a server span per request, continuing the caller's W3C traceparent
+// Register it after MetricsMiddleware, which provides the matched route and kstatus
+func TracingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, path := range untracedPaths {
+			if r.URL.Path == path || strings.HasPrefix(r.URL.Path, path+"/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracing.StartKind(ctx, r.Method, trace.SpanKindServer,
+			semconv.HTTPRequestMethodKey.String(r.Method),
+			semconv.URLPath(r.URL.Path),
+		)
+		defer span.End()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+
+		outcome := response.GetOutcome(ctx)
+		if outcome == nil {
+			return
+		}
+		if outcome.Route != "" {
+			span.SetName(outcome.Route)
+			span.SetAttributes(semconv.HTTPRoute(outcome.Route))
+		}
+		if outcome.KStatus != 0 {
+			span.SetAttributes(tracing.AttrKStatus.Int(int(outcome.KStatus)))
+			if outcome.KStatus >= 400 {
+				span.SetStatus(codes.Error, "")
+			}
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"kokka.com/kokka/internal/shared/config"
+	"kokka.com/kokka/internal/shared/constant/status"
+	"kokka.com/kokka/internal/shared/http_client"
+	"kokka.com/kokka/internal/shared/response"
+	"kokka.com/kokka/internal/shared/tracing"
+)
+
+const (
+	testTraceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testParentSpanID = "00f067aa0ba902b7"
+)
+
+// exportedSpan is the part of a file exporter span line the tests check
+type exportedSpan struct {
+	Name        string
+	SpanContext struct{ TraceID, SpanID string }
+	Parent      struct{ TraceID, SpanID string }
+	SpanKind    trace.SpanKind
+	Attributes  []struct {
+		Key   string
+		Value struct{ Value any }
+	}
+	Status struct{ Code string }
+}
+
+func (s *exportedSpan) attribute(key string) string {
+	for _, attr := range s.Attributes {
+		if attr.Key == key {
+			return fmt.Sprint(attr.Value.Value)
+		}
+	}
+	return ""
+}
+
+// readSpans decodes the spans written by the file exporter, by name
+func readSpans(t *testing.T, path string) map[string]*exportedSpan {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open trace file: %v", err)
+	}
+	defer file.Close()
+
+	spans := make(map[string]*exportedSpan)
+	decoder := json.NewDecoder(file)
+	for {
+		span := &exportedSpan{}
+		if err := decoder.Decode(span); err == io.EOF {
+			return spans
+		} else if err != nil {
+			t.Fatalf("decode span: %v", err)
+		}
+		spans[span.Name] = span
+	}
+}
+
+// TestTracingMiddleware serves requests through the middleware chain with the file exporter and
+// checks the server span continues the caller's traceparent, which is forwarded to outgoing calls
+func TestTracingMiddleware(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces", "spans.json")
+	provider, err := tracing.Setup(&config.TracingConfig{Exporter: tracing.ExporterFile, File: path, SampleRatio: 1, ServiceName: "kokka-test"})
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	// The downstream service records the traceparent it is sent
+	var forwarded string
+	downstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = r.Header.Get("traceparent")
+	}))
+	defer downstream.Close()
+	client := http_client.NewClient(http_client.WithBaseURL(downstream.URL))
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /token/transfer", RouteMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := tracing.Start(r.Context(), "TokenService.TransferToken")
+		defer span.End()
+		if _, err := client.Get(ctx, "/"); err != nil {
+			t.Errorf("downstream call: %v", err)
+		}
+		response.WriteJson(w, ctx, nil, errors.New("insufficient balance"), status.FAIL)
+	})))
+	mux.Handle("GET /healthz", RouteMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+	handler := MetricsMiddleware(TracingMiddleware(mux))
+
+	req := httptest.NewRequest(http.MethodPost, "/token/transfer", strings.NewReader(`{}`))
+	req.Header.Set("traceparent", "00-"+testTraceID+"-"+testParentSpanID+"-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	if err := provider.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	spans := readSpans(t, path)
+
+	server := spans["POST /token/transfer"]
+	if server == nil {
+		t.Fatalf("no server span named after the route in %v", spans)
+	}
+	if server.SpanKind != trace.SpanKindServer {
+		t.Errorf("server span kind = %v", server.SpanKind)
+	}
+	if server.SpanContext.TraceID != testTraceID || server.Parent.SpanID != testParentSpanID {
+		t.Errorf("server span trace %s, parent %s: the incoming traceparent was not continued", server.SpanContext.TraceID, server.Parent.SpanID)
+	}
+	if route := server.attribute("http.route"); route != "POST /token/transfer" {
+		t.Errorf("http.route = %q", route)
+	}
+	if kstatus := server.attribute(string(tracing.AttrKStatus)); kstatus != "400" {
+		t.Errorf("kokka.kstatus = %q, want 400", kstatus)
+	}
+	if server.Status.Code != "Error" {
+		t.Errorf("server span status = %q, want Error", server.Status.Code)
+	}
+
+	child := spans["TokenService.TransferToken"]
+	if child == nil || child.Parent.SpanID != server.SpanContext.SpanID || child.SpanContext.TraceID != testTraceID {
+		t.Fatalf("service span %+v is not a child of the server span", child)
+	}
+	if want := "00-" + testTraceID + "-" + child.SpanContext.SpanID + "-01"; forwarded != want {
+		t.Errorf("downstream traceparent = %q, want %q", forwarded, want)
+	}
+
+	for name, span := range spans {
+		if span.SpanKind == trace.SpanKindServer && span != server {
+			t.Errorf("unexpected server span %s: /healthz is not traced", name)
+		}
+	}
+}
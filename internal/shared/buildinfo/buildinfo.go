@@ -0,0 +1,44 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Version is set at build time:
+//
+//	go build -ldflags "-X kokka.com/kokka/internal/shared/buildinfo.Version=v1.2.3"
+var Version = "dev"
+
+// Info describes the running binary
+type Info struct {
+	Version      string
+	Revision     string // VCS commit, empty when built outside a checkout
+	RevisionTime string
+	Modified     bool // Built from a checkout with uncommitted changes
+	GoVersion    string
+}
+
+// Get returns the build info of the running binary
+func Get() *Info {
+	info := &Info{
+		Version:   Version,
+		GoVersion: runtime.Version(),
+	}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Revision = setting.Value
+		case "vcs.time":
+			info.RevisionTime = setting.Value
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+	return info
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -16,9 +17,22 @@ type Env struct {
 	MailerConfig          *MailerConfig
 	S3Config              *S3Config
 	BlockchainConfig      *BlockchainConfig
+	WalletConfig          *WalletConfig
+	SignerConfig          *SignerConfig
+	HDWalletConfig        *HDWalletConfig
+	SweeperConfig         *SweeperConfig
+	HealthConfig          *HealthConfig
+	TracingConfig         *TracingConfig
+	RedactionConfig       *RedactionConfig
+	IdempotencyConfig     *IdempotencyConfig
+	JobsConfig            *JobsConfig
+	IndexerConfig         *IndexerConfig
+	PolicyFile            string
+	RPCProxyConfig        *RPCProxyConfig
+	RPCCacheConfig        *RPCCacheConfig
 	SharedKeyBytes        []byte
 	GexSessionDriver      string
-	LogFile               string
+	LogConfig             *LogConfig
 	SerializedSessionFile string
 }
 
@@ -30,12 +44,16 @@ func NewEnv(envpath string) (*Env, error) {
 
 	result := &Env{
 		DBEnv: &DBConfig{
+			Driver:   getConfig("DB_DRIVER"),
 			Host:     getConfig("DB_HOST"),
 			Port:     getConfig("DB_PORT"),
 			User:     getConfig("DB_USER"),
 			Password: getConfig("DB_PASSWORD"),
 			Name:     getConfig("DB_NAME"),
 			SSLMode:  getConfig("DB_SSL_MODE"),
+
+			MigrationsDir:          getConfigWithDefault("MIGRATIONS_DIR", "migrations"),
+			AllowPendingMigrations: getBoolConfig("ALLOW_PENDING_MIGRATIONS"),
 		},
 		HostConfig: &HostConfig{
 			ServerMode:    getConfig("SERVER_MODE"),
@@ -43,6 +61,9 @@ func NewEnv(envpath string) (*Env, error) {
 			ServerPort:    getConfig("SERVER_PORT"),
 			HttpsCertFile: getConfigOptional("HTTPS_CERT_FILE"),
 			HttpsKeyFile:  getConfigOptional("HTTPS_KEY_FILE"),
+
+			ShutdownTimeoutSeconds: getIntConfigWithDefault("SHUTDOWN_TIMEOUT_SECONDS", 30),
+			HTTPStatusCodes:        getBoolConfig("RESPONSE_HTTP_STATUS"),
 		},
 		TwilioConfig: &TwilioConfig{
 			AccountSID:          getConfigOptional("TWILIO_ACCOUNT_SID"),
@@ -64,12 +85,102 @@ func NewEnv(envpath string) (*Env, error) {
 			Bucket:    getConfig("S3_BUCKET"),
 		},
 		BlockchainConfig: &BlockchainConfig{
-			RPCURL:        getConfigWithDefault("BLOCKCHAIN_RPC_URL", "https://x24.i247.com"),
-			DecryptionKey: getConfig("DECRYPTION_KEY"),
+			RPCURL:         getConfigWithDefault("BLOCKCHAIN_RPC_URL", "https://x24.i247.com"),
+			ChainID:        getConfig("BLOCKCHAIN_CHAIN_ID"),
+			DecryptionKey:  getConfig("DECRYPTION_KEY"),
+			DecryptionKeys: getConfig("DECRYPTION_KEYS"),
+			ActiveKeyID:    getConfig("DECRYPTION_ACTIVE_KEY_ID"),
+			EnvelopeKDF:    getConfigWithDefault("ENVELOPE_KDF", "scrypt"),
+
+			EnvelopeAcceptKDFs:     getListConfig("ENVELOPE_ACCEPT_KDFS"),
+			EnvelopeMaxDerivations: getIntConfigWithDefault("ENVELOPE_MAX_DERIVATIONS", 4),
+
+			MaxRetries:          getIntConfigWithDefault("BLOCKCHAIN_RPC_MAX_RETRIES", 3),
+			RetryDelayMillis:    getIntConfigWithDefault("BLOCKCHAIN_RPC_RETRY_DELAY_MS", 250),
+			RetryMaxDelayMillis: getIntConfigWithDefault("BLOCKCHAIN_RPC_RETRY_MAX_DELAY_MS", 5000),
+		},
+		WalletConfig: &WalletConfig{
+			MasterKey: getConfig("WALLET_MASTER_KEY"),
+			StorePath: getConfigWithDefault("WALLET_STORE_PATH", "data/wallets.json"),
+		},
+		SignerConfig: &SignerConfig{
+			KeystoreDir:            getConfigWithDefault("KEYSTORE_DIR", "data/keystore"),
+			KeystorePassphraseFile: getConfig("KEYSTORE_PASSPHRASE_FILE"),
+			RemoteURL:              getConfig("REMOTE_SIGNER_URL"),
+			RemoteAuthTokenFile:    getConfig("REMOTE_SIGNER_TOKEN_FILE"),
+		},
+		HDWalletConfig: &HDWalletConfig{
+			MnemonicFile: getConfig("HD_MNEMONIC_FILE"),
+			Passphrase:   getConfig("HD_PASSPHRASE"),
+		},
+		SweeperConfig: &SweeperConfig{
+			Enabled:         getBoolConfig("SWEEPER_ENABLED"),
+			TreasuryAddress: getConfig("SWEEPER_TREASURY_ADDRESS"),
+			Tokens:          getListConfig("SWEEPER_TOKENS"),
+			Threshold:       getConfigWithDefault("SWEEPER_THRESHOLD", "0"),
+			IntervalSeconds: getIntConfigWithDefault("SWEEPER_INTERVAL_SECONDS", 300),
+			GasWalletID:     getConfig("SWEEPER_GAS_WALLET_ID"),
+		},
+		HealthConfig: &HealthConfig{
+			MaxBlockAgeSeconds:  getIntConfigWithDefault("HEALTH_MAX_BLOCK_AGE_SECONDS", 120),
+			CheckTimeoutSeconds: getIntConfigWithDefault("HEALTH_CHECK_TIMEOUT_SECONDS", 5),
+		},
+		TracingConfig: &TracingConfig{
+			Exporter:     getConfigWithDefault("TRACING_EXPORTER", "none"),
+			OTLPEndpoint: getConfig("TRACING_OTLP_ENDPOINT"),
+			OTLPInsecure: getBoolConfig("TRACING_OTLP_INSECURE"),
+			File:         getConfigWithDefault("TRACING_FILE", "logs/traces.jsonl"),
+			SampleRatio:  getFloatConfigWithDefault("TRACING_SAMPLE_RATIO", 1),
+			ServiceName:  getConfigWithDefault("TRACING_SERVICE_NAME", "kokka"),
+		},
+		RedactionConfig: &RedactionConfig{
+			Paths:       getListConfig("LOG_REDACT_PATHS"),
+			KeyPatterns: getListConfig("LOG_REDACT_KEYS"),
+			Detectors:   getListConfig("LOG_REDACT_DETECTORS"),
+		},
+		IdempotencyConfig: &IdempotencyConfig{
+			TTLHours: getIntConfigWithDefault("IDEMPOTENCY_TTL_HOURS", 24),
+		},
+		JobsConfig: &JobsConfig{
+			Owner:                 getConfig("JOB_OWNER"),
+			TimeoutSeconds:        getIntConfigWithDefault("JOB_TIMEOUT_SECONDS", 300),
+			HistoryDays:           getIntConfigWithDefault("JOB_HISTORY_DAYS", 7),
+			ConfirmationsSchedule: getConfigWithDefault("JOB_CONFIRMATIONS_SCHEDULE", "@every 15s"),
+			ReconcileSchedule:     getConfigWithDefault("JOB_RECONCILE_SCHEDULE", "*/10 * * * *"),
+			ReconcileAfterMinutes: getIntConfigWithDefault("JOB_RECONCILE_AFTER_MINUTES", 30),
+			IndexerSchedule:       getConfigWithDefault("JOB_INDEXER_SCHEDULE", "@every 30s"),
+			HousekeepingSchedule:  getConfigWithDefault("JOB_HOUSEKEEPING_SCHEDULE", "@hourly"),
+		},
+		IndexerConfig: &IndexerConfig{
+			Tokens:        getListConfig("INDEXER_TOKENS"),
+			StartBlock:    getIntConfigWithDefault("INDEXER_START_BLOCK", 0),
+			BatchBlocks:   getIntConfigWithDefault("INDEXER_BATCH_BLOCKS", 1000),
+			Confirmations: getIntConfigWithDefault("INDEXER_CONFIRMATIONS", 6),
+		},
+		RPCProxyConfig: &RPCProxyConfig{
+			PolicyFile:         getConfig("RPC_POLICY_FILE"),
+			AuditRetentionDays: getIntConfigWithDefault("RPC_AUDIT_RETENTION_DAYS", 90),
+		},
+		RPCCacheConfig: &RPCCacheConfig{
+			Enabled:           getBoolConfigWithDefault("RPC_CACHE_ENABLED", true),
+			MaxEntries:        getIntConfigWithDefault("RPC_CACHE_MAX_ENTRIES", 10000),
+			FinalityDepth:     getIntConfigWithDefault("RPC_CACHE_FINALITY_DEPTH", 64),
+			HeadRefreshMillis: getIntConfigWithDefault("RPC_CACHE_HEAD_REFRESH_MS", 1000),
+		},
+		PolicyFile:       getConfig("POLICY_FILE"),
+		SharedKeyBytes:   getFileBytesConfig("GEX_SHARED_KEY"),
+		GexSessionDriver: getConfig("GEX_SESSION_DRIVER"),
+		LogConfig: &LogConfig{
+			File:                getConfig("LOG_FILE_PATH"),
+			Format:              getConfigWithDefault("LOG_FORMAT", "text"),
+			Level:               getConfigWithDefault("LOG_LEVEL", "info"),
+			Stdout:              getBoolConfigWithDefault("LOG_STDOUT", true),
+			MaxSizeMB:           getIntConfigWithDefault("LOG_MAX_SIZE_MB", 100),
+			MaxBackups:          getIntConfigWithDefault("LOG_MAX_BACKUPS", 14),
+			MaxAgeDays:          getIntConfigWithDefault("LOG_MAX_AGE_DAYS", 30),
+			Compress:            getBoolConfigWithDefault("LOG_COMPRESS", true),
+			RotateIntervalHours: getIntConfigWithDefault("LOG_ROTATE_INTERVAL_HOURS", 24),
 		},
-		SharedKeyBytes:        getFileBytesConfig("GEX_SHARED_KEY"),
-		GexSessionDriver:      getConfig("GEX_SESSION_DRIVER"),
-		LogFile:               getConfig("LOG_FILE_PATH"),
 		SerializedSessionFile: getConfig("SERIALIZED_SESSION_FILE"),
 	}
 
@@ -85,6 +196,14 @@ func getFloatConfigOptional(key string) *float64 {
 	return &floatVal
 }
 
+func getFloatConfigWithDefault(key string, defaultValue float64) float64 {
+	val := getFloatConfigOptional(key)
+	if val == nil {
+		return defaultValue
+	}
+	return *val
+}
+
 func getConfigOptional(key string) *string {
 	if os.Getenv(key) == "" {
 		return nil
@@ -102,6 +221,25 @@ func getIntConfigOptional(key string) *int {
 	return &intVal
 }
 
+func getIntConfigWithDefault(key string, defaultValue int) int {
+	val := getIntConfigOptional(key)
+	if val == nil {
+		return defaultValue
+	}
+	return *val
+}
+
+// getListConfig reads a comma-separated list, skipping empty entries
+func getListConfig(key string) []string {
+	var result []string
+	for _, item := range strings.Split(getConfig(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
 func getConfig(key string) string {
 	val := getConfigOptional(key)
 	if val == nil {
@@ -118,6 +256,13 @@ func getBoolConfig(key string) bool {
 	return *val == "true"
 }
 
+func getBoolConfigWithDefault(key string, defaultValue bool) bool {
+	if getConfigOptional(key) == nil {
+		return defaultValue
+	}
+	return getBoolConfig(key)
+}
+
 func getFileBytesConfig(key string) []byte {
 	path := getConfig(key)
 	bytes, err := loadFile(path)
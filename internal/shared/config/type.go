@@ -1,12 +1,16 @@
 package config
 
 type DBConfig struct {
+	Driver   string // "postgres" (default) or "sqlite"
 	Host     string
 	Port     string
 	User     string
 	Password string
 	Name     string
 	SSLMode  string
+
+	MigrationsDir          string // Directory holding up/ and down/ migration files
+	AllowPendingMigrations bool   // Start the server even when migrations are pending
 }
 
 type ServerConfig struct {
@@ -20,6 +24,9 @@ type HostConfig struct {
 	ServerPort    string
 	HttpsCertFile *string
 	HttpsKeyFile  *string
+
+	ShutdownTimeoutSeconds int  // How long shutdown waits for in-flight writes and jobs
+	HTTPStatusCodes        bool // Send the kstatus as the HTTP status instead of always 200
 }
 
 type TwilioConfig struct {
@@ -45,6 +52,113 @@ type S3Config struct {
 }
 
 type BlockchainConfig struct {
-	RPCURL        string
-	DecryptionKey string // AES-256 decryption key for decrypting client-provided private keys (32-byte hex string)
+	RPCURL         string
+	ChainID        string // Expected chain ID in decimal; readiness fails on a mismatch, empty skips the check
+	DecryptionKey  string // Legacy key for CryptoJS-encrypted client-provided private keys
+	DecryptionKeys string // Envelope keys as "kid:secret,kid:secret"
+	ActiveKeyID    string // Key-id new envelopes are sealed with
+	EnvelopeKDF    string // "scrypt" (default) or "argon2id"
+
+	EnvelopeAcceptKDFs     []string // Further KDFs envelopes are opened with, e.g. the previous one while rewrapping
+	EnvelopeMaxDerivations int      // KDF runs in flight at once; further decryptions wait
+
+	MaxRetries          int // Retries of failed reads; sends are only retried once the node is known not to have them
+	RetryDelayMillis    int // Delay before the first retry, doubled for each further retry
+	RetryMaxDelayMillis int // Cap of the backoff and of a Retry-After the node asks for
+}
+
+type WalletConfig struct {
+	MasterKey string // Hex-encoded 32-byte key used to encrypt custodial wallet keys at rest
+	StorePath string // Path of the wallet store file, used without a database
+}
+
+type SignerConfig struct {
+	KeystoreDir            string // Directory keystore wallet files are registered from
+	KeystorePassphraseFile string // File holding the passphrase for keystore wallets
+	RemoteURL              string // kokka-signer endpoint, e.g. unix:///run/kokka-signer.sock
+	RemoteAuthTokenFile    string // File holding the bearer token for the kokka-signer daemon
+}
+
+type HDWalletConfig struct {
+	MnemonicFile string // File holding the BIP-39 mnemonic for derived deposit addresses
+	Passphrase   string // Optional BIP-39 passphrase
+}
+
+type SweeperConfig struct {
+	Enabled         bool
+	TreasuryAddress string   // Destination of swept tokens
+	Tokens          []string // Token contracts to sweep
+	Threshold       string   // Minimum balance to sweep, in token units
+	IntervalSeconds int
+	GasWalletID     string // Optional wallet that funds deposit addresses for gas
+}
+
+type HealthConfig struct {
+	MaxBlockAgeSeconds  int // Readiness fails when the node's head block is older
+	CheckTimeoutSeconds int // Per-check timeout of readiness and diagnostics
+}
+
+type TracingConfig struct {
+	Exporter     string  // "none" (default), "otlp" or "file"
+	OTLPEndpoint string  // Collector host:port; empty uses OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4318
+	OTLPInsecure bool    // Plain HTTP to the collector
+	File         string  // Span file of the file exporter, one JSON span per line
+	SampleRatio  float64 // Share of new traces recorded; traces started upstream follow the caller's decision
+	ServiceName  string
+}
+
+type IdempotencyConfig struct {
+	TTLHours int // How long Idempotency-Key results are kept for replay
+}
+
+type JobsConfig struct {
+	Owner                 string // Identifies this instance in job locks; defaults to hostname:pid
+	TimeoutSeconds        int    // Default per-run timeout
+	HistoryDays           int    // Job run history retention
+	ConfirmationsSchedule string // Schedules are cron expressions or "@every <duration>"; "off" disables the job
+	ReconcileSchedule     string
+	ReconcileAfterMinutes int // Journal entries idle this long are reconciled
+	IndexerSchedule       string
+	HousekeepingSchedule  string
+}
+
+type IndexerConfig struct {
+	Tokens        []string // Token contracts whose Transfer events are indexed; empty disables the indexer
+	StartBlock    int
+	BatchBlocks   int
+	Confirmations int // Blocks behind the head that are considered final
+}
+
+// RPCProxyConfig configures POST /blockchain/rpc
+type RPCProxyConfig struct {
+	PolicyFile         string // Method rules, parameter limits and client quotas; empty uses the built-in rules
+	AuditRetentionDays int    // How long the audit trail of proxied calls is kept (with a database)
+}
+
+// RPCCacheConfig configures the cache of JSON-RPC reads
+type RPCCacheConfig struct {
+	Enabled           bool
+	MaxEntries        int // Least recently used results are evicted past this
+	FinalityDepth     int // Blocks this far below the head are treated as final and cached until evicted
+	HeadRefreshMillis int // How often the head block is polled to expire reads at "latest"
+}
+
+// RedactionConfig adds rules to the built-in log redaction rules
+type RedactionConfig struct {
+	Paths       []string // JSON paths such as $.params[0] or $[?method=eth_sign].params
+	KeyPatterns []string // Case-insensitive regexps matched against JSON keys and header names
+	Detectors   []string // Value detectors: hex_private_key, cryptojs
+}
+
+// LogConfig configures the process-wide log output
+type LogConfig struct {
+	File                string // Log file; empty logs to stdout only
+	Format              string // "text" (default) or "json"
+	Level               string // Minimum level: debug, info (default), warn or error; changeable at runtime
+	Stdout              bool   // Mirror the file to stdout
+	MaxSizeMB           int    // Rotate once the file exceeds this size
+	MaxBackups          int    // Rotated files kept; 0 keeps all
+	MaxAgeDays          int    // Rotated files older than this are removed; 0 keeps all
+	Compress            bool   // Gzip rotated files
+	RotateIntervalHours int    // Also rotate on these wall-clock boundaries (24 = midnight UTC); 0 rotates by size only
 }
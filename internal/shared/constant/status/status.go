@@ -1,12 +1,17 @@
 package status
 
 const (
-	UNKNOW       Code = 100
-	OK           Code = 200
-	SUCCESS      Code = 200
-	CREATED      Code = 201
-	FAIL         Code = 400
-	UNAUTHORIZED Code = 401
-	NOT_FOUND    Code = 404
-	INTERNAL     Code = 500
+	UNKNOW            Code = 100
+	OK                Code = 200
+	SUCCESS           Code = 200
+	CREATED           Code = 201
+	FAIL              Code = 400
+	UNAUTHORIZED      Code = 401
+	FORBIDDEN         Code = 403
+	NOT_FOUND         Code = 404
+	CONFLICT          Code = 409
+	UNPROCESSABLE     Code = 422
+	TOO_MANY_REQUESTS Code = 429
+	INTERNAL          Code = 500
+	UNAVAILABLE       Code = 503
 )
@@ -9,6 +9,11 @@ import (
 	"maps"
 	"net/http"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"kokka.com/kokka/internal/shared/logger"
+	"kokka.com/kokka/internal/shared/metrics"
 )
 
 // Client is the base HTTP client for making external API calls
@@ -21,13 +26,6 @@ type Client struct {
 	retryConfig  *RetryConfig
 }
 
-// RetryConfig defines retry behavior for failed requests
-type RetryConfig struct {
-	MaxRetries         int
-	RetryDelay         time.Duration
-	RetryableHTTPCodes []int
-}
-
 // NewClient creates a new HTTP client with the given options
 func NewClient(opts ...Option) *Client {
 	client := &Client{
@@ -78,13 +76,13 @@ func (c *Client) Delete(ctx context.Context, path string, opts ...RequestOption)
 // Do performs an HTTP request with the given method, path, and body
 func (c *Client) Do(ctx context.Context, method, path string, body interface{}, opts ...RequestOption) (*Response, error) {
 	// Build the request
-	req, err := c.buildRequest(ctx, method, path, body, opts...)
+	req, reqConfig, err := c.buildRequest(ctx, method, path, body, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build request: %w", err)
 	}
 
 	// Execute request with retry logic if configured
-	if c.retryConfig != nil {
+	if c.retryConfig != nil && !reqConfig.noRetry {
 		return c.doWithRetry(ctx, req)
 	}
 
@@ -92,7 +90,7 @@ func (c *Client) Do(ctx context.Context, method, path string, body interface{},
 }
 
 // buildRequest constructs an HTTP request with all configurations applied
-func (c *Client) buildRequest(ctx context.Context, method, path string, body interface{}, opts ...RequestOption) (*http.Request, error) {
+func (c *Client) buildRequest(ctx context.Context, method, path string, body interface{}, opts ...RequestOption) (*http.Request, *RequestConfig, error) {
 	// Create request config with client defaults
 	reqConfig := &RequestConfig{
 		headers:     make(map[string]string),
@@ -119,7 +117,7 @@ func (c *Client) buildRequest(ctx context.Context, method, path string, body int
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal body: %w", err)
+			return nil, nil, fmt.Errorf("failed to marshal body: %w", err)
 		}
 		bodyReader = bytes.NewReader(jsonBody)
 
@@ -132,7 +130,7 @@ func (c *Client) buildRequest(ctx context.Context, method, path string, body int
 	// Create HTTP request
 	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Add headers
@@ -140,7 +138,13 @@ func (c *Client) buildRequest(ctx context.Context, method, path string, body int
 		req.Header.Set(key, value)
 	}
 
-	return req, nil
+	// Propagate the trace context (W3C traceparent) of the calling span
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	if requestID := logger.RequestID(ctx); requestID != "" {
+		req.Header.Set(logger.RequestIDHeader, requestID)
+	}
+
+	return req, reqConfig, nil
 }
 
 // executeRequest executes the HTTP request and returns a Response
@@ -182,39 +186,54 @@ func (c *Client) executeRequest(ctx context.Context, req *http.Request) (*Respon
 	return resp, nil
 }
 
-// doWithRetry executes the request with retry logic
+// doWithRetry executes the request, repeating it after a backoff while the response is retryable
 func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*Response, error) {
-	var resp *Response
-	var err error
+	retry := c.retryConfig
 
-	for attempt := 0; attempt <= c.retryConfig.MaxRetries; attempt++ {
-		if attempt > 0 {
-			time.Sleep(c.retryConfig.RetryDelay)
+	for attempt := 1; ; attempt++ {
+		resp, err := c.executeRequest(ctx, req)
+		if attempt > retry.MaxRetries || ctx.Err() != nil {
+			return resp, err
+		}
+		reason := retry.retryReason(resp, err)
+		if reason == "" {
+			return resp, err
+		}
+		delay, ok := retry.delay(attempt, resp)
+		if !ok {
+			return resp, err
 		}
 
-		resp, err = c.executeRequest(ctx, req)
-		if err == nil && !c.shouldRetry(resp.StatusCode) {
-			return resp, nil
+		// The body was consumed by the attempt, each retry sends a fresh copy
+		next, rewindErr := rewind(ctx, req)
+		if rewindErr != nil {
+			return resp, err
 		}
-	}
+		req = next
 
-	return resp, err
+		metrics.IncRetry(req.URL.Host)
+		logger.GetLogger(ctx).Warnf("[HTTP Client] %s, retry %d of %d in %s", reason, attempt, retry.MaxRetries, delay.Round(time.Millisecond))
+		if Wait(ctx, delay) != nil {
+			return resp, err
+		}
+	}
 }
 
-// shouldRetry determines if a request should be retried based on status code
-func (c *Client) shouldRetry(statusCode int) bool {
-	if c.retryConfig == nil {
-		return false
+// rewind returns a copy of a sent request with a fresh body
+func rewind(ctx context.Context, req *http.Request) (*http.Request, error) {
+	next := req.Clone(ctx)
+	if req.Body == nil || req.Body == http.NoBody {
+		return next, nil
 	}
-
-	for _, code := range c.retryConfig.RetryableHTTPCodes {
-		if statusCode == code {
-			return true
-		}
+	if req.GetBody == nil {
+		return nil, fmt.Errorf("request body cannot be sent again")
 	}
-
-	// Default: retry on 5xx errors
-	return statusCode >= 500 && statusCode < 600
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	next.Body = body
+	return next, nil
 }
 
 // buildQueryString builds a URL query string from params
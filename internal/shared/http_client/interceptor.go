@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"kokka.com/kokka/internal/shared/logger"
+	"kokka.com/kokka/internal/shared/metrics"
+	"kokka.com/kokka/internal/shared/redact"
 )
 
 // Interceptor defines the interface for request/response interceptors
@@ -38,13 +40,15 @@ func NewLoggingInterceptor(logBody bool) *LoggingInterceptor {
 func (i *LoggingInterceptor) Before(ctx context.Context, req *http.Request) error {
 	logger := logger.GetLogger(ctx)
 
-	logger.Infof("[HTTP Client] %s %s", req.Method, req.URL.String())
+	redactor := redact.Default()
+
+	logger.Infof("[HTTP Client] %s %s", req.Method, redactor.Text(req.URL.Redacted()))
 
 	if req.Header != nil {
 		logger.Info("[HTTP Client] Request Headers")
 		for key, values := range req.Header {
 			for _, value := range values {
-				logger.Infof("%s: %s", key, value)
+				logger.Infof("%s: %s", key, redactor.Header(key, value))
 			}
 		}
 	}
@@ -58,6 +62,7 @@ func (i *LoggingInterceptor) Before(ctx context.Context, req *http.Request) erro
 
 		// IMPORTANT: Restore the body so it can be read again by the HTTP client
 		req.Body = io.NopCloser(bytes.NewBuffer(requestBody))
+		requestBody = redactor.Body(requestBody)
 
 		// Try to pretty-print as JSON, fallback to raw string if not valid JSON
 		var prettyJSON bytes.Buffer
@@ -79,12 +84,14 @@ func (i *LoggingInterceptor) After(ctx context.Context, resp *Response) error {
 	logger.Infof("[HTTP Client] Response Status: %d", resp.StatusCode)
 
 	if i.LogBody {
+		responseBody := redact.Default().Body(resp.Body)
+
 		var prettyJSON bytes.Buffer
-		if err := json.Indent(&prettyJSON, resp.Body, "", "  "); err == nil {
+		if err := json.Indent(&prettyJSON, responseBody, "", "  "); err == nil {
 			logger.Infof("[HTTP Client] Response Body:\n%s", prettyJSON.String())
 		} else {
 			// Not valid JSON, just log as-is
-			logger.Infof("[HTTP Client] Response Body: %s", string(resp.Body))
+			logger.Infof("[HTTP Client] Response Body: %s", string(responseBody))
 		}
 	}
 
@@ -254,6 +261,7 @@ type CircuitBreakerInterceptor struct {
 	failureCount     int
 	lastFailureTime  time.Time
 	state            string // "closed", "open", "half-open"
+	host             string // Last host seen, labels the exported state
 }
 
 // NewCircuitBreakerInterceptor creates a new circuit breaker interceptor
@@ -267,10 +275,12 @@ func NewCircuitBreakerInterceptor(failureThreshold int, resetTimeout time.Durati
 
 // Before checks if the circuit is open
 func (i *CircuitBreakerInterceptor) Before(ctx context.Context, req *http.Request) error {
+	i.host = req.URL.Host
 	if i.state == "open" {
 		if time.Since(i.lastFailureTime) > i.resetTimeout {
 			i.state = "half-open"
 			i.failureCount = 0
+			metrics.SetCircuitState(i.host, metrics.CircuitHalfOpen)
 		} else {
 			return fmt.Errorf("circuit breaker is open")
 		}
@@ -287,11 +297,13 @@ func (i *CircuitBreakerInterceptor) After(ctx context.Context, resp *Response) e
 
 		if i.failureCount >= i.failureThreshold {
 			i.state = "open"
+			metrics.SetCircuitState(i.host, metrics.CircuitOpen)
 			logger.Warnf("[Circuit Breaker] Circuit opened after %d failures", i.failureCount)
 		}
 	} else if resp.IsSuccess() && i.state == "half-open" {
 		i.state = "closed"
 		i.failureCount = 0
+		metrics.SetCircuitState(i.host, metrics.CircuitClosed)
 		logger.Infof("[Circuit Breaker] Circuit closed")
 	}
 
@@ -16,6 +16,7 @@ type RequestOption func(*RequestConfig)
 type RequestConfig struct {
 	headers     map[string]string
 	queryParams map[string]string
+	noRetry     bool
 }
 
 // ===============================
@@ -78,14 +79,10 @@ func WithInterceptor(interceptor Interceptor) Option {
 	}
 }
 
-// WithRetry configures retry behavior
-func WithRetry(maxRetries int, retryDelay time.Duration, retryableHTTPCodes ...int) Option {
+// WithRetry configures retry behavior (see DefaultRetryConfig)
+func WithRetry(config RetryConfig) Option {
 	return func(c *Client) {
-		c.retryConfig = &RetryConfig{
-			MaxRetries:         maxRetries,
-			RetryDelay:         retryDelay,
-			RetryableHTTPCodes: retryableHTTPCodes,
-		}
+		c.retryConfig = &config
 	}
 }
 
@@ -114,6 +111,13 @@ func WithRequestQueryParam(key, value string) RequestOption {
 	}
 }
 
+// WithoutRetry sends a specific request once, for requests that are not safe to repeat
+func WithoutRetry() RequestOption {
+	return func(rc *RequestConfig) {
+		rc.noRetry = true
+	}
+}
+
 // WithRequestQueryParams sets multiple query parameters for a specific request
 func WithRequestQueryParams(params map[string]string) RequestOption {
 	return func(rc *RequestConfig) {
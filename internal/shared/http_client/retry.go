@@ -0,0 +1,134 @@
+package http_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryConfig defines retry behavior for failed requests
+// Delays grow exponentially from BaseDelay up to MaxDelay; a Retry-After header longer than the backoff
+// is honoured, and one longer than MaxDelay ends the retries so callers are not held up
+type RetryConfig struct {
+	MaxRetries         int
+	BaseDelay          time.Duration // Delay before the first retry, doubled for each further retry
+	MaxDelay           time.Duration // Cap of the backoff and of Retry-After
+	Jitter             float64       // Fraction of each delay that is randomised, from 0 (none) to 1
+	RetryableHTTPCodes []int         // Statuses retried by the default RetryReason
+
+	// RetryReason describes why a response or error should be retried, or returns empty when it should not;
+	// nil retries network errors and RetryableHTTPCodes
+	RetryReason func(resp *Response, err error) string
+}
+
+// DefaultRetryConfig retries network errors, rate limiting and gateway errors 3 times
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries:         3,
+		BaseDelay:          250 * time.Millisecond,
+		MaxDelay:           5 * time.Second,
+		Jitter:             0.5,
+		RetryableHTTPCodes: []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+	}
+}
+
+// Backoff returns the delay before a retry, 1 for the first one
+func (r *RetryConfig) Backoff(attempt int) time.Duration {
+	delay := r.BaseDelay
+	for i := 1; i < attempt && (r.MaxDelay <= 0 || delay < r.MaxDelay); i++ {
+		delay *= 2
+	}
+	if r.MaxDelay > 0 {
+		delay = min(delay, r.MaxDelay)
+	}
+
+	// Spread out retries of callers that failed together
+	if r.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * min(r.Jitter, 1) * float64(delay))
+	}
+	return delay
+}
+
+// delay returns the delay before a retry, false when Retry-After asks for longer than MaxDelay
+func (r *RetryConfig) delay(attempt int, resp *Response) (time.Duration, bool) {
+	delay := r.Backoff(attempt)
+	if after, ok := retryAfter(resp); ok && after > delay {
+		if r.MaxDelay > 0 && after > r.MaxDelay {
+			return 0, false
+		}
+		delay = after
+	}
+	return delay, true
+}
+
+// retryReason applies RetryReason, or the default rules
+func (r *RetryConfig) retryReason(resp *Response, err error) string {
+	if r.RetryReason != nil {
+		return r.RetryReason(resp, err)
+	}
+	if err != nil {
+		return NetworkRetryReason(err)
+	}
+	if slices.Contains(r.RetryableHTTPCodes, resp.StatusCode) {
+		return fmt.Sprintf("HTTP %d", resp.StatusCode)
+	}
+	return ""
+}
+
+// NetworkRetryReason describes a transient network error worth retrying, or returns empty
+// Connection resets, refused connections, connections closed mid-response and timeouts are transient
+func NetworkRetryReason(err error) string {
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, syscall.ECONNRESET):
+		return "connection reset"
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return "connection refused"
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return "connection closed"
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	}
+	return ""
+}
+
+// retryAfter parses the Retry-After header of a response, in seconds or as an HTTP date
+func retryAfter(resp *Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	value := resp.Header("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// Wait sleeps for a delay, returning early with the context's error when it is done
+func Wait(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,82 @@
+package lifecycle
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// drainPollInterval is how often Drain checks for in-flight operations
+const drainPollInterval = 50 * time.Millisecond
+
+// Drainer tracks in-flight operations so shutdown can wait for them
+// Once draining, new operations are refused and Drain waits for the running ones
+type Drainer struct {
+	mutex    sync.Mutex
+	draining bool
+	inFlight int
+}
+
+// NewDrainer creates a drainer that accepts operations
+func NewDrainer() *Drainer {
+	return &Drainer{}
+}
+
+// Acquire registers an operation; it returns false once draining has started
+// Every successful Acquire must be paired with Release
+func (d *Drainer) Acquire() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.draining {
+		return false
+	}
+	d.inFlight++
+	return true
+}
+
+// Release marks an operation as finished
+func (d *Drainer) Release() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.inFlight--
+}
+
+// Draining reports whether shutdown has started
+func (d *Drainer) Draining() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.draining
+}
+
+// InFlight returns the number of running operations
+func (d *Drainer) InFlight() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.inFlight
+}
+
+// Drain refuses new operations and waits for the running ones, or until the context is done
+// It returns the number of operations still running when it gave up
+func (d *Drainer) Drain(ctx context.Context) int {
+	d.mutex.Lock()
+	d.draining = true
+	d.mutex.Unlock()
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if remaining := d.InFlight(); remaining == 0 {
+			return 0
+		}
+		select {
+		case <-ctx.Done():
+			return d.InFlight()
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,12 +1,17 @@
 package logger
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
 	"path/filepath"
 	"runtime"
+	"time"
+
+	"kokka.com/kokka/internal/shared/redact"
 )
 
 // Custom slog.Handler implementation
@@ -18,6 +23,7 @@ type customHandler struct {
 	attrs           []slog.Attr
 	groups          []string
 	backgroundColor BackgroundColor
+	json            bool // Write JSON lines instead of the text format
 }
 
 // newCustomHandler creates a new custom handler that writes to the given writer
@@ -36,9 +42,8 @@ func newCustomHandlerWithBgColor(w io.Writer, bgColor BackgroundColor) *customHa
 }
 
 // Enabled reports whether the handler handles records at the given level
-func (h *customHandler) Enabled(ctx context.Context, level slog.Level) bool {
-	// Enable all levels
-	return true
+func (h *customHandler) Enabled(ctx context.Context, minLevel slog.Level) bool {
+	return minLevel >= level.Level()
 }
 
 // Handle formats and writes the log record
@@ -46,6 +51,9 @@ func (h *customHandler) Handle(ctx context.Context, r slog.Record) error {
 	// Extract session info from context
 	token, userid, route := extractSessionInfo(ctx)
 
+	// Secrets that slipped into a message never reach the log output
+	r.Message = redact.Default().Text(r.Message)
+
 	// Get caller information (filename and line)
 	// We need to skip frames to get the actual caller
 	var file string
@@ -67,6 +75,10 @@ func (h *customHandler) Handle(ctx context.Context, r slog.Record) error {
 		line = f.Line
 	}
 
+	if h.json {
+		return h.handleJSON(ctx, r, route, file, line)
+	}
+
 	// Format timestamp with microseconds: 2025/12/04 04:18:38.151018
 	timestamp := r.Time.Format("2006/01/02 15:04:05")
 
@@ -74,8 +86,9 @@ func (h *customHandler) Handle(ctx context.Context, r slog.Record) error {
 	level := r.Level.String()
 
 	// Build the log message WITHOUT the trailing newline
-	logMsg := fmt.Sprintf("%s [%s] [%s] [%s] %s:%d %s: %s",
+	logMsg := fmt.Sprintf("%s [%s] [%s] [%s] [%s] %s:%d %s: %s",
 		timestamp,
+		requestIDOrNone(ctx),
 		token,
 		userid,
 		route,
@@ -102,6 +115,52 @@ func (h *customHandler) Handle(ctx context.Context, r slog.Record) error {
 	return err
 }
 
+// handleJSON writes the record as one JSON object, keyed for querying by request, route, client and tx hash
+func (h *customHandler) handleJSON(ctx context.Context, r slog.Record, route, file string, line int) error {
+	client, txHash := extractFields(ctx).snapshot()
+
+	entry := map[string]any{
+		"time":       r.Time.Format(time.RFC3339Nano),
+		"level":      r.Level.String(),
+		"msg":        r.Message,
+		"source":     fmt.Sprintf("%s:%d", file, line),
+		"request_id": RequestID(ctx),
+		"route":      route,
+	}
+	if client != "" {
+		entry["client"] = client
+	}
+	if txHash != "" {
+		entry["tx_hash"] = txHash
+	}
+
+	addAttr := func(a slog.Attr) bool {
+		entry[a.Key] = a.Value.Resolve().Any()
+		return true
+	}
+	for _, a := range h.attrs {
+		addAttr(a)
+	}
+	r.Attrs(addAttr)
+
+	var logLine bytes.Buffer
+	encoder := json.NewEncoder(&logLine)
+	encoder.SetEscapeHTML(false) // Keep request/response bodies readable
+	if err := encoder.Encode(entry); err != nil {
+		return err
+	}
+	_, err := h.writer.Write(logLine.Bytes())
+	return err
+}
+
+// requestIDOrNone returns the request ID of the context for the text format
+func requestIDOrNone(ctx context.Context) string {
+	if id := RequestID(ctx); id != "" {
+		return id
+	}
+	return "-"
+}
+
 // WithAttrs returns a new handler with the given attributes added
 func (h *customHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 	newHandler := &customHandler{
@@ -109,6 +168,7 @@ func (h *customHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 		attrs:           make([]slog.Attr, len(h.attrs)+len(attrs)),
 		groups:          make([]string, len(h.groups)),
 		backgroundColor: h.backgroundColor,
+		json:            h.json,
 	}
 	copy(newHandler.attrs, h.attrs)
 	copy(newHandler.attrs[len(h.attrs):], attrs)
@@ -123,6 +183,7 @@ func (h *customHandler) WithGroup(name string) slog.Handler {
 		attrs:           make([]slog.Attr, len(h.attrs)),
 		groups:          make([]string, len(h.groups)+1),
 		backgroundColor: h.backgroundColor,
+		json:            h.json,
 	}
 	copy(newHandler.attrs, h.attrs)
 	copy(newHandler.groups, h.groups)
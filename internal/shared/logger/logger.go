@@ -3,10 +3,8 @@ package logger
 import (
 	"context"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
-	"os"
 	"runtime"
 	"time"
 )
@@ -63,34 +61,25 @@ func GetBackgroundColor(ctx context.Context) BackgroundColor {
 type logger struct {
 	slogger         *slog.Logger
 	request         *http.Request
-	outFile         *os.File
 	ctx             context.Context
 	backgroundColor BackgroundColor
 }
 
-// NewRequestScopedLogger creates a new request-scoped logger instance
-func NewRequestScopedLogger(r *http.Request, outFilePath string) *logger {
-	var outFile *os.File
-	var writer io.Writer
-
-	// If outFilePath is provided, open the file for writing
-	if outFilePath != "" {
-		f, err := os.OpenFile(outFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-		if err != nil {
-			// If file opening fails, fallback to stdout
-			writer = os.Stdout
-		} else {
-			outFile = f
-			// Write to both file and stdout
-			writer = io.MultiWriter(f, os.Stdout)
-		}
-	} else {
-		// Default to stdout
-		writer = os.Stdout
-	}
+// Output formats of the shared log output (see Setup)
+type Format string
+
+const (
+	FormatText Format = "text" // Human-readable lines, optionally coloured
+	FormatJSON Format = "json" // One JSON object per line, for log shipping
+)
 
+// NewRequestScopedLogger creates a new request-scoped logger instance
+// It writes to the shared output opened by Setup, so creating one per request is cheap
+// The request ID is taken from the request context (see WithRequestID)
+func NewRequestScopedLogger(r *http.Request) *logger {
 	// Create custom handler
-	handler := newCustomHandler(writer)
+	handler := newCustomHandler(sharedWriter{})
+	handler.json = jsonOutput()
 
 	// Create slog logger with custom handler
 	slogger := slog.New(handler)
@@ -100,70 +89,62 @@ func NewRequestScopedLogger(r *http.Request, outFilePath string) *logger {
 
 	// Create context with session info
 	ctx := withSessionInfo(r.Context(), token, userid, route)
+	ctx = context.WithValue(ctx, fieldsKey, &requestFields{})
 
 	return &logger{
 		slogger:         slogger,
 		request:         r,
-		outFile:         outFile,
 		ctx:             ctx,
 		backgroundColor: BgNone, // Default to transparent background
 	}
 }
 
-// Close closes the logger's file handle if it exists
-func (l *logger) Close() error {
-	if l.outFile != nil {
-		return l.outFile.Close()
-	}
-	return nil
-}
-
 // Info logs an informational message
 func (l *logger) Info(args ...any) {
 	msg := fmt.Sprint(args...)
-	l.log(l.ctx, slog.LevelInfo, msg)
+	l.log(l.context(), slog.LevelInfo, msg)
 }
 
 // Infof logs a formatted informational message
 func (l *logger) Infof(template string, args ...any) {
 	msg := fmt.Sprintf(template, args...)
-	l.log(l.ctx, slog.LevelInfo, msg)
+	l.log(l.context(), slog.LevelInfo, msg)
 }
 
 // Error logs an error message
 func (l *logger) Error(args ...any) {
 	msg := fmt.Sprint(args...)
-	l.log(l.ctx, slog.LevelError, msg)
+	l.log(l.context(), slog.LevelError, msg)
 }
 
 // Errorf logs a formatted error message
 func (l *logger) Errorf(template string, args ...any) {
 	msg := fmt.Sprintf(template, args...)
-	l.log(l.ctx, slog.LevelError, msg)
+	l.log(l.context(), slog.LevelError, msg)
 }
 
 // Debug logs a debug message
 func (l *logger) Debug(args ...any) {
 	msg := fmt.Sprint(args...)
-	l.log(l.ctx, slog.LevelDebug, msg)
+	l.log(l.context(), slog.LevelDebug, msg)
 }
 
 // Debugf logs a formatted debug message
 func (l *logger) Debugf(template string, args ...any) {
 	msg := fmt.Sprintf(template, args...)
-	l.log(l.ctx, slog.LevelDebug, msg)
+	l.log(l.context(), slog.LevelDebug, msg)
 }
 
 // Warn logs a warning message
 func (l *logger) Warn(args ...any) {
 	msg := fmt.Sprint(args...)
-	l.log(l.ctx, slog.LevelWarn, msg)
+	l.log(l.context(), slog.LevelWarn, msg)
 }
 
 // Warnf logs a formatted warning message
 func (l *logger) Warnf(template string, args ...any) {
 	msg := fmt.Sprintf(template, args...)
-	l.log(l.ctx, slog.LevelWarn, msg)
+	l.log(l.context(), slog.LevelWarn, msg)
 }
 
 // log is a helper method that creates a log record with the correct caller information
@@ -176,62 +157,80 @@ func (l *logger) log(ctx context.Context, level slog.Level, msg string) {
 	// Create a new record with the correct PC
 	r := slog.NewRecord(time.Now(), level, msg, pcs[0])
 
+	if !defaultLogger.Enabled(ctx, level) {
+		return
+	}
+
+	// Without a request-scoped logger (e.g. background jobs) fall back to the default logger
+	if l == nil {
+		_ = defaultLogger.Handler().Handle(ctx, r)
+		return
+	}
+
 	// Call the handler directly
 	_ = l.slogger.Handler().Handle(ctx, r)
 }
 
+// context returns the logger context, which is empty for a nil logger
+func (l *logger) context() context.Context {
+	if l == nil {
+		return context.Background()
+	}
+	return l.ctx
+}
+
 // InfoWithBgColor logs an informational message with a specific background color
 func (l *logger) InfoWithBgColor(bgColor BackgroundColor, args ...any) {
 	msg := fmt.Sprint(args...)
-	ctx := WithBackgroundColor(l.ctx, bgColor)
+	ctx := WithBackgroundColor(l.context(), bgColor)
 	l.log(ctx, slog.LevelInfo, msg)
 }
 
 // InfofWithBgColor logs a formatted informational message with a specific background color
 func (l *logger) InfofWithBgColor(bgColor BackgroundColor, template string, args ...any) {
 	msg := fmt.Sprintf(template, args...)
-	ctx := WithBackgroundColor(l.ctx, bgColor)
+	ctx := WithBackgroundColor(l.context(), bgColor)
 	l.log(ctx, slog.LevelInfo, msg)
 }
 
 // ErrorWithBgColor logs an error message with a specific background color
 func (l *logger) ErrorWithBgColor(bgColor BackgroundColor, args ...any) {
 	msg := fmt.Sprint(args...)
-	ctx := WithBackgroundColor(l.ctx, bgColor)
+	ctx := WithBackgroundColor(l.context(), bgColor)
 	l.log(ctx, slog.LevelError, msg)
 }
 
 // ErrorfWithBgColor logs a formatted error message with a specific background color
 func (l *logger) ErrorfWithBgColor(bgColor BackgroundColor, template string, args ...any) {
 	msg := fmt.Sprintf(template, args...)
-	ctx := WithBackgroundColor(l.ctx, bgColor)
+	ctx := WithBackgroundColor(l.context(), bgColor)
 	l.log(ctx, slog.LevelError, msg)
 }
 
 // DebugWithBgColor logs a debug message with a specific background color
 func (l *logger) DebugWithBgColor(bgColor BackgroundColor, args ...any) {
 	msg := fmt.Sprint(args...)
-	ctx := WithBackgroundColor(l.ctx, bgColor)
+	ctx := WithBackgroundColor(l.context(), bgColor)
 	l.log(ctx, slog.LevelDebug, msg)
 }
 
 // DebugfWithBgColor logs a formatted debug message with a specific background color
 func (l *logger) DebugfWithBgColor(bgColor BackgroundColor, template string, args ...any) {
 	msg := fmt.Sprintf(template, args...)
-	ctx := WithBackgroundColor(l.ctx, bgColor)
+	ctx := WithBackgroundColor(l.context(), bgColor)
 	l.log(ctx, slog.LevelDebug, msg)
 }
 
 // WarnWithBgColor logs a warning message with a specific background color
 func (l *logger) WarnWithBgColor(bgColor BackgroundColor, args ...any) {
 	msg := fmt.Sprint(args...)
-	ctx := WithBackgroundColor(l.ctx, bgColor)
+	ctx := WithBackgroundColor(l.context(), bgColor)
 	l.log(ctx, slog.LevelWarn, msg)
 }
 
 // WarnfWithBgColor logs a formatted warning message with a specific background color
 func (l *logger) WarnfWithBgColor(bgColor BackgroundColor, template string, args ...any) {
 	msg := fmt.Sprintf(template, args...)
-	ctx := WithBackgroundColor(l.ctx, bgColor)
+	ctx := WithBackgroundColor(l.context(), bgColor)
 	l.log(ctx, slog.LevelWarn, msg)
 }
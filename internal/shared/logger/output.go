@@ -0,0 +1,150 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+	"kokka.com/kokka/internal/shared/config"
+)
+
+// Process-wide output shared by the default and request-scoped loggers
+// ------------------------------------------------------------
+
+var (
+	outputMutex sync.RWMutex
+	output      io.Writer          = os.Stdout
+	outputFile  *lumberjack.Logger // Set when LOG_FILE_PATH is configured
+	outputJSON  bool
+	stopRotate  chan struct{} // Closed to stop time-based rotation
+
+	level = new(slog.LevelVar) // Minimum level written by every logger, changeable at runtime
+)
+
+// Setup opens the shared log output; call Close on shutdown
+// The file is rotated once it exceeds MaxSizeMB and every RotateIntervalHours, old files are
+// compressed and pruned by MaxBackups and MaxAgeDays
+func Setup(cfg *config.LogConfig) error {
+	if err := SetLevel(cfg.Level); err != nil {
+		return err
+	}
+
+	var file *lumberjack.Logger
+	writer := io.Writer(os.Stdout)
+	if cfg.File != "" {
+		if err := os.MkdirAll(filepath.Dir(cfg.File), 0755); err != nil {
+			return fmt.Errorf("failed to create log directory: %w", err)
+		}
+		file = &lumberjack.Logger{
+			Filename:   cfg.File,
+			MaxSize:    cfg.MaxSizeMB,
+			MaxBackups: cfg.MaxBackups,
+			MaxAge:     cfg.MaxAgeDays,
+			Compress:   cfg.Compress,
+			LocalTime:  true,
+		}
+		writer = file
+		if cfg.Stdout {
+			writer = io.MultiWriter(file, os.Stdout)
+		}
+	}
+
+	Close()
+
+	outputMutex.Lock()
+	output = writer
+	outputFile = file
+	outputJSON = Format(cfg.Format) == FormatJSON
+	if file != nil && cfg.RotateIntervalHours > 0 {
+		stopRotate = make(chan struct{})
+		go rotateEvery(file, time.Duration(cfg.RotateIntervalHours)*time.Hour, stopRotate)
+	}
+	outputMutex.Unlock()
+
+	return nil
+}
+
+// Close stops rotation and closes the log file, falling back to stdout
+func Close() error {
+	outputMutex.Lock()
+	defer outputMutex.Unlock()
+
+	if stopRotate != nil {
+		close(stopRotate)
+		stopRotate = nil
+	}
+
+	var err error
+	if outputFile != nil {
+		err = outputFile.Close()
+		outputFile = nil
+	}
+	output = os.Stdout
+	return err
+}
+
+// SetLevel changes the minimum level of all loggers; level is debug, info, warn or error
+func SetLevel(name string) error {
+	parsed, err := ParseLevel(name)
+	if err != nil {
+		return err
+	}
+	level.Set(parsed)
+	return nil
+}
+
+// Level returns the current minimum level name
+func Level() string {
+	return strings.ToLower(level.Level().String())
+}
+
+// ParseLevel parses a level name; an empty name is info
+func ParseLevel(name string) (slog.Level, error) {
+	var parsed slog.Level
+	if name == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := parsed.UnmarshalText([]byte(name)); err != nil {
+		return 0, fmt.Errorf("invalid log level %q (want debug, info, warn or error)", name)
+	}
+	return parsed, nil
+}
+
+// sharedWriter writes to the current shared output, so loggers survive Setup and Close
+type sharedWriter struct{}
+
+func (sharedWriter) Write(p []byte) (int, error) {
+	outputMutex.RLock()
+	defer outputMutex.RUnlock()
+	return output.Write(p)
+}
+
+// jsonOutput reports whether request-scoped loggers write JSON lines
+func jsonOutput() bool {
+	outputMutex.RLock()
+	defer outputMutex.RUnlock()
+	return outputJSON
+}
+
+// rotateEvery rotates the file on wall-clock boundaries of interval (e.g. midnight UTC for 24h)
+func rotateEvery(file *lumberjack.Logger, interval time.Duration, stop chan struct{}) {
+	for {
+		now := time.Now()
+		timer := time.NewTimer(now.Truncate(interval).Add(interval).Sub(now))
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+			if err := file.Rotate(); err != nil {
+				Error("logger: failed to rotate %s: %v", file.Filename, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+)
+
+// RequestIDHeader carries the request ID on incoming requests, responses and outbound calls
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds the length of an accepted client-supplied request ID
+const maxRequestIDLength = 128
+
+type requestIDKeyType string
+type fieldsKeyType string
+
+const (
+	requestIDKey = requestIDKeyType("request_id")
+	fieldsKey    = fieldsKeyType("fields")
+)
+
+// requestFields are log fields that become known while the request is being served
+type requestFields struct {
+	mutex  sync.Mutex
+	client string
+	txHash string
+}
+
+// NewRequestID returns a random request ID
+func NewRequestID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
+
+// ValidRequestID reports whether a client-supplied request ID can be used as-is
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-' || c == '_' || c == '.' || c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// WithRequestID adds the request ID to the context
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID returns the request ID of the context, or "" outside a request
+func RequestID(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDKey).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// SetClient records the API client serving the request in later log lines
+func (l *logger) SetClient(client string) {
+	if fields := l.fields(); fields != nil {
+		fields.mutex.Lock()
+		fields.client = client
+		fields.mutex.Unlock()
+	}
+}
+
+// SetTxHash records the transaction hash the request is about in later log lines
+func (l *logger) SetTxHash(txHash string) {
+	if fields := l.fields(); fields != nil {
+		fields.mutex.Lock()
+		fields.txHash = txHash
+		fields.mutex.Unlock()
+	}
+}
+
+// fields returns the mutable fields of the logger, which are nil for a nil logger
+func (l *logger) fields() *requestFields {
+	if l == nil {
+		return nil
+	}
+	return extractFields(l.ctx)
+}
+
+// extractFields retrieves the mutable request fields from context
+func extractFields(ctx context.Context) *requestFields {
+	fields, _ := ctx.Value(fieldsKey).(*requestFields)
+	return fields
+}
+
+// snapshot returns the client and transaction hash recorded so far
+func (f *requestFields) snapshot() (client, txHash string) {
+	if f == nil {
+		return "", ""
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.client, f.txHash
+}
@@ -0,0 +1,244 @@
+package metrics
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Label values for missing or unbounded labels
+const (
+	LabelNone      = "none"
+	LabelUnmatched = "unmatched" // Requests that matched no route, so scanners cannot blow up cardinality
+	LabelOther     = "other"     // RPC methods past maxRPCMethods
+)
+
+// maxRPCMethods caps the distinct method labels, as /blockchain/rpc forwards client-chosen methods
+const maxRPCMethods = 100
+
+// Circuit breaker states, as exported by kokka_http_client_circuit_state
+const (
+	CircuitClosed   = 0
+	CircuitHalfOpen = 1
+	CircuitOpen     = 2
+)
+
+// rpcMethods are the method labels handed out so far
+var (
+	rpcMethodsMutex sync.Mutex
+	rpcMethods      = map[string]bool{}
+)
+
+// registry holds the kokka series plus the Go runtime and process collectors
+var registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kokka_http_requests_total",
+		Help: "HTTP requests served, by route and kstatus.",
+	}, []string{"route", "kstatus"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kokka_http_request_duration_seconds",
+		Help:    "HTTP request latency, by route and kstatus.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "kstatus"})
+
+	rpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kokka_rpc_requests_total",
+		Help: "JSON-RPC calls, by method and endpoint.",
+	}, []string{"method", "endpoint"})
+
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kokka_rpc_request_duration_seconds",
+		Help:    "JSON-RPC call latency including retries, by method and endpoint.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "endpoint"})
+
+	rpcErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kokka_rpc_errors_total",
+		Help: "Failed JSON-RPC calls, by method, endpoint and kind (transport, http, rpc).",
+	}, []string{"method", "endpoint", "kind"})
+
+	rpcCacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kokka_rpc_cache_lookups_total",
+		Help: "Cacheable JSON-RPC reads, by method and result (hit, miss).",
+	}, []string{"method", "result"})
+
+	rpcCacheEntries = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "kokka_rpc_cache_entries",
+		Help: "Results held by the JSON-RPC read cache.",
+	})
+
+	rpcCacheInvalidations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kokka_rpc_cache_invalidations_total",
+		Help: "Cached JSON-RPC results dropped, by reason (head, event, evicted, purge).",
+	}, []string{"reason"})
+
+	httpClientRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kokka_http_client_retries_total",
+		Help: "Outgoing HTTP requests retried, by host.",
+	}, []string{"host"})
+
+	circuitState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kokka_http_client_circuit_state",
+		Help: "Circuit breaker state by host: 0 closed, 1 half-open, 2 open.",
+	}, []string{"host"})
+
+	transactionsSubmitted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kokka_transactions_submitted_total",
+		Help: "Transactions broadcast, by kind (journal operation) and token.",
+	}, []string{"kind", "token"})
+
+	transactionsConfirmed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kokka_transactions_confirmed_total",
+		Help: "Transactions mined, by kind, token and status (confirmed, reverted).",
+	}, []string{"kind", "token", "status"})
+
+	decryptionFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kokka_decryption_failures_total",
+		Help: "Private keys that failed to decrypt, by source (client_key, wallet_key, keystore).",
+	}, []string{"source"})
+
+	signingFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kokka_signing_failures_total",
+		Help: "Transactions that failed to sign, by signer (local, keystore, remote).",
+	}, []string{"signer"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		rpcRequests,
+		rpcDuration,
+		rpcErrors,
+		rpcCacheLookups,
+		rpcCacheEntries,
+		rpcCacheInvalidations,
+		httpClientRetries,
+		circuitState,
+		transactionsSubmitted,
+		transactionsConfirmed,
+		decryptionFailures,
+		signingFailures,
+	)
+}
+
+// Handler serves the metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// ObserveHTTPRequest records a served request; kstatus is 0 when the handler wrote no kstatus
+func ObserveHTTPRequest(route string, kstatus int, elapsed time.Duration) {
+	if route == "" {
+		route = LabelUnmatched
+	}
+	code := LabelNone
+	if kstatus != 0 {
+		code = strconv.Itoa(kstatus)
+	}
+	httpRequests.WithLabelValues(route, code).Inc()
+	httpDuration.WithLabelValues(route, code).Observe(elapsed.Seconds())
+}
+
+// ObserveRPCCall records a JSON-RPC call; errorKind is empty on success
+func ObserveRPCCall(method string, endpoint string, elapsed time.Duration, errorKind string) {
+	method = rpcMethodLabel(method)
+	rpcRequests.WithLabelValues(method, endpoint).Inc()
+	rpcDuration.WithLabelValues(method, endpoint).Observe(elapsed.Seconds())
+	if errorKind != "" {
+		rpcErrors.WithLabelValues(method, endpoint, errorKind).Inc()
+	}
+}
+
+// ObserveRPCCacheLookup records a cacheable JSON-RPC read served from the cache or the node
+func ObserveRPCCacheLookup(method string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	rpcCacheLookups.WithLabelValues(rpcMethodLabel(method), result).Inc()
+}
+
+// SetRPCCacheEntries records the number of cached JSON-RPC results
+func SetRPCCacheEntries(entries int) {
+	rpcCacheEntries.Set(float64(entries))
+}
+
+// AddRPCCacheInvalidations records dropped cache entries
+func AddRPCCacheInvalidations(reason string, count int) {
+	if count > 0 {
+		rpcCacheInvalidations.WithLabelValues(reason).Add(float64(count))
+	}
+}
+
+// IncRetry records a retried outgoing request
+func IncRetry(host string) {
+	httpClientRetries.WithLabelValues(host).Inc()
+}
+
+// SetCircuitState records the circuit breaker state of a host
+func SetCircuitState(host string, state int) {
+	circuitState.WithLabelValues(host).Set(float64(state))
+}
+
+// IncTransactionSubmitted records a broadcast transaction
+func IncTransactionSubmitted(kind string, token string) {
+	transactionsSubmitted.WithLabelValues(kind, orNone(token)).Inc()
+}
+
+// IncTransactionConfirmed records a mined transaction with its final status
+func IncTransactionConfirmed(kind string, token string, status string) {
+	transactionsConfirmed.WithLabelValues(kind, orNone(token), status).Inc()
+}
+
+// IncDecryptionFailure records a private key that failed to decrypt
+func IncDecryptionFailure(source string) {
+	decryptionFailures.WithLabelValues(source).Inc()
+}
+
+// IncSigningFailure records a transaction that failed to sign
+func IncSigningFailure(signer string) {
+	signingFailures.WithLabelValues(signer).Inc()
+}
+
+// EndpointLabel reduces a URL to scheme and host, as paths may carry API keys
+func EndpointLabel(endpoint string) string {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Host == "" {
+		return "unknown"
+	}
+	return parsed.Scheme + "://" + parsed.Host
+}
+
+// rpcMethodLabel returns the method as a label until maxRPCMethods distinct methods were seen
+func rpcMethodLabel(method string) string {
+	rpcMethodsMutex.Lock()
+	defer rpcMethodsMutex.Unlock()
+
+	if rpcMethods[method] {
+		return method
+	}
+	if len(rpcMethods) >= maxRPCMethods || len(method) > 64 {
+		return LabelOther
+	}
+	rpcMethods[method] = true
+	return method
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return LabelNone
+	}
+	return value
+}
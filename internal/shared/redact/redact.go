@@ -0,0 +1,336 @@
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"kokka.com/kokka/internal/shared/config"
+)
+
+// Mask replaces redacted values
+const Mask = "[REDACTED]"
+
+// Value detectors
+const (
+	DetectorHexPrivateKey = "hex_private_key" // Bare 64-hex-digit strings; 0x-prefixed hashes are kept
+	DetectorCryptoJS      = "cryptojs"        // CryptoJS AES ciphertexts ("U2FsdGVk" = base64 "Salted__")
+)
+
+// Built-in rules, always applied in addition to the configured ones
+var (
+	// defaultPaths redact signed raw transactions of single and batched JSON-RPC requests
+	defaultPaths = []string{
+		"$[?method=eth_sendRawTransaction].params",
+		"$[*][?method=eth_sendRawTransaction].params",
+	}
+
+	// defaultKeyPatterns match JSON keys and header names, case-insensitively
+	defaultKeyPatterns = []string{
+		`private[_-]?key`,
+		`decryption[_-]?key`,
+		`secret`,
+		`password`,
+		`passphrase`,
+		`mnemonic`,
+		`seed[_-]?phrase`,
+		`^authorization$`,
+		`^x-api-key$`,
+		`^(set-)?cookie$`,
+	}
+
+	defaultDetectors = []string{DetectorHexPrivateKey, DetectorCryptoJS}
+
+	detectorPatterns = map[string]*regexp.Regexp{
+		DetectorHexPrivateKey: regexp.MustCompile(`[0-9a-fA-F]{64}`),
+		DetectorCryptoJS:      regexp.MustCompile(`U2FsdGVk[0-9A-Za-z+/]+=*`),
+	}
+)
+
+// Redactor removes secrets from logged request, response and header values
+type Redactor struct {
+	paths     [][]segment
+	keys      []*regexp.Regexp
+	detectors []*regexp.Regexp
+}
+
+var defaultRedactor atomic.Pointer[Redactor]
+
+func init() {
+	r, err := New(&config.RedactionConfig{})
+	if err != nil {
+		panic(err)
+	}
+	defaultRedactor.Store(r)
+}
+
+// Default returns the redactor used by the request and HTTP client loggers
+func Default() *Redactor {
+	return defaultRedactor.Load()
+}
+
+// SetDefault replaces the redactor used by the request and HTTP client loggers
+func SetDefault(r *Redactor) {
+	if r != nil {
+		defaultRedactor.Store(r)
+	}
+}
+
+// New builds a redactor from the built-in rules plus the configured ones
+func New(cfg *config.RedactionConfig) (*Redactor, error) {
+	r := &Redactor{}
+
+	for _, path := range append(append([]string{}, defaultPaths...), cfg.Paths...) {
+		segments, err := parsePath(path)
+		if err != nil {
+			return nil, err
+		}
+		r.paths = append(r.paths, segments)
+	}
+
+	for _, pattern := range append(append([]string{}, defaultKeyPatterns...), cfg.KeyPatterns...) {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction key pattern %q: %w", pattern, err)
+		}
+		r.keys = append(r.keys, re)
+	}
+
+	seen := map[string]bool{}
+	for _, name := range append(append([]string{}, defaultDetectors...), cfg.Detectors...) {
+		re, ok := detectorPatterns[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown redaction detector %q", name)
+		}
+		if !seen[name] {
+			seen[name] = true
+			r.detectors = append(r.detectors, re)
+		}
+	}
+
+	return r, nil
+}
+
+// Body redacts a request or response body; JSON is redacted by path, key and value,
+// anything else by value only
+func (r *Redactor) Body(body []byte) []byte {
+	var doc any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil || decoder.More() {
+		return []byte(r.Text(string(body)))
+	}
+
+	for _, path := range r.paths {
+		doc = redactPath(doc, path)
+	}
+	doc = r.redactValue(doc)
+
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(doc); err != nil {
+		return []byte(r.Text(string(body)))
+	}
+	return bytes.TrimSuffix(out.Bytes(), []byte("\n"))
+}
+
+// Header redacts a header value by the header name and by value
+func (r *Redactor) Header(name, value string) string {
+	if r.sensitiveKey(name) {
+		return Mask
+	}
+	return r.Text(value)
+}
+
+// Map redacts a decoded form or JSON body in place and returns it
+func (r *Redactor) Map(values map[string]any) map[string]any {
+	for key, value := range values {
+		if r.sensitiveKey(key) {
+			values[key] = Mask
+			continue
+		}
+		values[key] = r.redactValue(value)
+	}
+	return values
+}
+
+// Text redacts secrets detected by value in free text
+func (r *Redactor) Text(text string) string {
+	for _, re := range r.detectors {
+		if re == detectorPatterns[DetectorHexPrivateKey] {
+			text = replaceStandalone(re, text)
+			continue
+		}
+		text = re.ReplaceAllString(text, Mask)
+	}
+	return text
+}
+
+// replaceStandalone masks the matches that are not part of a longer word, so "0x"-prefixed
+// hashes and longer hex strings are kept; the neighbouring bytes are checked rather than
+// matched, so keys separated by a single character are all masked
+func replaceStandalone(re *regexp.Regexp, text string) string {
+	matches := re.FindAllStringIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+
+	var out strings.Builder
+	last := 0
+	for _, match := range matches {
+		start, end := match[0], match[1]
+		if (start > 0 && isWordByte(text[start-1])) || (end < len(text) && isWordByte(text[end])) {
+			continue
+		}
+		out.WriteString(text[last:start])
+		out.WriteString(Mask)
+		last = end
+	}
+	out.WriteString(text[last:])
+	return out.String()
+}
+
+// isWordByte reports whether b is an ASCII letter or digit
+func isWordByte(b byte) bool {
+	return '0' <= b && b <= '9' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+// sensitiveKey reports whether a JSON key or header name holds a secret
+func (r *Redactor) sensitiveKey(key string) bool {
+	for _, re := range r.keys {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactValue applies the key and value rules to a decoded JSON value
+func (r *Redactor) redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return r.Map(v)
+	case []any:
+		for i := range v {
+			v[i] = r.redactValue(v[i])
+		}
+		return v
+	case string:
+		return r.Text(v)
+	default:
+		return value
+	}
+}
+
+// JSON paths
+// ------------------------------------------------------------
+
+// segment is one step of a JSON path
+type segment struct {
+	key         string // Object member; "*" matches every member
+	index       int    // Array element; -1 matches every element
+	isIndex     bool
+	filterKey   string // [?key=value] keeps the current node only if it is an object with key == value
+	filterValue string
+	isFilter    bool
+}
+
+// parsePath parses the supported JSON-path subset: $, .name, .*, [n], [*] and [?key=value]
+func parsePath(path string) ([]segment, error) {
+	invalid := fmt.Errorf("invalid redaction path %q", path)
+	if !strings.HasPrefix(path, "$") {
+		return nil, invalid
+	}
+
+	var segments []segment
+	rest := path[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, invalid
+			}
+			segments = append(segments, segment{key: rest[:end]})
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, invalid
+			}
+			inner := rest[1:end]
+			rest = rest[end+1:]
+			switch {
+			case inner == "*":
+				segments = append(segments, segment{index: -1, isIndex: true})
+			case strings.HasPrefix(inner, "?"):
+				key, value, ok := strings.Cut(inner[1:], "=")
+				if !ok || key == "" {
+					return nil, invalid
+				}
+				segments = append(segments, segment{filterKey: key, filterValue: value, isFilter: true})
+			default:
+				index, err := strconv.Atoi(inner)
+				if err != nil || index < 0 {
+					return nil, invalid
+				}
+				segments = append(segments, segment{index: index, isIndex: true})
+			}
+		default:
+			return nil, invalid
+		}
+	}
+
+	if len(segments) == 0 || segments[len(segments)-1].isFilter {
+		return nil, fmt.Errorf("redaction path %q does not select a value", path)
+	}
+	return segments, nil
+}
+
+// redactPath masks every value the path selects and returns the (possibly replaced) node
+func redactPath(node any, path []segment) any {
+	if len(path) == 0 {
+		return Mask
+	}
+
+	seg, rest := path[0], path[1:]
+	switch {
+	case seg.isFilter:
+		object, ok := node.(map[string]any)
+		if !ok || fmt.Sprint(object[seg.filterKey]) != seg.filterValue {
+			return node
+		}
+		return redactPath(node, rest)
+	case seg.isIndex:
+		array, ok := node.([]any)
+		if !ok {
+			return node
+		}
+		for i := range array {
+			if seg.index < 0 || seg.index == i {
+				array[i] = redactPath(array[i], rest)
+			}
+		}
+		return array
+	default:
+		object, ok := node.(map[string]any)
+		if !ok {
+			return node
+		}
+		for key, value := range object {
+			if seg.key == "*" || seg.key == key {
+				object[key] = redactPath(value, rest)
+			}
+		}
+		return object
+	}
+}
@@ -0,0 +1,160 @@
+package redact
+
+import (
+	"strings"
+	"testing"
+
+	"kokka.com/kokka/internal/shared/config"
+)
+
+const (
+	testKey        = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	testOtherKey   = "8da4ef21b864d2cc526dbdb2a120bd2874c36c9d0a1fb7f8c63d7f7a8b41de8f"
+	testCiphertext = "U2FsdGVkX1+XkQ7cJv5b0lE3yZ9qv2Hk7Vt4xGm8pB0sN6wRfYc1DaA3uLzTeI9o"
+	testRawTx      = "0xf86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"
+)
+
+func newTestRedactor(t *testing.T) *Redactor {
+	t.Helper()
+	r, err := New(&config.RedactionConfig{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return r
+}
+
+func assertRedacted(t *testing.T, got string, secrets ...string) {
+	t.Helper()
+	for _, secret := range secrets {
+		if strings.Contains(got, secret) {
+			t.Errorf("%q leaks %q", got, secret)
+		}
+	}
+	if !strings.Contains(got, Mask) {
+		t.Errorf("%q has no %s", got, Mask)
+	}
+}
+
+func TestBodyEncryptedPrivateKey(t *testing.T) {
+	r := newTestRedactor(t)
+
+	got := string(r.Body([]byte(`{"address":"0x9858EfFD232B4033E47d90003D41EC34EcaEda94","encrypted_private_key":"opaque-value"}`)))
+
+	assertRedacted(t, got, "opaque-value")
+	if !strings.Contains(got, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94") {
+		t.Errorf("address was redacted: %s", got)
+	}
+}
+
+func TestBodyNestedSecretKeys(t *testing.T) {
+	r := newTestRedactor(t)
+
+	got := string(r.Body([]byte(`{"wallet":{"privateKey":"a","mnemonic":"b c d","items":[{"password":"e"}]}}`)))
+
+	want := `{"wallet":{"items":[{"password":"[REDACTED]"}],"mnemonic":"[REDACTED]","privateKey":"[REDACTED]"}}`
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestCryptoJSCiphertext(t *testing.T) {
+	r := newTestRedactor(t)
+
+	assertRedacted(t, r.Text("decrypting "+testCiphertext+"== failed"), testCiphertext)
+	assertRedacted(t, string(r.Body([]byte(`{"data":"`+testCiphertext+`"}`))), testCiphertext)
+}
+
+func TestHexPrivateKey(t *testing.T) {
+	r := newTestRedactor(t)
+
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"alone", testKey},
+		{"in text", "key=" + testKey + " loaded"},
+		{"adjacent keys", testKey + "," + testOtherKey},
+		{"space separated keys", testKey + " " + testOtherKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRedacted(t, r.Text(tt.text), testKey, testOtherKey)
+		})
+	}
+}
+
+func TestHexPrivateKeyKeepsHashes(t *testing.T) {
+	r := newTestRedactor(t)
+
+	for _, text := range []string{
+		"tx 0x" + testKey + " mined",
+		testKey + "ab", // Longer than a key
+	} {
+		if got := r.Text(text); got != text {
+			t.Errorf("Text(%q) = %q, want unchanged", text, got)
+		}
+	}
+}
+
+func TestBodySendRawTransaction(t *testing.T) {
+	r := newTestRedactor(t)
+
+	single := string(r.Body([]byte(`{"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction","params":["` + testRawTx + `"]}`)))
+	assertRedacted(t, single, testRawTx)
+
+	batch := string(r.Body([]byte(`[{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]},` +
+		`{"jsonrpc":"2.0","id":2,"method":"eth_sendRawTransaction","params":["` + testRawTx + `"]}]`)))
+	assertRedacted(t, batch, testRawTx)
+	if !strings.Contains(batch, `"method":"eth_blockNumber","params":[]`) {
+		t.Errorf("other batch entries were redacted: %s", batch)
+	}
+}
+
+func TestBodyNotJSON(t *testing.T) {
+	r := newTestRedactor(t)
+
+	assertRedacted(t, string(r.Body([]byte("key "+testKey))), testKey)
+}
+
+func TestHeader(t *testing.T) {
+	r := newTestRedactor(t)
+
+	for _, name := range []string{"Authorization", "X-Api-Key", "Cookie", "Set-Cookie", "X-Decryption-Key"} {
+		if got := r.Header(name, "secret-value"); got != Mask {
+			t.Errorf("Header(%q) = %q, want %s", name, got, Mask)
+		}
+	}
+	if got := r.Header("Content-Type", "application/json"); got != "application/json" {
+		t.Errorf("Header(Content-Type) = %q", got)
+	}
+	assertRedacted(t, r.Header("X-Debug", testKey), testKey)
+}
+
+func TestConfiguredRules(t *testing.T) {
+	r, err := New(&config.RedactionConfig{
+		Paths:       []string{"$.data.token"},
+		KeyPatterns: []string{"^session$"},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	got := string(r.Body([]byte(`{"data":{"token":"t1","other":"o"},"session":"s1"}`)))
+	want := `{"data":{"other":"o","token":"[REDACTED]"},"session":"[REDACTED]"}`
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestNewInvalidRules(t *testing.T) {
+	for _, cfg := range []*config.RedactionConfig{
+		{Paths: []string{"data.token"}},
+		{Paths: []string{"$[?method=x]"}},
+		{KeyPatterns: []string{"("}},
+		{Detectors: []string{"unknown"}},
+	} {
+		if _, err := New(cfg); err == nil {
+			t.Errorf("New(%+v) succeeded", cfg)
+		}
+	}
+}
@@ -3,12 +3,35 @@ package response
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
+	"sync/atomic"
 
 	"kokka.com/kokka/internal/shared/constant/status"
 )
 
+// useHTTPStatus makes WriteJson send the kstatus as the HTTP status instead of always 200
+var useHTTPStatus atomic.Bool
+
+// UseHTTPStatus turns the real HTTP status mode on or off (RESPONSE_HTTP_STATUS)
+func UseHTTPStatus(enabled bool) {
+	useHTTPStatus.Store(enabled)
+}
+
+// codedError is implemented by typed errors that carry a stable error code
+type codedError interface {
+	error
+	ErrorCode() string
+	IsRetryable() bool
+}
+
+// revertedError is implemented by errors that carry an EVM revert reason
+type revertedError interface {
+	RevertReason() string
+	RevertDetails() any
+}
+
 func WriteJson(w http.ResponseWriter, ctx context.Context, data any, err error, statusCode status.Code) {
 	payload := make(map[string]any)
 
@@ -29,6 +52,17 @@ func WriteJson(w http.ResponseWriter, ctx context.Context, data any, err error,
 
 	if err != nil {
 		payload["error"] = err.Error()
+		payload["error_code"], payload["retryable"] = errorCode(err, statusCode)
+
+		var reverted revertedError
+		if errors.As(err, &reverted) {
+			if reason := reverted.RevertReason(); reason != "" {
+				payload["revert_reason"] = reason
+			}
+			if details := reverted.RevertDetails(); details != nil {
+				payload["revert"] = details
+			}
+		}
 	}
 
 	// Default to not set if not set
@@ -40,8 +74,48 @@ func WriteJson(w http.ResponseWriter, ctx context.Context, data any, err error,
 		payload["kmessage"] = err.Error()
 	}
 
+	if outcome := GetOutcome(ctx); outcome != nil {
+		outcome.KStatus = statusCode
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.Header().Set("X-Content-Type-Options", "nosniff")
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(httpStatus(statusCode))
 	json.NewEncoder(w).Encode(payload)
 }
+
+// errorCode returns the stable code of err and whether it is retryable
+// Errors without a code get a generic one derived from the kstatus
+func errorCode(err error, statusCode status.Code) (string, bool) {
+	var coded codedError
+	if errors.As(err, &coded) {
+		return coded.ErrorCode(), coded.IsRetryable()
+	}
+
+	switch statusCode {
+	case status.FAIL:
+		return "BAD_REQUEST", false
+	case status.UNAUTHORIZED:
+		return "UNAUTHORIZED", false
+	case status.FORBIDDEN:
+		return "FORBIDDEN", false
+	case status.NOT_FOUND:
+		return "NOT_FOUND", false
+	case status.CONFLICT:
+		return "CONFLICT", false
+	case status.TOO_MANY_REQUESTS:
+		return "TOO_MANY_REQUESTS", true
+	case status.UNAVAILABLE:
+		return "UNAVAILABLE", true
+	default:
+		return "INTERNAL_ERROR", false
+	}
+}
+
+// httpStatus returns the HTTP status to write for a kstatus
+func httpStatus(statusCode status.Code) int {
+	if !useHTTPStatus.Load() || statusCode < 200 || statusCode > 599 {
+		return http.StatusOK
+	}
+	return statusCode
+}
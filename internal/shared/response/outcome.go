@@ -0,0 +1,34 @@
+package response
+
+import (
+	"context"
+
+	"kokka.com/kokka/internal/shared/constant/status"
+)
+
+type outcomeKey struct{}
+
+// Outcome is filled in while a request is served, so middleware can report on it afterwards
+type Outcome struct {
+	Route   string      // Matched route pattern, empty when no route matched
+	KStatus status.Code // kstatus written by WriteJson, 0 when the handler did not use it
+	TxHash  string      // Transaction signed while serving the request (see RecordTxHash), even if sending it failed
+}
+
+// WithOutcome attaches an outcome to the request context
+func WithOutcome(ctx context.Context, outcome *Outcome) context.Context {
+	return context.WithValue(ctx, outcomeKey{}, outcome)
+}
+
+// GetOutcome returns the outcome attached to the context, or nil
+func GetOutcome(ctx context.Context) *Outcome {
+	outcome, _ := ctx.Value(outcomeKey{}).(*Outcome)
+	return outcome
+}
+
+// RecordTxHash records that the request signed a transaction
+func RecordTxHash(ctx context.Context, txHash string) {
+	if outcome := GetOutcome(ctx); outcome != nil {
+		outcome.TxHash = txHash
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/md5"
+	"crypto/rand"
 	"encoding/base64"
 	"errors"
 )
@@ -34,18 +35,23 @@ func pkcs7Unpad(data []byte) ([]byte, error) {
 	return data[:len(data)-pad], nil
 }
 
+// DecryptCrypto decrypts a CryptoJS / OpenSSL "Salted__" AES-256-CBC ciphertext (legacy, unauthenticated)
+// New ciphertexts should use the Keyring envelope format
 func DecryptCrypto(encryptedBase64 string, passphrase string) (string, error) {
 	raw, err := base64.StdEncoding.DecodeString(encryptedBase64)
 	if err != nil {
 		return "", err
 	}
 
-	if string(raw[:8]) != "Salted__" {
+	if len(raw) < 16 || string(raw[:8]) != "Salted__" {
 		return "", errors.New("missing Salted__ header")
 	}
 
 	salt := raw[8:16]
 	ciphertext := raw[16:]
+	if len(ciphertext) == 0 || len(ciphertext)%aes.BlockSize != 0 {
+		return "", errors.New("invalid ciphertext length")
+	}
 
 	key, iv := evpBytesToKey([]byte(passphrase), salt, 32, 16)
 
@@ -64,3 +70,53 @@ func DecryptCrypto(encryptedBase64 string, passphrase string) (string, error) {
 
 	return string(plaintext), nil
 }
+
+// EncryptAESGCM encrypts plaintext with AES-256-GCM and returns nonce||ciphertext
+func EncryptAESGCM(plaintext []byte, key []byte) ([]byte, error) {
+	return sealAESGCM(plaintext, key, nil)
+}
+
+// DecryptAESGCM decrypts nonce||ciphertext produced by EncryptAESGCM
+func DecryptAESGCM(sealed []byte, key []byte) ([]byte, error) {
+	return openAESGCM(sealed, key, nil)
+}
+
+// sealAESGCM encrypts plaintext with AES-256-GCM, authenticating additionalData, and returns nonce||ciphertext
+func sealAESGCM(plaintext []byte, key []byte, additionalData []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// openAESGCM decrypts nonce||ciphertext produced by sealAESGCM
+func openAESGCM(sealed []byte, key []byte, additionalData []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, additionalData)
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// TokenDecimals is the number of decimals of the ERC20 tokens kokka handles
+const TokenDecimals = 18
+
+// TokenUnitsToWei converts a non-negative amount in token units, e.g. "2" or "2.5", to wei
+// The conversion is exact; digits beyond TokenDecimals are truncated
+func TokenUnitsToWei(amount string) (*big.Int, error) {
+	// big.Rat also parses fractions such as "1/3", which are not amounts
+	value, ok := new(big.Rat).SetString(amount)
+	if !ok || strings.Contains(amount, "/") {
+		return nil, fmt.Errorf("invalid amount format: %s", amount)
+	}
+	if value.Sign() < 0 {
+		return nil, fmt.Errorf("amount must not be negative: %s", amount)
+	}
+
+	decimals := new(big.Int).Exp(big.NewInt(10), big.NewInt(TokenDecimals), nil)
+	value.Mul(value, new(big.Rat).SetInt(decimals))
+	return new(big.Int).Quo(value.Num(), value.Denom()), nil
+}
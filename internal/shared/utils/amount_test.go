@@ -0,0 +1,34 @@
+package utils
+
+import "testing"
+
+func TestTokenUnitsToWei(t *testing.T) {
+	tests := []struct {
+		amount string
+		wei    string
+	}{
+		{"2", "2000000000000000000"},
+		{"2.5", "2500000000000000000"},
+		{"0", "0"},
+		{"0.000000000000000001", "1"},
+		{"0.0000000000000000019", "1"}, // Sub-wei digits are truncated
+		{"123456789.123456789", "123456789123456789000000000"},
+		{"1e3", "1000000000000000000000"},
+	}
+	for _, tt := range tests {
+		wei, err := TokenUnitsToWei(tt.amount)
+		if err != nil {
+			t.Errorf("TokenUnitsToWei(%q): %v", tt.amount, err)
+			continue
+		}
+		if wei.String() != tt.wei {
+			t.Errorf("TokenUnitsToWei(%q) = %s, want %s", tt.amount, wei, tt.wei)
+		}
+	}
+
+	for _, amount := range []string{"", "abc", "-1", "1/3"} {
+		if _, err := TokenUnitsToWei(amount); err == nil {
+			t.Errorf("TokenUnitsToWei(%q) was accepted", amount)
+		}
+	}
+}
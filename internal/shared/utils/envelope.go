@@ -0,0 +1,295 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Envelope format (base64 of):
+//
+//	magic "KKE" | version (1) | kdf (1) | key-id length (1) | key-id | salt (16) | nonce (12) | AES-256-GCM ciphertext
+//
+// Everything before the nonce is the header and is authenticated as GCM additional data,
+// so the key-id and KDF cannot be swapped without failing decryption.
+
+const (
+	envelopeMagic   = "KKE"
+	envelopeVersion = 1
+	envelopeSaltLen = 16
+)
+
+// Envelope KDFs
+const (
+	KDFScrypt   = "scrypt"
+	KDFArgon2id = "argon2id"
+)
+
+const (
+	kdfIDScrypt   byte = 1
+	kdfIDArgon2id byte = 2
+)
+
+// DefaultMaxDerivations caps the KDF runs in flight when no limit is configured
+const DefaultMaxDerivations = 4
+
+// LegacyKeyID identifies the DECRYPTION_KEY used for CryptoJS ("Salted__") ciphertexts
+const LegacyKeyID = "legacy"
+
+// EnvelopeInfo describes how a ciphertext was encrypted
+type EnvelopeInfo struct {
+	KeyID  string
+	KDF    string // Empty for legacy ciphertexts
+	Legacy bool   // CryptoJS / OpenSSL "Salted__" format
+}
+
+// Keyring holds the decryption keys by key-id and seals new envelopes with the active key
+type Keyring struct {
+	keys        map[string][]byte
+	legacyKey   []byte // Key for CryptoJS ciphertexts, nil if not configured
+	activeID    string
+	kdf         string
+	acceptKDFs  map[byte]bool // KDFs envelopes are opened with; the KDF id comes from the ciphertext
+	derivations chan struct{} // Semaphore bounding the KDF runs in flight, each costs CPU and memory
+}
+
+// NewKeyring builds a keyring from "kid:secret,kid:secret" pairs
+// legacyKey decrypts CryptoJS ciphertexts and, if no keys are given, is also the active key under LegacyKeyID
+// Envelopes are only opened with kdf or one of acceptKDFs, and at most maxDerivations keys are derived at once
+func NewKeyring(keySpec string, activeID string, legacyKey string, kdf string, acceptKDFs []string, maxDerivations int) (*Keyring, error) {
+	k := &Keyring{keys: map[string][]byte{}, activeID: activeID, kdf: kdf, acceptKDFs: map[byte]bool{}}
+
+	if k.kdf == "" {
+		k.kdf = KDFScrypt
+	}
+	for _, name := range append([]string{k.kdf}, acceptKDFs...) {
+		kdfID, err := envelopeKDFID(name)
+		if err != nil {
+			return nil, err
+		}
+		k.acceptKDFs[kdfID] = true
+	}
+
+	if maxDerivations <= 0 {
+		maxDerivations = DefaultMaxDerivations
+	}
+	k.derivations = make(chan struct{}, maxDerivations)
+
+	for _, pair := range strings.Split(keySpec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		id, secret, ok := strings.Cut(pair, ":")
+		if !ok || id == "" || secret == "" {
+			return nil, errors.New("decryption keys must be formatted as kid:secret")
+		}
+		if len(id) > 255 {
+			return nil, fmt.Errorf("key-id %s is too long", id)
+		}
+		if _, exists := k.keys[id]; exists {
+			return nil, fmt.Errorf("duplicate key-id %s", id)
+		}
+		k.keys[id] = []byte(secret)
+	}
+
+	if legacyKey != "" {
+		k.legacyKey = []byte(legacyKey)
+		if _, exists := k.keys[LegacyKeyID]; !exists {
+			k.keys[LegacyKeyID] = k.legacyKey
+		}
+	}
+
+	if k.activeID == "" {
+		switch {
+		case len(k.keys) == 1:
+			for id := range k.keys {
+				k.activeID = id
+			}
+		case len(k.keys) > 1:
+			return nil, errors.New("an active key-id is required when several decryption keys are configured")
+		}
+	}
+	if k.activeID != "" {
+		if _, ok := k.keys[k.activeID]; !ok {
+			return nil, fmt.Errorf("active key-id %s is not configured", k.activeID)
+		}
+	}
+
+	return k, nil
+}
+
+// ActiveKeyID returns the key-id new envelopes are sealed with
+func (k *Keyring) ActiveKeyID() string {
+	return k.activeID
+}
+
+// KeyIDs returns the configured key-ids, sorted
+func (k *Keyring) KeyIDs() []string {
+	ids := make([]string, 0, len(k.keys))
+	for id := range k.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Seal encrypts plaintext into a base64 envelope under the active key
+func (k *Keyring) Seal(plaintext []byte) (string, error) {
+	if k.activeID == "" {
+		return "", errors.New("no decryption key is configured")
+	}
+
+	kdfID, err := envelopeKDFID(k.kdf)
+	if err != nil {
+		return "", err
+	}
+
+	salt := make([]byte, envelopeSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	header := []byte(envelopeMagic)
+	header = append(header, envelopeVersion, kdfID, byte(len(k.activeID)))
+	header = append(header, k.activeID...)
+	header = append(header, salt...)
+
+	key, err := k.deriveEnvelopeKey(kdfID, k.keys[k.activeID], salt)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := sealAESGCM(plaintext, key, header)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(append(header, sealed...)), nil
+}
+
+// Open decrypts an envelope or a legacy CryptoJS ciphertext
+func (k *Keyring) Open(ciphertext string) ([]byte, *EnvelopeInfo, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(ciphertext))
+	if err != nil {
+		return nil, nil, errors.New("ciphertext is not valid base64")
+	}
+
+	if bytes.HasPrefix(raw, []byte("Salted__")) {
+		if k.legacyKey == nil {
+			return nil, nil, errors.New("legacy ciphertexts are not supported: DECRYPTION_KEY is not configured")
+		}
+		plaintext, err := DecryptCrypto(ciphertext, string(k.legacyKey))
+		if err != nil {
+			return nil, nil, err
+		}
+		return []byte(plaintext), &EnvelopeInfo{KeyID: LegacyKeyID, Legacy: true}, nil
+	}
+
+	return k.openEnvelope(raw)
+}
+
+// OpenString decrypts a ciphertext to a string
+func (k *Keyring) OpenString(ciphertext string) (string, error) {
+	plaintext, _, err := k.Open(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// Rewrap decrypts a ciphertext and seals it again under the active key
+func (k *Keyring) Rewrap(ciphertext string) (string, *EnvelopeInfo, error) {
+	plaintext, info, err := k.Open(ciphertext)
+	if err != nil {
+		return "", nil, err
+	}
+	defer clear(plaintext)
+
+	rewrapped, err := k.Seal(plaintext)
+	if err != nil {
+		return "", nil, err
+	}
+	return rewrapped, info, nil
+}
+
+// openEnvelope parses and decrypts a versioned envelope
+func (k *Keyring) openEnvelope(raw []byte) ([]byte, *EnvelopeInfo, error) {
+	if !bytes.HasPrefix(raw, []byte(envelopeMagic)) || len(raw) < len(envelopeMagic)+3 {
+		return nil, nil, errors.New("unrecognised ciphertext format")
+	}
+
+	pos := len(envelopeMagic)
+	version, kdfID, idLen := raw[pos], raw[pos+1], int(raw[pos+2])
+	pos += 3
+	if version != envelopeVersion {
+		return nil, nil, fmt.Errorf("unsupported envelope version %d", version)
+	}
+	if len(raw) < pos+idLen+envelopeSaltLen {
+		return nil, nil, errors.New("envelope is truncated")
+	}
+
+	keyID := string(raw[pos : pos+idLen])
+	pos += idLen
+	salt := raw[pos : pos+envelopeSaltLen]
+	pos += envelopeSaltLen
+	header, sealed := raw[:pos], raw[pos:]
+
+	secret, ok := k.keys[keyID]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown key-id %s", keyID)
+	}
+	if !k.acceptKDFs[kdfID] {
+		return nil, nil, fmt.Errorf("envelope KDF id %d is not accepted", kdfID)
+	}
+
+	key, err := k.deriveEnvelopeKey(kdfID, secret, salt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plaintext, err := openAESGCM(sealed, key, header)
+	if err != nil {
+		return nil, nil, errors.New("failed to decrypt envelope: wrong key or corrupted ciphertext")
+	}
+
+	kdf := KDFScrypt
+	if kdfID == kdfIDArgon2id {
+		kdf = KDFArgon2id
+	}
+	return plaintext, &EnvelopeInfo{KeyID: keyID, KDF: kdf}, nil
+}
+
+// envelopeKDFID returns the header id of a KDF name
+func envelopeKDFID(kdf string) (byte, error) {
+	switch kdf {
+	case KDFScrypt:
+		return kdfIDScrypt, nil
+	case KDFArgon2id:
+		return kdfIDArgon2id, nil
+	default:
+		return 0, fmt.Errorf("unsupported envelope KDF %q", kdf)
+	}
+}
+
+// deriveEnvelopeKey derives the 32-byte AES key from a secret and salt, waiting for a free derivation slot
+func (k *Keyring) deriveEnvelopeKey(kdfID byte, secret []byte, salt []byte) ([]byte, error) {
+	k.derivations <- struct{}{}
+	defer func() { <-k.derivations }()
+
+	switch kdfID {
+	case kdfIDScrypt:
+		return scrypt.Key(secret, salt, 1<<15, 8, 1, 32)
+	case kdfIDArgon2id:
+		return argon2.IDKey(secret, salt, 3, 64*1024, 4, 32), nil
+	default:
+		return nil, fmt.Errorf("unsupported envelope KDF id %d", kdfID)
+	}
+}
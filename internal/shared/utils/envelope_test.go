@@ -0,0 +1,191 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testPlaintext = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+func newTestKeyring(t *testing.T, keySpec string, activeID string, kdf string, acceptKDFs ...string) *Keyring {
+	t.Helper()
+	k, err := NewKeyring(keySpec, activeID, "legacy-pass", kdf, acceptKDFs, 2)
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	return k
+}
+
+// encryptCryptoJS encrypts like CryptoJS.AES.encrypt(plaintext, passphrase)
+func encryptCryptoJS(t *testing.T, plaintext string, passphrase string) string {
+	t.Helper()
+	salt := []byte("12345678")
+	key, iv := evpBytesToKey([]byte(passphrase), salt, 32, 16)
+
+	pad := aes.BlockSize - len(plaintext)%aes.BlockSize
+	data := append([]byte(plaintext), bytes.Repeat([]byte{byte(pad)}, pad)...)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatalf("aes.NewCipher: %v", err)
+	}
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(data, data)
+
+	return base64.StdEncoding.EncodeToString(append(append([]byte("Salted__"), salt...), data...))
+}
+
+func TestSealOpen(t *testing.T) {
+	for _, kdf := range []string{KDFScrypt, KDFArgon2id} {
+		t.Run(kdf, func(t *testing.T) {
+			k := newTestKeyring(t, "k1:secret-one", "k1", kdf)
+
+			ciphertext, err := k.Seal([]byte(testPlaintext))
+			if err != nil {
+				t.Fatalf("Seal: %v", err)
+			}
+			plaintext, info, err := k.Open(ciphertext)
+			if err != nil {
+				t.Fatalf("Open: %v", err)
+			}
+			if string(plaintext) != testPlaintext {
+				t.Errorf("Open = %q, want %q", plaintext, testPlaintext)
+			}
+			if info.KeyID != "k1" || info.KDF != kdf || info.Legacy {
+				t.Errorf("info = %+v", info)
+			}
+		})
+	}
+}
+
+func TestRewrap(t *testing.T) {
+	old := newTestKeyring(t, "k1:secret-one", "k1", KDFScrypt)
+	ciphertext, err := old.Seal([]byte(testPlaintext))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	// k2 is now active, envelopes are sealed with argon2id and scrypt ones are still opened
+	k := newTestKeyring(t, "k1:secret-one,k2:secret-two", "k2", KDFArgon2id, KDFScrypt)
+	rewrapped, info, err := k.Rewrap(ciphertext)
+	if err != nil {
+		t.Fatalf("Rewrap: %v", err)
+	}
+	if info.KeyID != "k1" || info.KDF != KDFScrypt {
+		t.Errorf("Rewrap info = %+v, want the original k1/scrypt", info)
+	}
+
+	plaintext, info, err := k.Open(rewrapped)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if string(plaintext) != testPlaintext || info.KeyID != "k2" || info.KDF != KDFArgon2id {
+		t.Errorf("Open = %q, %+v", plaintext, info)
+	}
+}
+
+func TestOpenLegacyCryptoJS(t *testing.T) {
+	k := newTestKeyring(t, "k1:secret-one", "k1", KDFScrypt)
+
+	plaintext, info, err := k.Open(encryptCryptoJS(t, testPlaintext, "legacy-pass"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if string(plaintext) != testPlaintext || !info.Legacy || info.KeyID != LegacyKeyID {
+		t.Errorf("Open = %q, %+v", plaintext, info)
+	}
+
+	if _, _, err := k.Open(encryptCryptoJS(t, testPlaintext, "other-pass")); err == nil {
+		t.Error("ciphertext of another passphrase was decrypted")
+	}
+}
+
+func TestOpenUnknownKeyID(t *testing.T) {
+	sealer := newTestKeyring(t, "k9:secret-nine", "k9", KDFScrypt)
+	ciphertext, err := sealer.Seal([]byte(testPlaintext))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	k := newTestKeyring(t, "k1:secret-one", "k1", KDFScrypt)
+	if _, _, err := k.Open(ciphertext); err == nil || !strings.Contains(err.Error(), "unknown key-id k9") {
+		t.Errorf("Open error = %v, want unknown key-id", err)
+	}
+}
+
+func TestOpenTamperedHeader(t *testing.T) {
+	k := newTestKeyring(t, "k1:secret-one,k2:secret-one", "k1", KDFScrypt)
+	ciphertext, err := k.Seal([]byte(testPlaintext))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	raw, _ := base64.StdEncoding.DecodeString(ciphertext)
+
+	// Same secret under another key-id: only the authenticated header tells them apart
+	keyIDPos := len(envelopeMagic) + 3
+	raw[keyIDPos+1] = '2'
+	if _, _, err := k.Open(base64.StdEncoding.EncodeToString(raw)); err == nil {
+		t.Error("envelope with a swapped key-id was decrypted")
+	}
+
+	raw[keyIDPos+1] = '1'
+	raw[keyIDPos+2] ^= 0xff // First salt byte
+	if _, _, err := k.Open(base64.StdEncoding.EncodeToString(raw)); err == nil {
+		t.Error("envelope with a modified salt was decrypted")
+	}
+}
+
+func TestOpenRefusesOtherKDF(t *testing.T) {
+	sealer := newTestKeyring(t, "k1:secret-one", "k1", KDFArgon2id)
+	ciphertext, err := sealer.Seal([]byte(testPlaintext))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	k := newTestKeyring(t, "k1:secret-one", "k1", KDFScrypt)
+	if _, _, err := k.Open(ciphertext); err == nil || !strings.Contains(err.Error(), "not accepted") {
+		t.Errorf("Open error = %v, want the KDF refused", err)
+	}
+}
+
+func TestDerivationsAreBounded(t *testing.T) {
+	k, err := NewKeyring("k1:secret-one", "", "", KDFScrypt, nil, 1)
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	ciphertext, err := k.Seal([]byte(testPlaintext))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	k.derivations <- struct{}{} // Occupy the only slot
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := k.Open(ciphertext)
+		done <- err
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Open derived a key while no slot was free")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	<-k.derivations
+	if err := <-done; err != nil {
+		t.Errorf("Open: %v", err)
+	}
+}
+
+func TestNewKeyringInvalidKDF(t *testing.T) {
+	if _, err := NewKeyring("k1:secret-one", "", "", "pbkdf2", nil, 1); err == nil {
+		t.Error("unsupported KDF was accepted")
+	}
+	if _, err := NewKeyring("k1:secret-one", "", "", KDFScrypt, []string{"md5"}, 1); err == nil {
+		t.Error("unsupported accepted KDF was accepted")
+	}
+}
@@ -0,0 +1,206 @@
+package signerd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// PolicyConfig is the signer-side policy file format
+type PolicyConfig struct {
+	ChainIDs []string              `json:"chain_ids"` // Decimal chain IDs the signer may sign for
+	Accounts map[string]RuleConfig `json:"accounts"`  // Rules per signing address
+	Default  *RuleConfig           `json:"default"`   // Optional rule for accounts not listed
+}
+
+// RuleConfig restricts what an account may sign
+type RuleConfig struct {
+	AllowedTo        []string `json:"allowed_to"`         // Allowed recipients/contracts, empty means any
+	AllowedSelectors []string `json:"allowed_selectors"`  // Allowed 4-byte method selectors, empty means any
+	MaxValueWei      string   `json:"max_value_wei"`      // Max native value per tx, empty means unlimited
+	MaxGas           uint64   `json:"max_gas"`            // Max gas limit per tx, 0 means unlimited
+	AllowTypedData   bool     `json:"allow_typed_data"`   // Whether EIP-712 signing is allowed
+	AllowMessages    bool     `json:"allow_messages"`     // Whether EIP-191 personal message signing is allowed
+	AllowContractNew bool     `json:"allow_contract_new"` // Whether contract creation is allowed
+}
+
+type rule struct {
+	allowedTo        map[common.Address]bool
+	allowedSelectors map[string]bool
+	maxValue         *big.Int
+	maxGas           uint64
+	allowTypedData   bool
+	allowMessages    bool
+	allowContractNew bool
+}
+
+// Policy decides what the signer daemon is willing to sign
+type Policy struct {
+	chainIDs    map[string]bool
+	accounts    map[common.Address]*rule
+	defaultRule *rule
+}
+
+// LoadPolicy loads a signer policy from a JSON file
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signer policy: %w", err)
+	}
+
+	var cfg PolicyConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse signer policy: %w", err)
+	}
+
+	p := &Policy{
+		chainIDs: make(map[string]bool, len(cfg.ChainIDs)),
+		accounts: make(map[common.Address]*rule, len(cfg.Accounts)),
+	}
+	for _, chainID := range cfg.ChainIDs {
+		p.chainIDs[chainID] = true
+	}
+	if len(p.chainIDs) == 0 {
+		return nil, fmt.Errorf("signer policy must list at least one chain id")
+	}
+
+	for address, rc := range cfg.Accounts {
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("signer policy: invalid account address %s", address)
+		}
+		r, err := newRule(rc)
+		if err != nil {
+			return nil, fmt.Errorf("signer policy: account %s: %w", address, err)
+		}
+		p.accounts[common.HexToAddress(address)] = r
+	}
+
+	if cfg.Default != nil {
+		r, err := newRule(*cfg.Default)
+		if err != nil {
+			return nil, fmt.Errorf("signer policy: default: %w", err)
+		}
+		p.defaultRule = r
+	}
+
+	return p, nil
+}
+
+// CheckTx returns an error if the account may not sign the transaction
+func (p *Policy) CheckTx(account common.Address, tx *types.Transaction, chainID *big.Int) error {
+	if !p.chainIDs[chainID.String()] {
+		return fmt.Errorf("chain id %s is not allowed", chainID.String())
+	}
+
+	r := p.ruleFor(account)
+	if r == nil {
+		return fmt.Errorf("account %s is not allowed to sign", account.Hex())
+	}
+
+	if tx.To() == nil {
+		if !r.allowContractNew {
+			return fmt.Errorf("contract creation is not allowed")
+		}
+	} else if len(r.allowedTo) > 0 && !r.allowedTo[*tx.To()] {
+		return fmt.Errorf("recipient %s is not allowed", tx.To().Hex())
+	}
+
+	if len(r.allowedSelectors) > 0 {
+		data := tx.Data()
+		if len(data) < 4 || !r.allowedSelectors[common.Bytes2Hex(data[:4])] {
+			return fmt.Errorf("method is not allowed")
+		}
+	}
+
+	if r.maxValue != nil && tx.Value().Cmp(r.maxValue) > 0 {
+		return fmt.Errorf("value exceeds limit of %s wei", r.maxValue.String())
+	}
+
+	if r.maxGas > 0 && tx.Gas() > r.maxGas {
+		return fmt.Errorf("gas limit exceeds limit of %d", r.maxGas)
+	}
+
+	return nil
+}
+
+// CheckTypedData returns an error if the account may not sign typed data for the domain's chain
+// Typed data without a domain chain id is refused, as its signature would be valid on every chain
+func (p *Policy) CheckTypedData(account common.Address, chainID *big.Int) error {
+	if chainID == nil {
+		return fmt.Errorf("typed data domain must set chainId")
+	}
+	if !p.chainIDs[chainID.String()] {
+		return fmt.Errorf("chain id %s is not allowed", chainID.String())
+	}
+
+	r := p.ruleFor(account)
+	if r == nil {
+		return fmt.Errorf("account %s is not allowed to sign", account.Hex())
+	}
+	if !r.allowTypedData {
+		return fmt.Errorf("typed data signing is not allowed for %s", account.Hex())
+	}
+	return nil
+}
+
+// CheckMessage returns an error if the account may not sign personal messages
+func (p *Policy) CheckMessage(account common.Address) error {
+	r := p.ruleFor(account)
+	if r == nil {
+		return fmt.Errorf("account %s is not allowed to sign", account.Hex())
+	}
+	if !r.allowMessages {
+		return fmt.Errorf("message signing is not allowed for %s", account.Hex())
+	}
+	return nil
+}
+
+// ruleFor returns the rule for an account, falling back to the default rule
+func (p *Policy) ruleFor(account common.Address) *rule {
+	if r, ok := p.accounts[account]; ok {
+		return r
+	}
+	return p.defaultRule
+}
+
+// newRule parses a rule configuration
+func newRule(rc RuleConfig) (*rule, error) {
+	r := &rule{
+		allowedTo:        make(map[common.Address]bool, len(rc.AllowedTo)),
+		allowedSelectors: make(map[string]bool, len(rc.AllowedSelectors)),
+		maxGas:           rc.MaxGas,
+		allowTypedData:   rc.AllowTypedData,
+		allowMessages:    rc.AllowMessages,
+		allowContractNew: rc.AllowContractNew,
+	}
+
+	for _, address := range rc.AllowedTo {
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid allowed_to address %s", address)
+		}
+		r.allowedTo[common.HexToAddress(address)] = true
+	}
+
+	for _, selector := range rc.AllowedSelectors {
+		selector = strings.ToLower(strings.TrimPrefix(selector, "0x"))
+		if len(selector) != 8 {
+			return nil, fmt.Errorf("invalid selector %s", selector)
+		}
+		r.allowedSelectors[selector] = true
+	}
+
+	if rc.MaxValueWei != "" {
+		maxValue, ok := new(big.Int).SetString(rc.MaxValueWei, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid max_value_wei %s", rc.MaxValueWei)
+		}
+		r.maxValue = maxValue
+	}
+
+	return r, nil
+}
@@ -0,0 +1,149 @@
+package signerd
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	testAccount   = common.HexToAddress("0x2c7536E3605D9C16a7a3D7b1898e529396a65c23")
+	testOther     = common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")
+	testRecipient = common.HexToAddress("0x3535353535353535353535353535353535353535")
+)
+
+// writePolicy writes a signer policy file and loads it
+func writePolicy(t *testing.T, cfg PolicyConfig) *Policy {
+	t.Helper()
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "signer-policy.json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	p, err := LoadPolicy(path)
+	if err != nil {
+		t.Fatalf("LoadPolicy: %v", err)
+	}
+	return p
+}
+
+func testPolicy(t *testing.T) *Policy {
+	return writePolicy(t, PolicyConfig{
+		ChainIDs: []string{"31337"},
+		Accounts: map[string]RuleConfig{
+			testAccount.Hex(): {
+				AllowedTo:        []string{testRecipient.Hex()},
+				AllowedSelectors: []string{"0xa9059cbb"},
+				MaxValueWei:      "1000",
+				MaxGas:           100000,
+				AllowTypedData:   true,
+				AllowMessages:    true,
+			},
+		},
+	})
+}
+
+func testTx(to *common.Address, value int64, gas uint64, data []byte) *types.Transaction {
+	return types.NewTx(&types.LegacyTx{Nonce: 1, To: to, Value: big.NewInt(value), Gas: gas, GasPrice: big.NewInt(1), Data: data})
+}
+
+func TestCheckTx(t *testing.T) {
+	p := testPolicy(t)
+	transfer := common.FromHex("0xa9059cbb" + "00")
+	other := common.FromHex("0x095ea7b3" + "00")
+
+	tests := []struct {
+		name    string
+		account common.Address
+		tx      *types.Transaction
+		chainID int64
+		allowed bool
+	}{
+		{"allowed", testAccount, testTx(&testRecipient, 1000, 100000, transfer), 31337, true},
+		{"other chain", testAccount, testTx(&testRecipient, 1, 21000, transfer), 1, false},
+		{"unlisted account", testOther, testTx(&testRecipient, 1, 21000, transfer), 31337, false},
+		{"other recipient", testAccount, testTx(&testOther, 1, 21000, transfer), 31337, false},
+		{"other method", testAccount, testTx(&testRecipient, 1, 21000, other), 31337, false},
+		{"no method", testAccount, testTx(&testRecipient, 1, 21000, nil), 31337, false},
+		{"value over limit", testAccount, testTx(&testRecipient, 1001, 21000, transfer), 31337, false},
+		{"gas over limit", testAccount, testTx(&testRecipient, 1, 100001, transfer), 31337, false},
+		{"contract creation", testAccount, testTx(nil, 0, 21000, transfer), 31337, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.CheckTx(tt.account, tt.tx, big.NewInt(tt.chainID))
+			if allowed := err == nil; allowed != tt.allowed {
+				t.Errorf("allowed = %v (%v), want %v", allowed, err, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestCheckTypedData(t *testing.T) {
+	p := testPolicy(t)
+
+	tests := []struct {
+		name    string
+		account common.Address
+		chainID *big.Int
+		allowed bool
+	}{
+		{"allowed", testAccount, big.NewInt(31337), true},
+		{"other chain", testAccount, big.NewInt(1), false},
+		{"no chain", testAccount, nil, false},
+		{"unlisted account", testOther, big.NewInt(31337), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.CheckTypedData(tt.account, tt.chainID)
+			if allowed := err == nil; allowed != tt.allowed {
+				t.Errorf("allowed = %v (%v), want %v", allowed, err, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestCheckDefaultRule(t *testing.T) {
+	p := writePolicy(t, PolicyConfig{
+		ChainIDs: []string{"31337"},
+		Default:  &RuleConfig{AllowMessages: true},
+	})
+
+	if err := p.CheckMessage(testOther); err != nil {
+		t.Errorf("CheckMessage with the default rule: %v", err)
+	}
+	if err := p.CheckTypedData(testOther, big.NewInt(31337)); err == nil {
+		t.Error("typed data was allowed without allow_typed_data")
+	}
+	if err := p.CheckTx(testOther, testTx(nil, 0, 21000, nil), big.NewInt(31337)); err == nil {
+		t.Error("contract creation was allowed without allow_contract_new")
+	}
+}
+
+func TestLoadPolicyInvalid(t *testing.T) {
+	for name, cfg := range map[string]PolicyConfig{
+		"no chain ids":     {},
+		"invalid account":  {ChainIDs: []string{"1"}, Accounts: map[string]RuleConfig{"0x12": {}}},
+		"invalid selector": {ChainIDs: []string{"1"}, Default: &RuleConfig{AllowedSelectors: []string{"0xa9"}}},
+		"invalid value":    {ChainIDs: []string{"1"}, Default: &RuleConfig{MaxValueWei: "1e18"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			data, _ := json.Marshal(cfg)
+			path := filepath.Join(t.TempDir(), "signer-policy.json")
+			if err := os.WriteFile(path, data, 0600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadPolicy(path); err == nil {
+				t.Error("invalid policy was loaded")
+			}
+		})
+	}
+}
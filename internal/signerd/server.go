@@ -0,0 +1,289 @@
+package signerd
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"kokka.com/kokka/internal/driven-adapter/external/blockchain"
+)
+
+// Config holds the signer daemon configuration
+type Config struct {
+	Listen      string // "unix:///path/to/socket" or "host:port"
+	KeystoreDir string
+	Passphrase  string
+	AuthToken   string // Optional bearer token required from callers
+	Policy      *Policy
+}
+
+// Server is the kokka-signer daemon
+// Keys never leave this process; every request is checked against the signer policy
+type Server struct {
+	config   *Config
+	keystore *keystore.KeyStore
+	server   *http.Server
+}
+
+// NewServer creates a new signer daemon
+func NewServer(config *Config) (*Server, error) {
+	if config.Policy == nil {
+		return nil, fmt.Errorf("signer policy is required")
+	}
+
+	ks := keystore.NewKeyStore(config.KeystoreDir, keystore.StandardScryptN, keystore.StandardScryptP)
+	if len(ks.Accounts()) == 0 {
+		return nil, fmt.Errorf("no accounts found in keystore %s", config.KeystoreDir)
+	}
+
+	s := &Server{
+		config:   config,
+		keystore: ks,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/accounts", s.authenticate(s.handleAccounts))
+	mux.HandleFunc("POST /v1/sign-tx", s.authenticate(s.handleSignTx))
+	mux.HandleFunc("POST /v1/sign-typed-data", s.authenticate(s.handleSignTypedData))
+	mux.HandleFunc("POST /v1/sign-message", s.authenticate(s.handleSignMessage))
+
+	s.server = &http.Server{
+		Handler:      mux,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 60 * time.Second,
+	}
+
+	return s, nil
+}
+
+// ListenAndServe serves on the configured unix socket or TCP address until the context is done
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	listener, err := s.listen()
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = s.server.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("kokka-signer listening on %s with %d account(s)", s.config.Listen, len(s.keystore.Accounts()))
+	if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// listen opens the unix socket (owner-only permissions) or TCP listener
+func (s *Server) listen() (net.Listener, error) {
+	if socketPath, ok := strings.CutPrefix(s.config.Listen, "unix://"); ok {
+		_ = os.Remove(socketPath) // Remove a stale socket from a previous run
+		listener, err := net.Listen("unix", socketPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen on %s: %w", socketPath, err)
+		}
+		if err := os.Chmod(socketPath, 0600); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+		}
+		return listener, nil
+	}
+
+	if s.config.AuthToken == "" {
+		return nil, fmt.Errorf("an auth token is required when listening on TCP")
+	}
+	return net.Listen("tcp", s.config.Listen)
+}
+
+// authenticate checks the bearer token when one is configured
+func (s *Server) authenticate(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.config.AuthToken != "" {
+			token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(token), []byte(s.config.AuthToken)) != 1 {
+				writeError(w, http.StatusUnauthorized, fmt.Errorf("invalid auth token"))
+				return
+			}
+		}
+		next(w, r)
+	}
+}
+
+// handleAccounts handles GET /v1/accounts
+func (s *Server) handleAccounts(w http.ResponseWriter, r *http.Request) {
+	resp := blockchain.RemoteAccountsResponse{Accounts: []string{}}
+	for _, account := range s.keystore.Accounts() {
+		resp.Accounts = append(resp.Accounts, account.Address.Hex())
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleSignTx handles POST /v1/sign-tx
+func (s *Server) handleSignTx(w http.ResponseWriter, r *http.Request) {
+	var req blockchain.RemoteSignTxRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid parameters"))
+		return
+	}
+
+	account, err := s.findAccount(req.Address)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	chainID, ok := new(big.Int).SetString(req.ChainID, 10)
+	if !ok {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid chain_id"))
+		return
+	}
+
+	rawTx, err := hexutil.Decode(req.Tx)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid tx encoding"))
+		return
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(rawTx); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid tx: %w", err))
+		return
+	}
+
+	if err := s.config.Policy.CheckTx(account.Address, tx, chainID); err != nil {
+		log.Printf("refused sign-tx for %s: %v", account.Address.Hex(), err)
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	signedTx, err := s.keystore.SignTxWithPassphrase(account, s.config.Passphrase, tx, chainID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to sign transaction: %w", err))
+		return
+	}
+
+	signedBytes, err := signedTx.MarshalBinary()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to encode transaction: %w", err))
+		return
+	}
+
+	log.Printf("signed tx %s for %s (nonce %d)", signedTx.Hash().Hex(), account.Address.Hex(), signedTx.Nonce())
+	writeJSON(w, http.StatusOK, blockchain.RemoteSignTxResponse{SignedTx: hexutil.Encode(signedBytes)})
+}
+
+// handleSignTypedData handles POST /v1/sign-typed-data
+func (s *Server) handleSignTypedData(w http.ResponseWriter, r *http.Request) {
+	var req blockchain.RemoteSignTypedDataRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid parameters"))
+		return
+	}
+
+	account, err := s.findAccount(req.Address)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	if err := s.config.Policy.CheckTypedData(account.Address, (*big.Int)(req.TypedData.Domain.ChainId)); err != nil {
+		log.Printf("refused sign-typed-data for %s: %v", account.Address.Hex(), err)
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	hash, _, err := apitypes.TypedDataAndHash(req.TypedData)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid typed data: %w", err))
+		return
+	}
+
+	signature, err := s.keystore.SignHashWithPassphrase(account, s.config.Passphrase, hash)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to sign typed data: %w", err))
+		return
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+
+	writeJSON(w, http.StatusOK, blockchain.RemoteSignatureResponse{Signature: hexutil.Encode(signature)})
+}
+
+// handleSignMessage handles POST /v1/sign-message
+func (s *Server) handleSignMessage(w http.ResponseWriter, r *http.Request) {
+	var req blockchain.RemoteSignMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid parameters"))
+		return
+	}
+
+	account, err := s.findAccount(req.Address)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	if err := s.config.Policy.CheckMessage(account.Address); err != nil {
+		log.Printf("refused sign-message for %s: %v", account.Address.Hex(), err)
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	message, err := hexutil.Decode(req.Message)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid message encoding"))
+		return
+	}
+
+	hash := blockchain.MessageHash(message)
+	signature, err := s.keystore.SignHashWithPassphrase(account, s.config.Passphrase, hash[:])
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to sign message: %w", err))
+		return
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+
+	log.Printf("signed message %s for %s", hash.Hex(), account.Address.Hex())
+	writeJSON(w, http.StatusOK, blockchain.RemoteSignatureResponse{Signature: hexutil.Encode(signature)})
+}
+
+// findAccount looks up a keystore account by address
+func (s *Server) findAccount(address string) (accounts.Account, error) {
+	if !common.IsHexAddress(address) {
+		return accounts.Account{}, fmt.Errorf("invalid address")
+	}
+	account, err := s.keystore.Find(accounts.Account{Address: common.HexToAddress(address)})
+	if err != nil {
+		return accounts.Account{}, fmt.Errorf("unknown account %s", address)
+	}
+	return account, nil
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, statusCode int, payload any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+// writeError writes a JSON error response
+func writeError(w http.ResponseWriter, statusCode int, err error) {
+	writeJSON(w, statusCode, blockchain.RemoteErrorResponse{Error: err.Error()})
+}
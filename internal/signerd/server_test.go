@@ -0,0 +1,225 @@
+package signerd
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"kokka.com/kokka/internal/driven-adapter/external/blockchain"
+)
+
+const (
+	testKey        = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318" // testAccount
+	testUnlisted   = "8da4ef21b864d2cc526dbdb2a120bd2874c36c9d0a1fb7f8c63d7f7a8b41de8f" // In the keystore, not in the policy
+	testPassphrase = "keystore-passphrase"
+	testAuthToken  = "signer-token"
+)
+
+// newTestServer serves a signer daemon whose keystore holds testAccount and the unlisted account
+func newTestServer(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+	dir := t.TempDir()
+
+	// Light scrypt keeps the test fast; the daemon reads the KDF parameters from the key file
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	var unlisted string
+	for _, hexKey := range []string{testKey, testUnlisted} {
+		key, err := crypto.HexToECDSA(hexKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		account, err := ks.ImportECDSA(key, testPassphrase)
+		if err != nil {
+			t.Fatalf("ImportECDSA: %v", err)
+		}
+		unlisted = account.Address.Hex()
+	}
+
+	s, err := NewServer(&Config{KeystoreDir: dir, Passphrase: testPassphrase, AuthToken: testAuthToken, Policy: testPolicy(t)})
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	server := httptest.NewServer(s.server.Handler)
+	t.Cleanup(server.Close)
+	return server, unlisted
+}
+
+// post sends a JSON request with the given bearer token and decodes the response into result
+func post(t *testing.T, server *httptest.Server, path string, token string, body any, result any) int {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest(http.MethodPost, server.URL+path, bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST %s: %v", path, err)
+	}
+	defer resp.Body.Close()
+	if result != nil {
+		_ = json.NewDecoder(resp.Body).Decode(result)
+	}
+	return resp.StatusCode
+}
+
+func testSignTxRequest(t *testing.T, address string, chainID string) blockchain.RemoteSignTxRequest {
+	t.Helper()
+	raw, err := testTx(&testRecipient, 1, 21000, hexutil.MustDecode("0xa9059cbb00")).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return blockchain.RemoteSignTxRequest{Address: address, ChainID: chainID, Tx: hexutil.Encode(raw)}
+}
+
+func testSignTypedDataRequest(address string, chainID int64) blockchain.RemoteSignTypedDataRequest {
+	return blockchain.RemoteSignTypedDataRequest{
+		Address: address,
+		TypedData: apitypes.TypedData{
+			Types: apitypes.Types{
+				"EIP712Domain": {{Name: "name", Type: "string"}, {Name: "chainId", Type: "uint256"}},
+				"Mail":         {{Name: "contents", Type: "string"}},
+			},
+			PrimaryType: "Mail",
+			Domain:      apitypes.TypedDataDomain{Name: "kokka", ChainId: math.NewHexOrDecimal256(chainID)},
+			Message:     apitypes.TypedDataMessage{"contents": "hello"},
+		},
+	}
+}
+
+func TestServerAuth(t *testing.T) {
+	server, unlisted := newTestServer(t)
+	accounts := map[string]bool{testAccount.Hex(): true, unlisted: true}
+
+	for _, token := range []string{"", "wrong-token"} {
+		var resp blockchain.RemoteErrorResponse
+		if code := post(t, server, "/v1/sign-message", token, blockchain.RemoteSignMessageRequest{
+			Address: testAccount.Hex(), Message: "0x68656c6c6f",
+		}, &resp); code != http.StatusUnauthorized {
+			t.Errorf("token %q: status %d (%s), want 401", token, code, resp.Error)
+		}
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/accounts", nil)
+	req.Header.Set("Authorization", "Bearer "+testAuthToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /v1/accounts: %v", err)
+	}
+	defer resp.Body.Close()
+	var listed blockchain.RemoteAccountsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&listed); err != nil || len(listed.Accounts) != len(accounts) {
+		t.Fatalf("GET /v1/accounts = %+v (%v)", listed, err)
+	}
+	for _, account := range listed.Accounts {
+		if !accounts[account] {
+			t.Errorf("GET /v1/accounts lists %s", account)
+		}
+	}
+}
+
+func TestServerSignTx(t *testing.T) {
+	server, unlisted := newTestServer(t)
+
+	var signed blockchain.RemoteSignTxResponse
+	if code := post(t, server, "/v1/sign-tx", testAuthToken, testSignTxRequest(t, testAccount.Hex(), "31337"), &signed); code != http.StatusOK {
+		t.Fatalf("sign-tx: status %d", code)
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(hexutil.MustDecode(signed.SignedTx)); err != nil {
+		t.Fatalf("signed tx: %v", err)
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(31337)), tx)
+	if err != nil || sender != testAccount {
+		t.Errorf("signed tx sender = %s (%v), want %s", sender.Hex(), err, testAccount.Hex())
+	}
+
+	tests := []struct {
+		name string
+		req  blockchain.RemoteSignTxRequest
+		code int
+	}{
+		{"refused chain", testSignTxRequest(t, testAccount.Hex(), "1"), http.StatusForbidden},
+		{"refused account", testSignTxRequest(t, unlisted, "31337"), http.StatusForbidden},
+		{"unknown account", testSignTxRequest(t, testOther.Hex(), "31337"), http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp blockchain.RemoteErrorResponse
+			if code := post(t, server, "/v1/sign-tx", testAuthToken, tt.req, &resp); code != tt.code {
+				t.Errorf("status %d (%s), want %d", code, resp.Error, tt.code)
+			}
+		})
+	}
+}
+
+func TestServerSignTypedData(t *testing.T) {
+	server, unlisted := newTestServer(t)
+
+	req := testSignTypedDataRequest(testAccount.Hex(), 31337)
+	var signed blockchain.RemoteSignatureResponse
+	if code := post(t, server, "/v1/sign-typed-data", testAuthToken, req, &signed); code != http.StatusOK {
+		t.Fatalf("sign-typed-data: status %d", code)
+	}
+	signer, err := blockchain.RecoverTypedDataSigner(req.TypedData, hexutil.MustDecode(signed.Signature))
+	if err != nil || signer != testAccount {
+		t.Errorf("typed data signer = %s (%v), want %s", signer.Hex(), err, testAccount.Hex())
+	}
+
+	tests := []struct {
+		name string
+		req  blockchain.RemoteSignTypedDataRequest
+		code int
+	}{
+		{"refused chain", testSignTypedDataRequest(testAccount.Hex(), 1), http.StatusForbidden},
+		{"refused account", testSignTypedDataRequest(unlisted, 31337), http.StatusForbidden},
+		{"unknown account", testSignTypedDataRequest(testOther.Hex(), 31337), http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp blockchain.RemoteErrorResponse
+			if code := post(t, server, "/v1/sign-typed-data", testAuthToken, tt.req, &resp); code != tt.code {
+				t.Errorf("status %d (%s), want %d", code, resp.Error, tt.code)
+			}
+		})
+	}
+}
+
+func TestServerSignMessage(t *testing.T) {
+	server, unlisted := newTestServer(t)
+
+	var signed blockchain.RemoteSignatureResponse
+	if code := post(t, server, "/v1/sign-message", testAuthToken, blockchain.RemoteSignMessageRequest{
+		Address: testAccount.Hex(), Message: hexutil.Encode([]byte("hello")),
+	}, &signed); code != http.StatusOK {
+		t.Fatalf("sign-message: status %d", code)
+	}
+	signer, err := blockchain.RecoverSigner(blockchain.MessageHash([]byte("hello")), hexutil.MustDecode(signed.Signature))
+	if err != nil || signer != testAccount {
+		t.Errorf("message signer = %s (%v), want %s", signer.Hex(), err, testAccount.Hex())
+	}
+
+	for address, want := range map[string]int{unlisted: http.StatusForbidden, testOther.Hex(): http.StatusNotFound} {
+		var resp blockchain.RemoteErrorResponse
+		if code := post(t, server, "/v1/sign-message", testAuthToken, blockchain.RemoteSignMessageRequest{
+			Address: address, Message: "0x00",
+		}, &resp); code != want {
+			t.Errorf("%s: status %d (%s), want %d", address, code, resp.Error, want)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS indexer_cursors;
+DROP TABLE IF EXISTS token_transfers;
@@ -0,0 +1,30 @@
+-- Journal of every kokka-initiated write (mint, burn, transfer, swap, sign-and-send)
+CREATE TABLE transactions (
+    id VARCHAR(64) PRIMARY KEY,
+    operation VARCHAR(32) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    requested_by VARCHAR(128) NOT NULL,
+    wallet_id VARCHAR(64),
+    payload TEXT NOT NULL,
+    signer_address VARCHAR(42),
+    nonce BIGINT,
+    tx_hash VARCHAR(66),
+    error TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_transactions_status ON transactions (status);
+CREATE INDEX idx_transactions_tx_hash ON transactions (tx_hash);
+CREATE INDEX idx_transactions_created_at ON transactions (created_at);
+
+-- Status transitions of journal entries
+CREATE TABLE transaction_events (
+    id VARCHAR(64) PRIMARY KEY,
+    transaction_id VARCHAR(64) NOT NULL REFERENCES transactions (id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL,
+    detail TEXT,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_transaction_events_transaction_id ON transaction_events (transaction_id);
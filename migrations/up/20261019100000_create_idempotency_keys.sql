@@ -0,0 +1,15 @@
+-- Results of write requests sent with an Idempotency-Key header, replayed on retry
+CREATE TABLE idempotency_keys (
+    client_id VARCHAR(128) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    response_code INTEGER,
+    response_body TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (client_id, idempotency_key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
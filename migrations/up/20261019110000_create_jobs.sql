@@ -0,0 +1,24 @@
+-- Pause flag and singleton lock of each background job, shared by all server instances
+CREATE TABLE job_states (
+    name VARCHAR(64) PRIMARY KEY,
+    paused BOOLEAN NOT NULL DEFAULT FALSE,
+    locked_by VARCHAR(128),
+    locked_until TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- Run history of background jobs
+CREATE TABLE job_runs (
+    id VARCHAR(64) PRIMARY KEY,
+    job VARCHAR(64) NOT NULL,
+    trigger_type VARCHAR(16) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    owner VARCHAR(128) NOT NULL,
+    detail TEXT,
+    error TEXT,
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX idx_job_runs_job_started_at ON job_runs (job, started_at);
+CREATE INDEX idx_job_runs_started_at ON job_runs (started_at);
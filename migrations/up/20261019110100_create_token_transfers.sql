@@ -0,0 +1,23 @@
+-- ERC-20 Transfer events indexed from the chain
+CREATE TABLE token_transfers (
+    id VARCHAR(96) PRIMARY KEY,
+    token VARCHAR(42) NOT NULL,
+    from_address VARCHAR(42) NOT NULL,
+    to_address VARCHAR(42) NOT NULL,
+    amount VARCHAR(78) NOT NULL,
+    block_number BIGINT NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    log_index BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_token_transfers_token_block ON token_transfers (token, block_number);
+CREATE INDEX idx_token_transfers_from_address ON token_transfers (from_address);
+CREATE INDEX idx_token_transfers_to_address ON token_transfers (to_address);
+
+-- Last block processed by each indexer
+CREATE TABLE indexer_cursors (
+    name VARCHAR(64) PRIMARY KEY,
+    block_number BIGINT NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
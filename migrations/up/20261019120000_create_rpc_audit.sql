@@ -0,0 +1,16 @@
+-- Audit trail of JSON-RPC calls made through POST /blockchain/rpc, one row per call (batches share request_id)
+CREATE TABLE rpc_audit (
+    id VARCHAR(64) PRIMARY KEY,
+    client_id VARCHAR(128) NOT NULL,
+    request_id VARCHAR(64),
+    method VARCHAR(128) NOT NULL,
+    batch BOOLEAN NOT NULL DEFAULT FALSE,
+    outcome VARCHAR(16) NOT NULL,
+    error TEXT,
+    params_bytes INTEGER NOT NULL,
+    duration_ms BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_rpc_audit_client_created_at ON rpc_audit (client_id, created_at);
+CREATE INDEX idx_rpc_audit_created_at ON rpc_audit (created_at);
@@ -0,0 +1,17 @@
+-- Custodial wallets, shared by every instance and the sweeper (keys are stored encrypted under WALLET_MASTER_KEY)
+CREATE TABLE wallets (
+    id VARCHAR(64) PRIMARY KEY,
+    address VARCHAR(42) NOT NULL,
+    label VARCHAR(255),
+    kind VARCHAR(16) NOT NULL,
+    encrypted_key TEXT,
+    keystore_path TEXT,
+    derivation_index BIGINT,
+    derivation_path VARCHAR(64),
+    created_by VARCHAR(128),
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_wallets_address ON wallets (LOWER(address));
+CREATE UNIQUE INDEX idx_wallets_derivation_index ON wallets (derivation_index);
+CREATE INDEX idx_wallets_created_at ON wallets (created_at);
@@ -0,0 +1,14 @@
+#!/usr/bin/env bash
+
+KOKKA_HOME="/apps/kokka"
+cd $KOKKA_HOME
+
+echo "$KOKKA_HOME"
+echo "Applying database migrations..."
+
+# The server refuses to start while migrations are pending
+if ! ./dist/server migrate up; then
+    echo "ERROR: Migrations failed!"
+    exit 1
+fi
+echo "Migrations applied!"
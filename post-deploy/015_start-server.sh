@@ -3,15 +3,45 @@
 KOKKA_HOME="/apps/kokka"
 cd $KOKKA_HOME
 
+# How long to wait for GET /readyz before failing the deploy
+READY_WAIT_SECONDS=${READY_WAIT_SECONDS:-60}
+
 echo "$KOKKA_HOME"
 echo "Starting new server..."
 
-# Store output in a logfile and save the PID to a file so we can kill the process later
+# Keep the previous run's console output; application logs go to LOG_FILE_PATH, which the server rotates
+if [ -f /apps/kokka/gosvr.log ]; then
+    mv /apps/kokka/gosvr.log /apps/kokka/gosvr.log.1
+fi
+
+# Store console output (startup errors, panics) in a logfile and save the PID to a file so we can kill the process later
 ./dist/server >> /apps/kokka/gosvr.log 2>&1 & echo $! > /apps/kokka/gosvr.pid
 
-echo "Verifying the server is running..."
-if ! ps -p $(cat /apps/kokka/gosvr.pid) > /dev/null 2>&1; then
-    echo "ERROR: Process is not running!"
-    exit 1
+# Probe the port and scheme the server listens on
+PORT=$(grep -E '^SERVER_PORT=' .env | tail -n 1 | cut -d= -f2- | tr -d '"')
+SCHEME="http"
+if grep -qE '^HTTPS_CERT_FILE=.+' .env; then
+    SCHEME="https"
 fi
+READY_URL="$SCHEME://127.0.0.1:${PORT:-9544}/readyz"
+
+echo "Waiting for $READY_URL..."
+WAITED=0
+while true; do
+    if ! ps -p $(cat /apps/kokka/gosvr.pid) > /dev/null 2>&1; then
+        echo "ERROR: Process is not running!"
+        exit 1
+    fi
+    if curl -sfk -o /dev/null --max-time 10 "$READY_URL"; then
+        break
+    fi
+    if [ $WAITED -ge $READY_WAIT_SECONDS ]; then
+        echo "ERROR: server is not ready after ${READY_WAIT_SECONDS}s:"
+        curl -sk --max-time 10 "$READY_URL"
+        echo
+        exit 1
+    fi
+    sleep 2
+    WAITED=$((WAITED + 2))
+done
 echo "OK!"
@@ -36,12 +36,20 @@ nohup ./dist/server > server.log 2>&1 &
 NEW_PID=$!
 echo $NEW_PID > "$PID_FILE"
 
-# Wait a moment and verify it started
-sleep 2
-if ps -p $NEW_PID > /dev/null 2>&1; then
-    echo "✅ Server started successfully with PID: $NEW_PID"
-    echo "📋 Check logs: tail -f $DEST_DIR/server.log"
-else
-    echo "❌ Server failed to start, check logs"
-    exit 1
-fi
\ No newline at end of file
+# Wait until the server reports ready
+READY_WAIT_SECONDS=${READY_WAIT_SECONDS:-60}
+WAITED=0
+until curl -sfk -o /dev/null --max-time 10 "http://127.0.0.1:$PORT/readyz" || curl -sfk -o /dev/null --max-time 10 "https://127.0.0.1:$PORT/readyz"; do
+    if ! ps -p $NEW_PID > /dev/null 2>&1; then
+        echo "❌ Server failed to start, check logs"
+        exit 1
+    fi
+    if [ $WAITED -ge $READY_WAIT_SECONDS ]; then
+        echo "❌ Server is not ready after ${READY_WAIT_SECONDS}s, check GET /readyz and logs"
+        exit 1
+    fi
+    sleep 2
+    WAITED=$((WAITED + 2))
+done
+echo "✅ Server started successfully with PID: $NEW_PID"
+echo "📋 Check logs: tail -f $DEST_DIR/server.log"
\ No newline at end of file
@@ -1,26 +1,42 @@
 #!/bin/sh
-# cd /apps/kokka
+# Stop the server gracefully: SIGTERM makes it refuse new writes, finish in-flight ones
+# and stop background jobs (up to SHUTDOWN_TIMEOUT_SECONDS) before exiting
 
-# echo "Killing old server..."
-# Only attempt to kill the process if the process same as PID file is running
-# kill $(cat /apps/kokka/gosvr.pid) 2>/dev/null
+PID_FILE="/apps/kokka/gosvr.pid"
+# Give the server its shutdown deadline plus a margin before forcing it
+STOP_WAIT_SECONDS=${STOP_WAIT_SECONDS:-60}
 
-ps -ef | grep kokkasvr | grep -v grep
-
-PID_TO_KILL=$(ps -ef | grep 'kokkasvr' | grep -v 'grep' | awk '{print $2; exit}')
-
-if [ -n "${PID_TO_KILL}" ]
+PID_TO_KILL=""
+if [ -f "${PID_FILE}" ] && kill -0 "$(cat ${PID_FILE})" 2>/dev/null
 then
-  echo "found pid $PID_TO_KILL"
-  echo "kill -HUP $PID_TO_KILL"
-
-  #sudo kill -HUP $PID_TO_KILL
-  kill -HUP $PID_TO_KILL
-
-  ps -ef | grep kokkasvr | grep -v grep
+  PID_TO_KILL=$(cat ${PID_FILE})
 else
-  echo "no pid found. server might not be running"
+  PID_TO_KILL=$(ps -ef | grep 'dist/server' | grep -v 'grep' | awk '{print $2; exit}')
 fi
 
+if [ -z "${PID_TO_KILL}" ]
+then
+  echo "no pid found. server might not be running"
+  echo "OK!"
+  exit 0
+fi
 
+echo "found pid $PID_TO_KILL"
+echo "kill -TERM $PID_TO_KILL"
+kill -TERM $PID_TO_KILL
+
+WAITED=0
+while kill -0 $PID_TO_KILL 2>/dev/null
+do
+  if [ $WAITED -ge $STOP_WAIT_SECONDS ]
+  then
+    echo "WARNING: server did not stop within ${STOP_WAIT_SECONDS}s, sending KILL"
+    kill -KILL $PID_TO_KILL
+    break
+  fi
+  sleep 1
+  WAITED=$((WAITED + 1))
+done
+
+rm -f ${PID_FILE}
 echo "OK!"